@@ -17,11 +17,15 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	velero "github.com/vmware-tanzu/velero/pkg/apis/velero/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 
 	"github.com/openshift/oadp-operator/pkg/common"
 	"github.com/openshift/oadp-operator/pkg/velero/server"
@@ -33,6 +37,26 @@ const ReconciledReasonComplete = "Complete"
 const ReconciledReasonError = "Error"
 const ReconcileCompleteMessage = "Reconcile complete"
 
+// ConditionValidated reports the validation result of DPAs reconciled in validate only mode
+const ConditionValidated = "Validated"
+const ValidatedReasonPassed = "Passed"
+const ValidatedReasonFailed = "Failed"
+const ValidatedPassedMessage = "DPA configuration is valid"
+
+// ConditionFeatureFlagsRecognized reports whether velero recognizes every feature flag of the DPA, velero ignores
+// unrecognized flags
+const ConditionFeatureFlagsRecognized = "FeatureFlagsRecognized"
+const FeatureFlagsReasonRecognized = "Recognized"
+const FeatureFlagsReasonUnrecognized = "Unrecognized"
+const FeatureFlagsRecognizedMessage = "All feature flags are recognized"
+
+// ConditionBackupLocationConfigRecognized reports whether the provider plugins recognize every config key of the
+// velero backup locations, plugins ignore unrecognized keys
+const ConditionBackupLocationConfigRecognized = "BackupLocationConfigRecognized"
+const BackupLocationConfigReasonRecognized = "Recognized"
+const BackupLocationConfigReasonUnrecognized = "Unrecognized"
+const BackupLocationConfigRecognizedMessage = "All backup location config keys are recognized"
+
 const OadpOperatorLabel = "openshift.io/oadp"
 const RegistryDeploymentLabel = "openshift.io/oadp-registry"
 
@@ -64,6 +88,7 @@ const ResticRestoreImageKey UnsupportedImageKey = "resticRestoreImageFqin"
 const KubeVirtPluginImageKey UnsupportedImageKey = "kubevirtPluginImageFqin"
 const NonAdminControllerImageKey UnsupportedImageKey = "nonAdminControllerImageFqin"
 const OperatorTypeKey UnsupportedImageKey = "operator-type"
+const MaxPluginCountKey UnsupportedImageKey = "max-plugin-count"
 
 const OperatorTypeMTC = "mtc"
 
@@ -105,19 +130,121 @@ type VeleroConfig struct {
 	DisableInformerCache *bool `json:"disableInformerCache,omitempty"`
 	// resourceTimeout defines how long to wait for several Velero resources before timeout occurs,
 	// such as Velero CRD availability, volumeSnapshot deletion, and repo availability.
-	// Default is 10m
+	// Default is 10m. Must not be lower than defaultItemOperationTimeout when both are set.
 	// +optional
 	ResourceTimeout string `json:"resourceTimeout,omitempty"`
+	// serviceAccountTokenAudience is the audience of the service account token projected into the Velero pod.
+	// Setting this field projects the token even when no backup location uses short lived credentials,
+	// which is required when a cloud OIDC trust expects a specific audience. Default audience is openshift.
+	// +optional
+	ServiceAccountTokenAudience string `json:"serviceAccountTokenAudience,omitempty"`
+	// serviceAccountTokenExpirationSeconds is the requested lifetime of the service account token projected into
+	// the Velero pod, between 600 (10 minutes) and 4294967296 (2^32) seconds. Setting this field projects the token
+	// even when no backup location uses short lived credentials. Default is 3600.
+	// +optional
+	ServiceAccountTokenExpirationSeconds *int64 `json:"serviceAccountTokenExpirationSeconds,omitempty"`
+	// restoreOnlyMode runs Velero with the backup, backup deletion, garbage collection and schedule controllers disabled,
+	// so a disaster recovery cluster can restore from shared backup storage without writing backups to it.
+	// Cannot be enabled while unpaused schedules exist in the namespace.
+	// +optional
+	RestoreOnlyMode *bool `json:"restoreOnlyMode,omitempty"`
+	// disableGarbageCollection disables the Velero garbage collection controller, so expired backups are not deleted
+	// and backup expiry has to be managed manually. Cannot be enabled while unpaused schedules set a backup ttl.
+	// +optional
+	DisableGarbageCollection *bool `json:"disableGarbageCollection,omitempty"`
+	// restoreResourcePrioritiesConfigMap is the name of a ConfigMap in the DPA namespace whose restoreResourcePriorities key
+	// holds the comma separated restore resource priorities passed to Velero instead of the OADP defaults.
+	// +optional
+	RestoreResourcePrioritiesConfigMap string `json:"restoreResourcePrioritiesConfigMap,omitempty"`
+	// pluginsVolume configures the volume Velero plugin binaries are copied into. Default is an unsized emptyDir.
+	// +optional
+	PluginsVolume *PluginsVolumeConfig `json:"pluginsVolume,omitempty"`
+	// podDisruptionBudget creates a PodDisruptionBudget for the Velero deployment when set,
+	// so voluntary disruptions such as node drains during cluster upgrades do not interrupt running backups.
+	// +optional
+	PodDisruptionBudget *PodDisruptionBudgetConfig `json:"podDisruptionBudget,omitempty"`
+	// command overrides the Velero container entrypoint, for example with a wrapper script of a hardened image.
+	// The server args managed by the operator are passed to the command unchanged, so it must invoke the velero binary.
+	// +optional
+	Command []string `json:"command,omitempty"`
+	// pluginConfigFiles mount ConfigMaps from the DPA namespace into the Velero pod, for plugins reading their
+	// settings from a config file
+	// +optional
+	PluginConfigFiles []PluginConfigFile `json:"pluginConfigFiles,omitempty"`
+	// sysctls are namespaced kernel parameters set on the Velero pod securityContext, for example network tuning
+	// for large restores. Only the sysctls kubernetes considers safe are allowed.
+	// +optional
+	Sysctls []corev1.Sysctl `json:"sysctls,omitempty"`
+	// readOnlyRootFilesystem runs the Velero container with a read-only root filesystem. The temporary files Velero
+	// writes, such as backup tarballs and plugin sockets, are written to an emptyDir mounted at /tmp instead.
+	// +optional
+	ReadOnlyRootFilesystem *bool `json:"readOnlyRootFilesystem,omitempty"`
+	// profilerAddress is the host:port address the Velero pprof profiler listens on, for example :6060 to reach it
+	// from outside the pod. As the profiler is unauthenticated, it is only applied when the DPA has the
+	// oadp.openshift.io/enable-velero-profiler annotation set to true. Velero default is localhost:6060.
+	// +optional
+	ProfilerAddress string `json:"profilerAddress,omitempty"`
+	// logFile also writes the Velero server logs to a file on a volume mounted in the Velero pod, for log collectors
+	// reading log files. Logs are still written to stdout. The Velero image must provide bash and tee, as the OADP image does.
+	// +optional
+	LogFile *LogFileConfig `json:"logFile,omitempty"`
 	// Velero args are settings to customize velero server arguments. Overrides values in other fields.
 	// +optional
 	Args *server.Args `json:"args,omitempty"`
 }
 
+// PluginConfigFile defines a ConfigMap mounted into the Velero pod as plugin config files
+type PluginConfigFile struct {
+	// configMap is the name of a ConfigMap in the DPA namespace, each key is mounted as a file
+	ConfigMap string `json:"configMap"`
+	// mountPath is the absolute path of the directory the ConfigMap keys are mounted into
+	MountPath string `json:"mountPath"`
+}
+
+// PluginsVolumeConfig defines the volume holding Velero plugin binaries
+type PluginsVolumeConfig struct {
+	// sizeLimit is the size limit of the plugins emptyDir volume, for example 1Gi
+	// +optional
+	SizeLimit string `json:"sizeLimit,omitempty"`
+	// persistentVolumeClaim is the name of a PersistentVolumeClaim in the DPA namespace backing the plugins volume
+	// instead of an emptyDir. Cannot be used together with sizeLimit.
+	// +optional
+	PersistentVolumeClaim string `json:"persistentVolumeClaim,omitempty"`
+}
+
+// LogFileConfig defines the volume the Velero server logs are written to
+type LogFileConfig struct {
+	// mountPath is the absolute path of the directory the log volume is mounted at, logs are written to velero.log in it.
+	// velero.log is rotated to velero.log.1 when the Velero pod starts and once it reaches 100Mi, or a quarter of
+	// sizeLimit if smaller.
+	MountPath string `json:"mountPath"`
+	// sizeLimit is the size limit of the log emptyDir volume, for example 1Gi
+	// +optional
+	SizeLimit string `json:"sizeLimit,omitempty"`
+	// persistentVolumeClaim is the name of a PersistentVolumeClaim in the DPA namespace backing the log volume
+	// instead of an emptyDir. Cannot be used together with sizeLimit.
+	// +optional
+	PersistentVolumeClaim string `json:"persistentVolumeClaim,omitempty"`
+}
+
+// PodDisruptionBudgetConfig defines the PodDisruptionBudget created for the Velero deployment
+type PodDisruptionBudgetConfig struct {
+	// minAvailable is the number or percentage of Velero pods that must remain available during voluntary disruptions.
+	// Cannot exceed the Velero replica count. Defaults to 1.
+	// +optional
+	MinAvailable *intstr.IntOrString `json:"minAvailable,omitempty"`
+}
+
 // PodConfig defines the pod configuration options
 type PodConfig struct {
-	// labels to add to pods
+	// labels to add to pods. Labels under app.kubernetes.io/ and the labels OADP uses to select the pods,
+	// such as component, are reserved and cannot be set
 	// +optional
 	Labels map[string]string `json:"labels,omitempty"`
+	// annotations to add to pods, such as sidecar.istio.io/inject: "false" to opt out of sidecar injection.
+	// Added to the podAnnotations set for all pods deployed by the operator, and cannot change their values.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
 	// nodeSelector defines the nodeSelector to be supplied to podSpec
 	// +optional
 	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
@@ -131,6 +258,15 @@ type PodConfig struct {
 	// env defines the list of environment variables to be supplied to podSpec
 	// +optional
 	Env []corev1.EnvVar `json:"env,omitempty"`
+	// maxUnavailable is the maximum number of node agent pods that can be unavailable during a rollout,
+	// as an absolute number or a percentage of nodes. Only applies to the node agent daemonset. Defaults to 1.
+	// +optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+	// shareProcessNamespace shares a single process namespace between the containers of the pod, so debugging
+	// sidecars can see and signal the velero process. Only applies to the velero deployment, as the node agent
+	// container is privileged.
+	// +optional
+	ShareProcessNamespace *bool `json:"shareProcessNamespace,omitempty"`
 }
 
 type NodeAgentCommonFields struct {
@@ -144,6 +280,10 @@ type NodeAgentCommonFields struct {
 	// timeout defines the NodeAgent timeout, default value is 1h
 	// +optional
 	Timeout string `json:"timeout,omitempty"`
+	// hostPID runs the NodeAgent Pod in the host process namespace, as required by some CSI drivers. The
+	// privileged NodeAgent containers can then see and signal every process of their node.
+	// +optional
+	HostPID *bool `json:"hostPID,omitempty"`
 	// Pod specific configuration
 	PodConfig *PodConfig `json:"podConfig,omitempty"`
 }
@@ -158,6 +298,11 @@ type NodeAgentConfig struct {
 	// +kubebuilder:validation:Enum=restic;kopia
 	// +kubebuilder:validation:Required
 	UploaderType string `json:"uploaderType"`
+
+	// metrics exposes the node agent Prometheus metrics, including the pod volume backup and restore latency
+	// of each volume, on port 8085 through the openshift-adp-node-agent-metrics-svc Service
+	// +optional
+	Metrics *bool `json:"metrics,omitempty"`
 }
 
 // ResticConfig is the configuration for restic server
@@ -224,6 +369,10 @@ type BackupLocation struct {
 	Velero *velero.BackupStorageLocationSpec `json:"velero,omitempty"`
 	// +optional
 	CloudStorage *CloudStorageLocation `json:"bucket,omitempty"`
+	// credentialMountPath mounts the credential secret of this backup location into the Velero pod at the given
+	// absolute path, for plugins that expect credentials at a nonstandard location.
+	// +optional
+	CredentialMountPath string `json:"credentialMountPath,omitempty"`
 }
 
 // SnapshotLocation defines the configuration for the DPA snapshot store
@@ -266,6 +415,7 @@ type DataProtectionApplicationSpec struct {
 	//   - kubevirtPluginImageFqin
 	//   - nonAdminControllerImageFqin
 	//   - operator-type
+	//   - max-plugin-count
 	// +optional
 	UnsupportedOverrides map[UnsupportedImageKey]string `json:"unsupportedOverrides,omitempty"`
 	// add annotations to pods deployed by operator
@@ -288,11 +438,204 @@ type DataProtectionApplicationSpec struct {
 	// features defines the configuration for the DPA to enable the OADP tech preview features
 	// +optional
 	Features *Features `json:"features"`
+	// cleanupOnDeletion removes the BackupStorageLocations and VolumeSnapshotLocations created for this DPA,
+	// the BackupRepositories of those BackupStorageLocations, and the mirrored BackupStorageLocations, when
+	// the DPA is deleted
+	// +optional
+	CleanupOnDeletion *bool `json:"cleanupOnDeletion,omitempty"`
+	// backupLocationFailover makes a secondary backup location the default while the primary is unavailable
+	// +optional
+	BackupLocationFailover *BackupLocationFailover `json:"backupLocationFailover,omitempty"`
+	// backupLocationMirror creates a read only copy of each BackupStorageLocation of this DPA in another namespace,
+	// such as the namespace of a velero instance used for disaster recovery
+	// +optional
+	BackupLocationMirror *BackupLocationMirror `json:"backupLocationMirror,omitempty"`
+	// backupMaintenanceWindow pauses the schedules in the DPA namespace during a daily maintenance window
+	// +optional
+	BackupMaintenanceWindow *BackupMaintenanceWindow `json:"backupMaintenanceWindow,omitempty"`
+	// egressNetworkPolicy creates a NetworkPolicy allowing egress of the Velero and node agent pods to the given
+	// endpoints, for namespaces with a default deny network policy
+	// +optional
+	EgressNetworkPolicy *EgressNetworkPolicy `json:"egressNetworkPolicy,omitempty"`
+}
+
+// BackupLocationFailover defines the backup locations used for failover of the default backup location
+type BackupLocationFailover struct {
+	// primary is the name of the default backup location
+	Primary string `json:"primary"`
+	// secondary is the name of the backup location made default while the primary is unavailable
+	Secondary string `json:"secondary"`
+}
+
+// BackupLocationMirror defines the namespace the BackupStorageLocations of a DPA are mirrored to. The credential
+// secrets of the backup locations are not copied and must exist in that namespace.
+type BackupLocationMirror struct {
+	// namespace the read only BackupStorageLocations are created in, an existing namespace other than the DPA namespace
+	Namespace string `json:"namespace"`
+}
+
+// BackupMaintenanceWindow defines a daily window during which schedules are paused. Schedules paused by the
+// operator are resumed when the window ends, schedules paused by the user are left paused.
+type BackupMaintenanceWindow struct {
+	// start is the UTC time of day the window starts at, in HH:MM format
+	Start string `json:"start"`
+	// duration of the window, less than 24h. The window may end on the next day.
+	Duration metav1.Duration `json:"duration"`
+}
+
+// EgressNetworkPolicy defines the endpoints Velero and node agent pods may connect to. DNS and kubernetes API server
+// egress is always allowed, other destinations must be allowed by additional endpoints or policies.
+type EgressNetworkPolicy struct {
+	// endpoints Velero and node agent pods may connect to, such as object storage
+	Endpoints []EgressEndpoint `json:"endpoints"`
+}
+
+// EgressEndpoint defines a network Velero and node agent pods may connect to
+type EgressEndpoint struct {
+	// cidr of the endpoint, for example 10.0.0.0/16, or 192.168.1.10/32 for a single address
+	CIDR string `json:"cidr"`
+	// ports of the endpoint connected to over TCP, all ports are allowed when empty
+	// +optional
+	Ports []int32 `json:"ports,omitempty"`
+}
+
+// CredentialDecision is the outcome of credential resolution for a default plugin
+// +kubebuilder:validation:Enum=NeedsCheck;Skipped;DefaultUsed
+type CredentialDecision string
+
+const (
+	// CredentialDecisionNeedsCheck means credentials referenced by the provider's locations are validated
+	CredentialDecisionNeedsCheck CredentialDecision = "NeedsCheck"
+	// CredentialDecisionSkipped means no credentials are validated for the provider
+	CredentialDecisionSkipped CredentialDecision = "Skipped"
+	// CredentialDecisionDefaultUsed means the provider's default cloud credentials secret is validated and used
+	CredentialDecisionDefaultUsed CredentialDecision = "DefaultUsed"
+)
+
+// ProviderCredentialResolution records the credential decision made for a default plugin
+type ProviderCredentialResolution struct {
+	// provider is the default plugin the decision applies to
+	Provider DefaultPlugin `json:"provider"`
+	// decision is the outcome of credential resolution for the provider
+	Decision CredentialDecision `json:"decision"`
+}
+
+// ValidationErrorCode identifies a DPA validation check, codes do not change between releases
+type ValidationErrorCode string
+
+const (
+	// ValidationErrorCodeInvalidSpec means the DPA spec is inconsistent, such as backup locations set with noDefaultBackupLocation
+	ValidationErrorCodeInvalidSpec ValidationErrorCode = "InvalidSpec"
+	// ValidationErrorCodeInvalidBackupMaintenanceWindow means the backupMaintenanceWindow start or duration is invalid
+	ValidationErrorCodeInvalidBackupMaintenanceWindow ValidationErrorCode = "InvalidBackupMaintenanceWindow"
+	// ValidationErrorCodeDuplicateBackupLocationName means backup locations resolve to the same BSL name
+	ValidationErrorCodeDuplicateBackupLocationName ValidationErrorCode = "DuplicateBackupLocationName"
+	// ValidationErrorCodeInvalidBackupStorageLocation means a backup storage location is invalid
+	ValidationErrorCodeInvalidBackupStorageLocation ValidationErrorCode = "InvalidBackupStorageLocation"
+	// ValidationErrorCodeInvalidVolumeSnapshotLocation means a volume snapshot location is invalid
+	ValidationErrorCodeInvalidVolumeSnapshotLocation ValidationErrorCode = "InvalidVolumeSnapshotLocation"
+	// ValidationErrorCodeInvalidPluginCredential means the credentials secret of a default plugin is missing or invalid
+	ValidationErrorCodeInvalidPluginCredential ValidationErrorCode = "InvalidPluginCredential"
+	// ValidationErrorCodeTooManyPlugins means more plugins are configured than allowed
+	ValidationErrorCodeTooManyPlugins ValidationErrorCode = "TooManyPlugins"
+	// ValidationErrorCodePluginRequiredAPIUnavailable means a default plugin requires an API the cluster does not serve
+	ValidationErrorCodePluginRequiredAPIUnavailable ValidationErrorCode = "PluginRequiredAPIUnavailable"
+	// ValidationErrorCodeCustomPluginOverlap means a custom plugin overlaps with a default plugin
+	ValidationErrorCodeCustomPluginOverlap ValidationErrorCode = "CustomPluginOverlap"
+	// ValidationErrorCodeInvalidPluginImage means a default plugin has no image in the image set the operator was installed with
+	ValidationErrorCodeInvalidPluginImage ValidationErrorCode = "InvalidPluginImage"
+	// ValidationErrorCodeInvalidServiceAccountToken means the service account token audience or expiration is invalid
+	ValidationErrorCodeInvalidServiceAccountToken ValidationErrorCode = "InvalidServiceAccountToken"
+	// ValidationErrorCodeInvalidClientPageSize means the velero client-page-size arg is invalid
+	ValidationErrorCodeInvalidClientPageSize ValidationErrorCode = "InvalidClientPageSize"
+	// ValidationErrorCodeUnsafeSysctl means a sysctl set on the velero pod is not in the safe set
+	ValidationErrorCodeUnsafeSysctl ValidationErrorCode = "UnsafeSysctl"
+	// ValidationErrorCodeInvalidPluginConfigFile means a plugin config file ConfigMap is missing or its mount path is invalid
+	ValidationErrorCodeInvalidPluginConfigFile ValidationErrorCode = "InvalidPluginConfigFile"
+	// ValidationErrorCodeRemovedFeatureFlag means a feature flag was removed from velero
+	ValidationErrorCodeRemovedFeatureFlag ValidationErrorCode = "RemovedFeatureFlag"
+	// ValidationErrorCodeInvalidItemOperationTimeout means defaultItemOperationTimeout is invalid
+	ValidationErrorCodeInvalidItemOperationTimeout ValidationErrorCode = "InvalidItemOperationTimeout"
+	// ValidationErrorCodeInvalidVeleroCommand means the velero command override does not run the velero binary
+	ValidationErrorCodeInvalidVeleroCommand ValidationErrorCode = "InvalidVeleroCommand"
+	// ValidationErrorCodeInvalidProfilerAddress means the velero profilerAddress is invalid or not enabled
+	ValidationErrorCodeInvalidProfilerAddress ValidationErrorCode = "InvalidProfilerAddress"
+	// ValidationErrorCodeConflictingListenPorts means the velero metrics and profiler servers listen on the same port
+	ValidationErrorCodeConflictingListenPorts ValidationErrorCode = "ConflictingListenPorts"
+	// ValidationErrorCodeInvalidRestoreOnlyMode means restore only mode is enabled while unpaused schedules exist
+	ValidationErrorCodeInvalidRestoreOnlyMode ValidationErrorCode = "InvalidRestoreOnlyMode"
+	// ValidationErrorCodeInvalidGarbageCollection means garbage collection is disabled while schedules set a backup ttl
+	ValidationErrorCodeInvalidGarbageCollection ValidationErrorCode = "InvalidGarbageCollection"
+	// ValidationErrorCodeInvalidNodeAgentMaxUnavailable means the node agent maxUnavailable is invalid
+	ValidationErrorCodeInvalidNodeAgentMaxUnavailable ValidationErrorCode = "InvalidNodeAgentMaxUnavailable"
+	// ValidationErrorCodeDataMoverRequiresNodeAgent means snapshot data movement is enabled by default while the node agent is disabled
+	ValidationErrorCodeDataMoverRequiresNodeAgent ValidationErrorCode = "DataMoverRequiresNodeAgent"
+	// ValidationErrorCodeMissingBackupStorageLocation means file system backup or snapshot data movement is enabled by
+	// default while no backup storage location exists to store volume data
+	ValidationErrorCodeMissingBackupStorageLocation ValidationErrorCode = "MissingBackupStorageLocation"
+	// ValidationErrorCodeInvalidPodDisruptionBudget means the velero pod disruption budget minAvailable cannot be satisfied by the velero replicas
+	ValidationErrorCodeInvalidPodDisruptionBudget ValidationErrorCode = "InvalidPodDisruptionBudget"
+	// ValidationErrorCodeInvalidEgressNetworkPolicy means the egress network policy is invalid
+	ValidationErrorCodeInvalidEgressNetworkPolicy ValidationErrorCode = "InvalidEgressNetworkPolicy"
+	// ValidationErrorCodeReservedPodLabel means a podConfig label is reserved by OADP
+	ValidationErrorCodeReservedPodLabel ValidationErrorCode = "ReservedPodLabel"
+	// ValidationErrorCodeInvalidPodScheduling means a podConfig nodeSelector entry or toleration is invalid
+	ValidationErrorCodeInvalidPodScheduling ValidationErrorCode = "InvalidPodScheduling"
+	// ValidationErrorCodeInvalidRestoreResourcePriorities means the restore resource priorities are invalid
+	ValidationErrorCodeInvalidRestoreResourcePriorities ValidationErrorCode = "InvalidRestoreResourcePriorities"
+	// ValidationErrorCodeInvalidResourceAllocations means the velero or node agent resource allocations are invalid
+	ValidationErrorCodeInvalidResourceAllocations ValidationErrorCode = "InvalidResourceAllocations"
+	// ValidationErrorCodeInvalidPluginsVolume means the plugins volume is invalid
+	ValidationErrorCodeInvalidPluginsVolume ValidationErrorCode = "InvalidPluginsVolume"
+	// ValidationErrorCodeConflictingFeatureFlag means a feature flag managed by OADP conflicts with the DPA configuration
+	ValidationErrorCodeConflictingFeatureFlag ValidationErrorCode = "ConflictingFeatureFlag"
+	// ValidationErrorCodeInvalidShareProcessNamespace means shareProcessNamespace conflicts with the security context of the pod
+	ValidationErrorCodeInvalidShareProcessNamespace ValidationErrorCode = "InvalidShareProcessNamespace"
+	// ValidationErrorCodeInvalidPodAnnotation means a podConfig annotation is invalid
+	ValidationErrorCodeInvalidPodAnnotation ValidationErrorCode = "InvalidPodAnnotation"
+	// ValidationErrorCodeInvalidNodeAgentTimeout means the restic or node agent timeout is not a positive duration
+	ValidationErrorCodeInvalidNodeAgentTimeout ValidationErrorCode = "InvalidNodeAgentTimeout"
+	// ValidationErrorCodeInvalidResourceTimeout means resourceTimeout is invalid or lower than defaultItemOperationTimeout
+	ValidationErrorCodeInvalidResourceTimeout ValidationErrorCode = "InvalidResourceTimeout"
+	// ValidationErrorCodeInvalidImageOverride means an image override is not a valid image reference with a tag or digest
+	ValidationErrorCodeInvalidImageOverride ValidationErrorCode = "InvalidImageOverride"
+	// ValidationErrorCodeInvalidLogFile means the velero log file volume is invalid
+	ValidationErrorCodeInvalidLogFile ValidationErrorCode = "InvalidLogFile"
+)
+
+// ValidationError is a DPA validation failure
+type ValidationError struct {
+	// code identifies the failed check
+	Code ValidationErrorCode `json:"code"`
+	// message describes the failure
+	Message string `json:"message"`
 }
 
 // DataProtectionApplicationStatus defines the observed state of DataProtectionApplication
 type DataProtectionApplicationStatus struct {
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// credentialResolutions lists the credential decision made for each default plugin
+	// +optional
+	CredentialResolutions []ProviderCredentialResolution `json:"credentialResolutions,omitempty"`
+	// validationErrors lists the failures of the last DPA validation, empty when the DPA is valid
+	// +optional
+	ValidationErrors []ValidationError `json:"validationErrors,omitempty"`
+	// veleroServerArgs lists the arguments of the velero server at the last successful reconcile, with the flags sorted
+	// +optional
+	VeleroServerArgs []string `json:"veleroServerArgs,omitempty"`
+	// mirroredBackupLocationsNamespace is the namespace the BackupStorageLocations were mirrored to at the last successful reconcile
+	// +optional
+	MirroredBackupLocationsNamespace string `json:"mirroredBackupLocationsNamespace,omitempty"`
+	// veleroImage is the image of the velero deployment at the last successful reconcile, including image overrides
+	// +optional
+	VeleroImage string `json:"veleroImage,omitempty"`
+	// pluginsInstalled lists the plugins with an init container in the velero deployment at the last successful reconcile,
+	// by default plugin name, such as aws, or custom plugin name
+	// +optional
+	PluginsInstalled []string `json:"pluginsInstalled,omitempty"`
+	// pluginsFailedCredentialValidation lists the default plugins whose credentials secrets failed validation at the last reconcile
+	// +optional
+	PluginsFailedCredentialValidation []string `json:"pluginsFailedCredentialValidation,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -322,6 +665,11 @@ func (dpa *DataProtectionApplication) BackupImages() bool {
 	return dpa.Spec.BackupImages == nil || *dpa.Spec.BackupImages
 }
 
+// IsOperatorTypeMTC returns true if the operator type override selects MTC, ignoring case
+func (dpa *DataProtectionApplication) IsOperatorTypeMTC() bool {
+	return strings.EqualFold(dpa.Spec.UnsupportedOverrides[OperatorTypeKey], OperatorTypeMTC)
+}
+
 // Default DisableInformerCache behavior when nil to false
 func (dpa *DataProtectionApplication) GetDisableInformerCache() bool {
 	if dpa.Spec.Configuration.Velero.DisableInformerCache == nil {
@@ -330,6 +678,55 @@ func (dpa *DataProtectionApplication) GetDisableInformerCache() bool {
 	return *dpa.Spec.Configuration.Velero.DisableInformerCache
 }
 
+// ValidateSpec validates the DPA spec without accessing the cluster, so it can be used before the DPA is
+// admitted. Checks that depend on secrets or other cluster resources are left to the reconciler.
+func (dpa *DataProtectionApplication) ValidateSpec() error {
+	if dpa.Spec.Configuration == nil || dpa.Spec.Configuration.Velero == nil {
+		return errors.New("DPA CR Velero configuration cannot be nil")
+	}
+
+	if dpa.Spec.Configuration.Restic != nil && dpa.Spec.Configuration.NodeAgent != nil {
+		return errors.New("DPA CR cannot have restic (deprecated in OADP 1.3) as well as nodeAgent options at the same time")
+	}
+
+	if dpa.Spec.Configuration.Velero.NoDefaultBackupLocation {
+		if len(dpa.Spec.BackupLocations) != 0 {
+			return errors.New("DPA CR Velero configuration cannot have backup locations if noDefaultBackupLocation is set")
+		}
+		if dpa.BackupImages() {
+			return errors.New("backupImages needs to be set to false when noDefaultBackupLocation is set")
+		}
+	} else {
+		if len(dpa.Spec.BackupLocations) == 0 {
+			return errors.New("no backupstoragelocations configured, ensure a backupstoragelocation has been configured or use the noDefaultBackupLocation flag")
+		}
+	}
+
+	for i := range dpa.Spec.BackupLocations {
+		if err := dpa.Spec.BackupLocations[i].ValidateVeleroOrCloudStorage(); err != nil {
+			return err
+		}
+	}
+
+	if val, found := dpa.Spec.UnsupportedOverrides[OperatorTypeKey]; found && !dpa.IsOperatorTypeMTC() {
+		return fmt.Errorf("unsupported operator type override %q, only %s operator type override is supported", val, OperatorTypeMTC)
+	}
+	return nil
+}
+
+// ValidateVeleroOrCloudStorage checks the backup location is configured with exactly one of velero or bucket
+func (bsl *BackupLocation) ValidateVeleroOrCloudStorage() error {
+	if bsl.CloudStorage == nil && bsl.Velero == nil {
+		return fmt.Errorf("BackupLocation must have velero or bucket configuration")
+	}
+
+	// velero objectStorage and the cloudStorage bucket both describe where backups are stored
+	if bsl.CloudStorage != nil && bsl.Velero != nil {
+		return fmt.Errorf("BackupLocation cannot have both velero and cloudStorage configuration, velero objectStorage and cloudStorage bucket %s are mutually exclusive", bsl.CloudStorage.CloudStorageRef.Name)
+	}
+	return nil
+}
+
 func (veleroConfig *VeleroConfig) HasFeatureFlag(flag string) bool {
 	for _, featureFlag := range veleroConfig.FeatureFlags {
 		if featureFlag == flag {
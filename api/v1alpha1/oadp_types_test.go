@@ -0,0 +1,109 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	velero "github.com/vmware-tanzu/velero/pkg/apis/velero/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/pointer"
+)
+
+func TestDataProtectionApplication_ValidateSpec(t *testing.T) {
+	veleroLocation := BackupLocation{Velero: &velero.BackupStorageLocationSpec{Provider: "aws"}}
+	tests := []struct {
+		name           string
+		spec           DataProtectionApplicationSpec
+		wantErrMessage string
+	}{
+		{
+			name: "valid spec",
+			spec: DataProtectionApplicationSpec{
+				Configuration:   &ApplicationConfig{Velero: &VeleroConfig{}},
+				BackupLocations: []BackupLocation{veleroLocation},
+			},
+		},
+		{
+			name:           "velero configuration not set",
+			spec:           DataProtectionApplicationSpec{Configuration: &ApplicationConfig{}},
+			wantErrMessage: "DPA CR Velero configuration cannot be nil",
+		},
+		{
+			name: "restic and nodeAgent both set",
+			spec: DataProtectionApplicationSpec{
+				Configuration: &ApplicationConfig{
+					Velero:    &VeleroConfig{},
+					Restic:    &ResticConfig{},
+					NodeAgent: &NodeAgentConfig{},
+				},
+				BackupLocations: []BackupLocation{veleroLocation},
+			},
+			wantErrMessage: "DPA CR cannot have restic (deprecated in OADP 1.3) as well as nodeAgent options at the same time",
+		},
+		{
+			name: "backup locations with noDefaultBackupLocation",
+			spec: DataProtectionApplicationSpec{
+				Configuration:   &ApplicationConfig{Velero: &VeleroConfig{NoDefaultBackupLocation: true}},
+				BackupImages:    pointer.Bool(false),
+				BackupLocations: []BackupLocation{veleroLocation},
+			},
+			wantErrMessage: "DPA CR Velero configuration cannot have backup locations if noDefaultBackupLocation is set",
+		},
+		{
+			name: "backupImages with noDefaultBackupLocation",
+			spec: DataProtectionApplicationSpec{
+				Configuration: &ApplicationConfig{Velero: &VeleroConfig{NoDefaultBackupLocation: true}},
+			},
+			wantErrMessage: "backupImages needs to be set to false when noDefaultBackupLocation is set",
+		},
+		{
+			name: "no backup locations",
+			spec: DataProtectionApplicationSpec{
+				Configuration: &ApplicationConfig{Velero: &VeleroConfig{}},
+			},
+			wantErrMessage: "no backupstoragelocations configured, ensure a backupstoragelocation has been configured or use the noDefaultBackupLocation flag",
+		},
+		{
+			name: "backup location without velero or bucket configuration",
+			spec: DataProtectionApplicationSpec{
+				Configuration:   &ApplicationConfig{Velero: &VeleroConfig{}},
+				BackupLocations: []BackupLocation{{}},
+			},
+			wantErrMessage: "BackupLocation must have velero or bucket configuration",
+		},
+		{
+			name: "backup location with both velero and bucket configuration",
+			spec: DataProtectionApplicationSpec{
+				Configuration: &ApplicationConfig{Velero: &VeleroConfig{}},
+				BackupLocations: []BackupLocation{{
+					Velero:       &velero.BackupStorageLocationSpec{Provider: "aws"},
+					CloudStorage: &CloudStorageLocation{CloudStorageRef: corev1.LocalObjectReference{Name: "bucket"}},
+				}},
+			},
+			wantErrMessage: "BackupLocation cannot have both velero and cloudStorage configuration, velero objectStorage and cloudStorage bucket bucket are mutually exclusive",
+		},
+		{
+			name: "unsupported operator type override",
+			spec: DataProtectionApplicationSpec{
+				Configuration:        &ApplicationConfig{Velero: &VeleroConfig{}},
+				BackupLocations:      []BackupLocation{veleroLocation},
+				UnsupportedOverrides: map[UnsupportedImageKey]string{OperatorTypeKey: "other"},
+			},
+			wantErrMessage: "unsupported operator type override \"other\", only mtc operator type override is supported",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dpa := &DataProtectionApplication{Spec: tt.spec}
+			err := dpa.ValidateSpec()
+			if tt.wantErrMessage == "" {
+				if err != nil {
+					t.Errorf("ValidateSpec() unexpected error = %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErrMessage {
+				t.Errorf("ValidateSpec() error = %v, want %v", err, tt.wantErrMessage)
+			}
+		})
+	}
+}
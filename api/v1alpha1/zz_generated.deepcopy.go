@@ -27,6 +27,7 @@ import (
 	"k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -84,6 +85,52 @@ func (in *BackupLocation) DeepCopy() *BackupLocation {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupLocationFailover) DeepCopyInto(out *BackupLocationFailover) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupLocationFailover.
+func (in *BackupLocationFailover) DeepCopy() *BackupLocationFailover {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupLocationFailover)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupLocationMirror) DeepCopyInto(out *BackupLocationMirror) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupLocationMirror.
+func (in *BackupLocationMirror) DeepCopy() *BackupLocationMirror {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupLocationMirror)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupMaintenanceWindow) DeepCopyInto(out *BackupMaintenanceWindow) {
+	*out = *in
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupMaintenanceWindow.
+func (in *BackupMaintenanceWindow) DeepCopy() *BackupMaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupMaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CloudStorage) DeepCopyInto(out *CloudStorage) {
 	*out = *in
@@ -349,6 +396,31 @@ func (in *DataProtectionApplicationSpec) DeepCopyInto(out *DataProtectionApplica
 		*out = new(Features)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.CleanupOnDeletion != nil {
+		in, out := &in.CleanupOnDeletion, &out.CleanupOnDeletion
+		*out = new(bool)
+		**out = **in
+	}
+	if in.BackupLocationFailover != nil {
+		in, out := &in.BackupLocationFailover, &out.BackupLocationFailover
+		*out = new(BackupLocationFailover)
+		**out = **in
+	}
+	if in.BackupLocationMirror != nil {
+		in, out := &in.BackupLocationMirror, &out.BackupLocationMirror
+		*out = new(BackupLocationMirror)
+		**out = **in
+	}
+	if in.BackupMaintenanceWindow != nil {
+		in, out := &in.BackupMaintenanceWindow, &out.BackupMaintenanceWindow
+		*out = new(BackupMaintenanceWindow)
+		**out = **in
+	}
+	if in.EgressNetworkPolicy != nil {
+		in, out := &in.EgressNetworkPolicy, &out.EgressNetworkPolicy
+		*out = new(EgressNetworkPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataProtectionApplicationSpec.
@@ -371,6 +443,31 @@ func (in *DataProtectionApplicationStatus) DeepCopyInto(out *DataProtectionAppli
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.CredentialResolutions != nil {
+		in, out := &in.CredentialResolutions, &out.CredentialResolutions
+		*out = make([]ProviderCredentialResolution, len(*in))
+		copy(*out, *in)
+	}
+	if in.ValidationErrors != nil {
+		in, out := &in.ValidationErrors, &out.ValidationErrors
+		*out = make([]ValidationError, len(*in))
+		copy(*out, *in)
+	}
+	if in.VeleroServerArgs != nil {
+		in, out := &in.VeleroServerArgs, &out.VeleroServerArgs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PluginsInstalled != nil {
+		in, out := &in.PluginsInstalled, &out.PluginsInstalled
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PluginsFailedCredentialValidation != nil {
+		in, out := &in.PluginsFailedCredentialValidation, &out.PluginsFailedCredentialValidation
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataProtectionApplicationStatus.
@@ -383,6 +480,48 @@ func (in *DataProtectionApplicationStatus) DeepCopy() *DataProtectionApplication
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EgressEndpoint) DeepCopyInto(out *EgressEndpoint) {
+	*out = *in
+	if in.Ports != nil {
+		in, out := &in.Ports, &out.Ports
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EgressEndpoint.
+func (in *EgressEndpoint) DeepCopy() *EgressEndpoint {
+	if in == nil {
+		return nil
+	}
+	out := new(EgressEndpoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EgressNetworkPolicy) DeepCopyInto(out *EgressNetworkPolicy) {
+	*out = *in
+	if in.Endpoints != nil {
+		in, out := &in.Endpoints, &out.Endpoints
+		*out = make([]EgressEndpoint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EgressNetworkPolicy.
+func (in *EgressNetworkPolicy) DeepCopy() *EgressNetworkPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(EgressNetworkPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Features) DeepCopyInto(out *Features) {
 	*out = *in
@@ -403,6 +542,21 @@ func (in *Features) DeepCopy() *Features {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogFileConfig) DeepCopyInto(out *LogFileConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogFileConfig.
+func (in *LogFileConfig) DeepCopy() *LogFileConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(LogFileConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodeAgentCommonFields) DeepCopyInto(out *NodeAgentCommonFields) {
 	*out = *in
@@ -416,6 +570,11 @@ func (in *NodeAgentCommonFields) DeepCopyInto(out *NodeAgentCommonFields) {
 		*out = make([]int64, len(*in))
 		copy(*out, *in)
 	}
+	if in.HostPID != nil {
+		in, out := &in.HostPID, &out.HostPID
+		*out = new(bool)
+		**out = **in
+	}
 	if in.PodConfig != nil {
 		in, out := &in.PodConfig, &out.PodConfig
 		*out = new(PodConfig)
@@ -437,6 +596,11 @@ func (in *NodeAgentCommonFields) DeepCopy() *NodeAgentCommonFields {
 func (in *NodeAgentConfig) DeepCopyInto(out *NodeAgentConfig) {
 	*out = *in
 	in.NodeAgentCommonFields.DeepCopyInto(&out.NodeAgentCommonFields)
+	if in.Metrics != nil {
+		in, out := &in.Metrics, &out.Metrics
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeAgentConfig.
@@ -469,6 +633,36 @@ func (in *NonAdmin) DeepCopy() *NonAdmin {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PluginConfigFile) DeepCopyInto(out *PluginConfigFile) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PluginConfigFile.
+func (in *PluginConfigFile) DeepCopy() *PluginConfigFile {
+	if in == nil {
+		return nil
+	}
+	out := new(PluginConfigFile)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PluginsVolumeConfig) DeepCopyInto(out *PluginsVolumeConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PluginsVolumeConfig.
+func (in *PluginsVolumeConfig) DeepCopy() *PluginsVolumeConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(PluginsVolumeConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PodConfig) DeepCopyInto(out *PodConfig) {
 	*out = *in
@@ -479,6 +673,13 @@ func (in *PodConfig) DeepCopyInto(out *PodConfig) {
 			(*out)[key] = val
 		}
 	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.NodeSelector != nil {
 		in, out := &in.NodeSelector, &out.NodeSelector
 		*out = make(map[string]string, len(*in))
@@ -501,6 +702,16 @@ func (in *PodConfig) DeepCopyInto(out *PodConfig) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.ShareProcessNamespace != nil {
+		in, out := &in.ShareProcessNamespace, &out.ShareProcessNamespace
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodConfig.
@@ -513,6 +724,41 @@ func (in *PodConfig) DeepCopy() *PodConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodDisruptionBudgetConfig) DeepCopyInto(out *PodDisruptionBudgetConfig) {
+	*out = *in
+	if in.MinAvailable != nil {
+		in, out := &in.MinAvailable, &out.MinAvailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodDisruptionBudgetConfig.
+func (in *PodDisruptionBudgetConfig) DeepCopy() *PodDisruptionBudgetConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(PodDisruptionBudgetConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProviderCredentialResolution) DeepCopyInto(out *ProviderCredentialResolution) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderCredentialResolution.
+func (in *ProviderCredentialResolution) DeepCopy() *ProviderCredentialResolution {
+	if in == nil {
+		return nil
+	}
+	out := new(ProviderCredentialResolution)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResticConfig) DeepCopyInto(out *ResticConfig) {
 	*out = *in
@@ -549,6 +795,21 @@ func (in *SnapshotLocation) DeepCopy() *SnapshotLocation {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ValidationError) DeepCopyInto(out *ValidationError) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ValidationError.
+func (in *ValidationError) DeepCopy() *ValidationError {
+	if in == nil {
+		return nil
+	}
+	out := new(ValidationError)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VeleroConfig) DeepCopyInto(out *VeleroConfig) {
 	*out = *in
@@ -587,6 +848,56 @@ func (in *VeleroConfig) DeepCopyInto(out *VeleroConfig) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.ServiceAccountTokenExpirationSeconds != nil {
+		in, out := &in.ServiceAccountTokenExpirationSeconds, &out.ServiceAccountTokenExpirationSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.RestoreOnlyMode != nil {
+		in, out := &in.RestoreOnlyMode, &out.RestoreOnlyMode
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DisableGarbageCollection != nil {
+		in, out := &in.DisableGarbageCollection, &out.DisableGarbageCollection
+		*out = new(bool)
+		**out = **in
+	}
+	if in.PluginsVolume != nil {
+		in, out := &in.PluginsVolume, &out.PluginsVolume
+		*out = new(PluginsVolumeConfig)
+		**out = **in
+	}
+	if in.PodDisruptionBudget != nil {
+		in, out := &in.PodDisruptionBudget, &out.PodDisruptionBudget
+		*out = new(PodDisruptionBudgetConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PluginConfigFiles != nil {
+		in, out := &in.PluginConfigFiles, &out.PluginConfigFiles
+		*out = make([]PluginConfigFile, len(*in))
+		copy(*out, *in)
+	}
+	if in.Sysctls != nil {
+		in, out := &in.Sysctls, &out.Sysctls
+		*out = make([]v1.Sysctl, len(*in))
+		copy(*out, *in)
+	}
+	if in.ReadOnlyRootFilesystem != nil {
+		in, out := &in.ReadOnlyRootFilesystem, &out.ReadOnlyRootFilesystem
+		*out = new(bool)
+		**out = **in
+	}
+	if in.LogFile != nil {
+		in, out := &in.LogFile, &out.LogFile
+		*out = new(LogFileConfig)
+		**out = **in
+	}
 	if in.Args != nil {
 		in, out := &in.Args, &out.Args
 		*out = new(server.Args)
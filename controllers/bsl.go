@@ -1,28 +1,68 @@
 package controllers
 
 import (
+	"crypto/x509"
+	"encoding/pem"
 	"errors"
 	"fmt"
+	"os"
+	"path"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
 
+	mapset "github.com/deckarep/golang-set/v2"
 	"github.com/go-logr/logr"
 	velerov1 "github.com/vmware-tanzu/velero/pkg/apis/velero/v1"
 	corev1 "k8s.io/api/core/v1"
+	k8serror "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	oadpv1alpha1 "github.com/openshift/oadp-operator/api/v1alpha1"
 	"github.com/openshift/oadp-operator/pkg/common"
+	"github.com/openshift/oadp-operator/pkg/credentials"
 	"github.com/openshift/oadp-operator/pkg/storage/aws"
 )
 
+// velero validates backup locations every minute by default, so the primary of a backup location failover is
+// checked at the same interval
+const backupLocationFailoverRequeueInterval = time.Minute
+
+// BSLs mirrored to another namespace are labeled with the namespace of their DPA
+const oadpBackupLocationMirrorLabel = "oadp.openshift.io/mirrored-from-namespace"
+
+// CloudStorageValidateBucket is an operator only CloudStorage backup location config key, removed from the BSL
+// created for velero. When true the bucket is checked to be reachable during validation.
+const CloudStorageValidateBucket = "validateBucket"
+
+// replaced in tests, which have no access to a bucket
+var headBucket = aws.HeadBucket
+
+// providers the CloudStorage controller is able to create buckets for
+var supportedCloudStorageProviders = mapset.NewSet[oadpv1alpha1.CloudStorageProvider](oadpv1alpha1.AWSBucketProvider)
+
 func (r *DPAReconciler) ValidateBackupStorageLocations(dpa oadpv1alpha1.DataProtectionApplication) (bool, error) {
 	// Ensure BSL is a valid configuration
 	// First, check for provider and then call functions based on the cloud provider for each backupstoragelocation configured
 	numDefaultLocations := 0
-	for _, bslSpec := range dpa.Spec.BackupLocations {
+	if err := validateCredentialMountPaths(&dpa); err != nil {
+		return false, err
+	}
+	// unrecognized config keys are reported in the BackupLocationConfigRecognized condition
+	for _, message := range unrecognizedBackupLocationConfigKeys(&dpa) {
+		// V(-1) corresponds to the warn level
+		r.Log.V(-1).Info(message)
+	}
+	for i, bslSpec := range dpa.Spec.BackupLocations {
 
-		if err := r.ensureBackupLocationHasVeleroOrCloudStorage(&bslSpec); err != nil {
+		if err := bslSpec.ValidateVeleroOrCloudStorage(); err != nil {
 			return false, err
 		}
 
@@ -30,6 +70,22 @@ func (r *DPAReconciler) ValidateBackupStorageLocations(dpa oadpv1alpha1.DataProt
 			return false, err
 		}
 
+		if err := validatePrefixLength(&bslSpec); err != nil {
+			return false, err
+		}
+
+		if err := validateCredentialKey(&bslSpec); err != nil {
+			return false, err
+		}
+
+		if err := validateCACert(getBackupLocationName(&dpa, i), &bslSpec); err != nil {
+			return false, err
+		}
+
+		if err := validateBackupSyncPeriod(getBackupLocationName(&dpa, i), &bslSpec); err != nil {
+			return false, err
+		}
+
 		if err := r.ensureSecretDataExists(&dpa, &bslSpec); err != nil {
 			return false, err
 		}
@@ -45,6 +101,10 @@ func (r *DPAReconciler) ValidateBackupStorageLocations(dpa oadpv1alpha1.DataProt
 				return false, fmt.Errorf("no provider specified for one of the backupstoragelocations configured")
 			}
 
+			if err := validateBackupLocationConfig(getBackupLocationName(&dpa, i), bslSpec.Velero); err != nil {
+				return false, err
+			}
+
 			// TODO: cases might need some updates for IBM/Minio/noobaa
 			switch provider {
 			case AWSProvider, "velero.io/aws":
@@ -74,15 +134,18 @@ func (r *DPAReconciler) ValidateBackupStorageLocations(dpa oadpv1alpha1.DataProt
 			if bslSpec.CloudStorage.Credential.LocalObjectReference.Name == "" {
 				return false, fmt.Errorf("must provide a valid credential secret name")
 			}
+			if err := r.validateCloudStorageProvider(&dpa, &bslSpec); err != nil {
+				return false, err
+			}
+			if err := r.validateCloudStorageBucket(&dpa, &bslSpec); err != nil {
+				return false, err
+			}
 			if bslSpec.CloudStorage.Default {
 				numDefaultLocations++
 			} else if bslSpec.Name == "default" {
 				return false, fmt.Errorf("Storage location named 'default' must be set as default")
 			}
 		}
-		if bslSpec.CloudStorage != nil && bslSpec.Velero != nil {
-			return false, fmt.Errorf("must choose one of bucket or velero")
-		}
 	}
 	if numDefaultLocations > 1 {
 		return false, fmt.Errorf("Only one Storage Location be set as default")
@@ -90,6 +153,12 @@ func (r *DPAReconciler) ValidateBackupStorageLocations(dpa oadpv1alpha1.DataProt
 	if numDefaultLocations == 0 && !dpa.Spec.Configuration.Velero.NoDefaultBackupLocation {
 		return false, errors.New("no default backupstoragelocations configured, ensure that one backupstoragelocation has been configured as the default location")
 	}
+	if err := validateBackupLocationFailover(&dpa); err != nil {
+		return false, err
+	}
+	if err := r.validateBackupLocationMirror(&dpa); err != nil {
+		return false, err
+	}
 	// TODO: Discuss If multiple BSLs exist, ensure we have multiple credentials
 
 	return true, nil
@@ -100,20 +169,33 @@ func (r *DPAReconciler) ReconcileBackupStorageLocations(log logr.Logger) (bool,
 	if err := r.Get(r.Context, r.NamespacedName, &dpa); err != nil {
 		return false, err
 	}
+	failoverActive, failoverApplied, err := r.backupLocationFailoverActive(&dpa)
+	if err != nil {
+		return false, err
+	}
+	// failover requeues the DPA periodically, so only the transitions are recorded
+	switch {
+	case failoverActive && !failoverApplied:
+		r.EventRecorder.Event(&dpa,
+			corev1.EventTypeWarning,
+			"BackupLocationFailover",
+			fmt.Sprintf("backup location %s is unavailable, %s is set as the default backup location", dpa.Spec.BackupLocationFailover.Primary, dpa.Spec.BackupLocationFailover.Secondary),
+		)
+	case !failoverActive && failoverApplied:
+		r.EventRecorder.Event(&dpa,
+			corev1.EventTypeNormal,
+			"BackupLocationFailback",
+			fmt.Sprintf("backup location %s is set back as the default backup location", dpa.Spec.BackupLocationFailover.Primary),
+		)
+	}
 	// Loop through all configured BSLs
 	for i, bslSpec := range dpa.Spec.BackupLocations {
 		// Create BSL as is, we can safely assume they are valid from
 		// ValidateBackupStorageLocations
 
-		// check if BSL name is specified in DPA spec
-		bslName := fmt.Sprintf("%s-%d", r.NamespacedName.Name, i+1)
-		if bslSpec.Name != "" {
-			bslName = bslSpec.Name
-		}
-
 		bsl := velerov1.BackupStorageLocation{
 			ObjectMeta: metav1.ObjectMeta{
-				Name:      bslName,
+				Name:      getBackupLocationName(&dpa, i),
 				Namespace: r.NamespacedName.Namespace,
 			},
 		}
@@ -122,10 +204,12 @@ func (r *DPAReconciler) ReconcileBackupStorageLocations(log logr.Logger) (bool,
 		// 	 2. <namespace>.dataprotectionapplication: <name>
 		// which in turn will be used in th elabel handler to trigger the reconciliation loop
 
-		secretName, _ := r.getSecretNameAndKeyforBackupLocation(bslSpec)
-		_, err := r.UpdateCredentialsSecretLabels(secretName, dpa.Namespace, dpa.Name)
-		if err != nil {
-			return false, err
+		// backup locations using workload identity have no secret
+		if !usesGCPWorkloadIdentity(bslSpec.Velero) {
+			secretName, _ := r.getSecretNameAndKeyforBackupLocation(bslSpec)
+			if _, err := r.UpdateCredentialsSecretLabels(secretName, dpa.Namespace, dpa.Name); err != nil {
+				return false, err
+			}
 		}
 
 		// Create BSL
@@ -137,6 +221,7 @@ func (r *DPAReconciler) ReconcileBackupStorageLocations(log logr.Logger) (bool,
 			// TODO: check for BSL status condition errors and respond here
 			if bslSpec.Velero != nil {
 				err := r.updateBSLFromSpec(&bsl, &dpa, *bslSpec.Velero)
+				applyBackupLocationFailover(&bsl, dpa.Spec.BackupLocationFailover, failoverActive)
 
 				return err
 			}
@@ -151,7 +236,7 @@ func (r *DPAReconciler) ReconcileBackupStorageLocations(log logr.Logger) (bool,
 					return err
 				}
 				bsl.Spec.BackupSyncPeriod = bslSpec.CloudStorage.BackupSyncPeriod
-				bsl.Spec.Config = bslSpec.CloudStorage.Config
+				bsl.Spec.Config = cloudStorageBSLConfig(bslSpec.CloudStorage.Config)
 				if bucket.Spec.EnableSharedConfig != nil && *bucket.Spec.EnableSharedConfig {
 					if bsl.Spec.Config == nil {
 						bsl.Spec.Config = map[string]string{}
@@ -160,6 +245,7 @@ func (r *DPAReconciler) ReconcileBackupStorageLocations(log logr.Logger) (bool,
 				}
 				bsl.Spec.Credential = bslSpec.CloudStorage.Credential
 				bsl.Spec.Default = bslSpec.CloudStorage.Default
+				applyBackupLocationFailover(&bsl, dpa.Spec.BackupLocationFailover, failoverActive)
 				bsl.Spec.ObjectStorage = &velerov1.ObjectStorageLocation{
 					Bucket: bucket.Spec.Name,
 					Prefix: bslSpec.CloudStorage.Prefix,
@@ -193,6 +279,412 @@ func (r *DPAReconciler) ReconcileBackupStorageLocations(log logr.Logger) (bool,
 	return true, nil
 }
 
+// getBackupLocationName returns the name of the BSL created for the backup location at index i
+func getBackupLocationName(dpa *oadpv1alpha1.DataProtectionApplication, i int) string {
+	if name := dpa.Spec.BackupLocations[i].Name; name != "" {
+		return name
+	}
+	return fmt.Sprintf("%s-%d", dpa.Name, i+1)
+}
+
+// validateBackupLocationNames rejects backup locations resolving to the same BSL name, as only one BSL would be
+// created for them. Unnamed backup locations are included with their generated name.
+func validateBackupLocationNames(dpa *oadpv1alpha1.DataProtectionApplication) error {
+	names := mapset.NewSet[string]()
+	for i := range dpa.Spec.BackupLocations {
+		name := getBackupLocationName(dpa, i)
+		if !names.Add(name) {
+			return fmt.Errorf("duplicate BackupLocation name %q found", name)
+		}
+	}
+	return nil
+}
+
+// validateBackupSyncPeriod rejects negative backup sync periods, which velero replaces with its default sync period,
+// and sync periods set in the backup location config, which velero does not read
+func validateBackupSyncPeriod(name string, bsl *oadpv1alpha1.BackupLocation) error {
+	var syncPeriod *metav1.Duration
+	switch {
+	case bsl.Velero != nil:
+		if value, found := bsl.Velero.Config["backupSyncPeriod"]; found {
+			return fmt.Errorf("BackupLocation %s: backupSyncPeriod %q must be set in the velero backupSyncPeriod field instead of config", name, value)
+		}
+		syncPeriod = bsl.Velero.BackupSyncPeriod
+	case bsl.CloudStorage != nil:
+		syncPeriod = bsl.CloudStorage.BackupSyncPeriod
+	}
+	if syncPeriod != nil && syncPeriod.Duration < 0 {
+		return fmt.Errorf("BackupLocation %s: backupSyncPeriod %s must not be negative, use 0 to disable backup sync", name, syncPeriod.Duration)
+	}
+	return nil
+}
+
+// validateCACert checks the CA bundle of a backup location holds at least one PEM encoded certificate, as velero
+// only fails on an invalid bundle once it connects to the object storage
+func validateCACert(name string, bsl *oadpv1alpha1.BackupLocation) error {
+	var caCert []byte
+	switch {
+	case bsl.Velero != nil && bsl.Velero.ObjectStorage != nil:
+		caCert = bsl.Velero.ObjectStorage.CACert
+	case bsl.CloudStorage != nil:
+		caCert = bsl.CloudStorage.CACert
+	}
+	if len(caCert) == 0 || containsPEMCertificate(caCert) {
+		return nil
+	}
+	return fmt.Errorf("BackupLocation %s: caCert is not valid PEM", name)
+}
+
+func containsPEMCertificate(data []byte) bool {
+	for {
+		block, rest := pem.Decode(data)
+		if block == nil {
+			return false
+		}
+		if block.Type == "CERTIFICATE" {
+			if _, err := x509.ParseCertificate(block.Bytes); err == nil {
+				return true
+			}
+		}
+		data = rest
+	}
+}
+
+// validateBackupLocationConfig checks the provider specific config keys of a velero backup location
+func validateBackupLocationConfig(name string, bslSpec *velerov1.BackupStorageLocationSpec) error {
+	switch strings.TrimPrefix(bslSpec.Provider, veleroIOPrefix) {
+	case AWSProvider:
+		return validateAWSBackupLocationConfig(name, bslSpec.Config)
+	case GCPProvider:
+		return validateGCPBackupLocationConfig(name, bslSpec)
+	}
+	return nil
+}
+
+// GCPUseWorkloadIdentity is an operator only gcp backup location config key, removed from the BSL created for velero
+const GCPUseWorkloadIdentity = "useWorkloadIdentity"
+
+// backup location config keys read by the provider plugins, or by OADP for useWorkloadIdentity
+var validAWSBackupLocationKeys = map[string]bool{
+	Region:                      true,
+	Profile:                     true,
+	S3URL:                       true,
+	S3ForcePathStyle:            true,
+	InsecureSkipTLSVerify:       true,
+	CredentialsFileKey:          true,
+	EnableSharedConfigKey:       true,
+	"publicUrl":                 true,
+	"kmsKeyId":                  true,
+	"customerKeyEncryptionFile": true,
+	"signatureVersion":          true,
+	"serverSideEncryption":      true,
+	"checksumAlgorithm":         true,
+	"tagging":                   true,
+}
+
+var validGCPBackupLocationKeys = map[string]bool{
+	GCPServiceAccount:      true,
+	GCPUseWorkloadIdentity: true,
+	CredentialsFileKey:     true,
+	"kmsKeyName":           true,
+}
+
+var validAzureBackupLocationKeys = map[string]bool{
+	ResourceGroup:                 true,
+	StorageAccount:                true,
+	AzureSubscriptionId:           true,
+	CredentialsFileKey:            true,
+	"storageAccountKeyEnvVar":     true,
+	"storageAccountURI":           true,
+	"activeDirectoryAuthorityURI": true,
+	"blockSizeInBytes":            true,
+	"useAAD":                      true,
+}
+
+var validBackupLocationKeys = map[string]map[string]bool{
+	AWSProvider:   validAWSBackupLocationKeys,
+	GCPProvider:   validGCPBackupLocationKeys,
+	AzureProvider: validAzureBackupLocationKeys,
+}
+
+// unrecognizedBackupLocationConfigKeys returns a message for each config key of the aws, gcp and azure velero backup
+// locations their provider plugin does not read. These are not validation errors, as newer plugins may read keys
+// missing from the lists.
+func unrecognizedBackupLocationConfigKeys(dpa *oadpv1alpha1.DataProtectionApplication) []string {
+	messages := []string{}
+	for i, bslSpec := range dpa.Spec.BackupLocations {
+		if bslSpec.Velero == nil {
+			continue
+		}
+		provider := strings.TrimPrefix(bslSpec.Velero.Provider, veleroIOPrefix)
+		validKeys, found := validBackupLocationKeys[provider]
+		if !found {
+			continue
+		}
+		for _, key := range sortedKeys(bslSpec.Velero.Config) {
+			if !validKeys[key] {
+				messages = append(messages, fmt.Sprintf("BackupLocation %s: config key %q is not recognized by the %s provider", getBackupLocationName(dpa, i), key, provider))
+			}
+		}
+	}
+	return messages
+}
+
+// validateGCPBackupLocationConfig requires the GCP service account workload identity federation authenticates
+// as, and rejects credentials alongside workload identity
+func validateGCPBackupLocationConfig(name string, bslSpec *velerov1.BackupStorageLocationSpec) error {
+	value, found := bslSpec.Config[GCPUseWorkloadIdentity]
+	if !found {
+		return nil
+	}
+	if _, err := strconv.ParseBool(value); err != nil {
+		return fmt.Errorf("BackupLocation %s: %s must be true or false, got %q", name, GCPUseWorkloadIdentity, value)
+	}
+	if !usesGCPWorkloadIdentity(bslSpec) {
+		return nil
+	}
+	if bslSpec.Config[GCPServiceAccount] == "" {
+		return fmt.Errorf("BackupLocation %s: %s requires %s to be set to the GCP service account of the workload identity", name, GCPUseWorkloadIdentity, GCPServiceAccount)
+	}
+	if bslSpec.Credential != nil {
+		return fmt.Errorf("BackupLocation %s: %s cannot be used with credential %s", name, GCPUseWorkloadIdentity, bslSpec.Credential.Name)
+	}
+	return nil
+}
+
+// usesGCPWorkloadIdentity returns true for gcp backup locations authenticating with workload identity federation
+// instead of a service account key secret
+func usesGCPWorkloadIdentity(bslSpec *velerov1.BackupStorageLocationSpec) bool {
+	if bslSpec == nil || strings.TrimPrefix(bslSpec.Provider, veleroIOPrefix) != GCPProvider {
+		return false
+	}
+	useWorkloadIdentity, err := strconv.ParseBool(bslSpec.Config[GCPUseWorkloadIdentity])
+	return err == nil && useWorkloadIdentity
+}
+
+// validateAWSBackupLocationConfig requires path style addressing and an explicit region for S3 compatible
+// storage reached through s3Url, such as MinIO or Ceph RGW, as velero cannot discover either of them
+func validateAWSBackupLocationConfig(name string, config map[string]string) error {
+	if config[S3URL] == "" {
+		return nil
+	}
+	if forcePathStyle, err := strconv.ParseBool(config[S3ForcePathStyle]); err != nil || !forcePathStyle {
+		return fmt.Errorf("BackupLocation %s: s3Url requires s3ForcePathStyle to be set to true", name)
+	}
+	if config[Region] == "" {
+		return fmt.Errorf("BackupLocation %s: s3Url requires region to be set", name)
+	}
+	return nil
+}
+
+// validateBackupLocationFailover checks the failover backup locations exist and the primary is the default location
+func validateBackupLocationFailover(dpa *oadpv1alpha1.DataProtectionApplication) error {
+	failover := dpa.Spec.BackupLocationFailover
+	if failover == nil {
+		return nil
+	}
+	if failover.Primary == "" || failover.Secondary == "" {
+		return errors.New("backupLocationFailover primary and secondary must be set")
+	}
+	if failover.Primary == failover.Secondary {
+		return fmt.Errorf("backupLocationFailover primary and secondary must be different backup locations, both are %s", failover.Primary)
+	}
+	defaults := map[string]bool{}
+	for i, bslSpec := range dpa.Spec.BackupLocations {
+		defaults[getBackupLocationName(dpa, i)] = (bslSpec.Velero != nil && bslSpec.Velero.Default) ||
+			(bslSpec.CloudStorage != nil && bslSpec.CloudStorage.Default)
+	}
+	for _, name := range []string{failover.Primary, failover.Secondary} {
+		if _, ok := defaults[name]; !ok {
+			return fmt.Errorf("backupLocationFailover backup location %s does not exist in backupLocations", name)
+		}
+	}
+	if !defaults[failover.Primary] {
+		return fmt.Errorf("backupLocationFailover primary backup location %s must be the default backup location", failover.Primary)
+	}
+	return nil
+}
+
+// validateBackupLocationMirror checks the backup location mirror namespace is an existing namespace other than the
+// DPA namespace
+func (r *DPAReconciler) validateBackupLocationMirror(dpa *oadpv1alpha1.DataProtectionApplication) error {
+	mirror := dpa.Spec.BackupLocationMirror
+	if mirror == nil {
+		return nil
+	}
+	if errs := validation.IsDNS1123Label(mirror.Namespace); len(errs) > 0 {
+		return fmt.Errorf("backupLocationMirror namespace %q is not a valid namespace name: %s", mirror.Namespace, strings.Join(errs, "; "))
+	}
+	if mirror.Namespace == dpa.Namespace {
+		return fmt.Errorf("backupLocationMirror namespace must be different from the DPA namespace %s", dpa.Namespace)
+	}
+	namespace := corev1.Namespace{}
+	if err := r.uncachedReader().Get(r.Context, types.NamespacedName{Name: mirror.Namespace}, &namespace); err != nil {
+		return fmt.Errorf("backupLocationMirror namespace %s: %w", mirror.Namespace, err)
+	}
+	return nil
+}
+
+// backupLocationMirrorNamespace returns the namespace the backup locations of the DPA are mirrored to, or an empty
+// string if they are not mirrored
+func backupLocationMirrorNamespace(dpa *oadpv1alpha1.DataProtectionApplication) string {
+	if dpa.Spec.BackupLocationMirror == nil {
+		return ""
+	}
+	return dpa.Spec.BackupLocationMirror.Namespace
+}
+
+// ReconcileBackupLocationMirror creates a read only copy of each BSL of the DPA in the backup location mirror
+// namespace, and deletes the copies of BSLs removed from the DPA or mirrored to a previous namespace
+func (r *DPAReconciler) ReconcileBackupLocationMirror(log logr.Logger) (bool, error) {
+	dpa := oadpv1alpha1.DataProtectionApplication{}
+	if err := r.Get(r.Context, r.NamespacedName, &dpa); err != nil {
+		return false, err
+	}
+	namespace := backupLocationMirrorNamespace(&dpa)
+	if previous := dpa.Status.MirroredBackupLocationsNamespace; previous != "" && previous != namespace {
+		if err := r.deleteMirroredBackupLocations(log, &dpa, previous, nil); err != nil {
+			return false, err
+		}
+	}
+	if namespace == "" {
+		return true, nil
+	}
+	names := sets.NewString()
+	for i := range dpa.Spec.BackupLocations {
+		source := velerov1.BackupStorageLocation{}
+		if err := r.Get(r.Context, types.NamespacedName{Namespace: dpa.Namespace, Name: getBackupLocationName(&dpa, i)}, &source); err != nil {
+			return false, err
+		}
+		if err := r.mirrorBackupLocation(&dpa, &source, namespace); err != nil {
+			return false, err
+		}
+		names.Insert(source.Name)
+	}
+	if err := r.deleteMirroredBackupLocations(log, &dpa, namespace, names); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// mirrorBackupLocation creates or updates the read only copy of a BSL in namespace. BSLs in namespace not mirrored
+// from the DPA are not overwritten.
+func (r *DPAReconciler) mirrorBackupLocation(dpa *oadpv1alpha1.DataProtectionApplication, source *velerov1.BackupStorageLocation, namespace string) error {
+	existing := velerov1.BackupStorageLocation{}
+	err := r.uncachedReader().Get(r.Context, types.NamespacedName{Namespace: namespace, Name: source.Name}, &existing)
+	if err != nil && !k8serror.IsNotFound(err) {
+		return err
+	}
+	if err == nil && !mirroredFrom(&existing, dpa) {
+		return fmt.Errorf("backupstoragelocation %s/%s already exists and is not mirrored from DPA %s/%s", namespace, source.Name, dpa.Namespace, dpa.Name)
+	}
+	mirror := existing.DeepCopy()
+	mirror.Name = source.Name
+	mirror.Namespace = namespace
+	buildMirroredBackupLocation(mirror, dpa, source)
+	op := controllerutil.OperationResultNone
+	switch {
+	case k8serror.IsNotFound(err):
+		if err := r.Create(r.Context, mirror); err != nil {
+			return err
+		}
+		op = controllerutil.OperationResultCreated
+	case !reflect.DeepEqual(existing.Labels, mirror.Labels) || !reflect.DeepEqual(existing.Spec, mirror.Spec):
+		if err := r.Update(r.Context, mirror); err != nil {
+			return err
+		}
+		op = controllerutil.OperationResultUpdated
+	}
+	if op != controllerutil.OperationResultNone {
+		r.EventRecorder.Event(dpa,
+			corev1.EventTypeNormal,
+			"BackupStorageLocationMirrored",
+			fmt.Sprintf("performed %s on read only backupstoragelocation %s/%s mirrored from %s/%s", op, mirror.Namespace, mirror.Name, source.Namespace, source.Name),
+		)
+	}
+	return nil
+}
+
+// buildMirroredBackupLocation copies the spec of the source BSL to its mirror, as a read only location that is not
+// the default location of the velero instance of the mirror namespace
+func buildMirroredBackupLocation(mirror *velerov1.BackupStorageLocation, dpa *oadpv1alpha1.DataProtectionApplication, source *velerov1.BackupStorageLocation) {
+	// owner references cannot cross namespaces, so mirrors are labeled with the namespace of their DPA
+	mirror.Labels = getDpaAppLabels(dpa)
+	mirror.Labels[oadpBackupLocationMirrorLabel] = dpa.Namespace
+	mirror.Spec = *source.Spec.DeepCopy()
+	mirror.Spec.AccessMode = velerov1.BackupStorageLocationAccessModeReadOnly
+	mirror.Spec.Default = false
+}
+
+func mirroredFrom(bsl *velerov1.BackupStorageLocation, dpa *oadpv1alpha1.DataProtectionApplication) bool {
+	return bsl.Labels[oadpBackupLocationMirrorLabel] == dpa.Namespace && bsl.Labels["app.kubernetes.io/instance"] == dpa.Name
+}
+
+// deleteMirroredBackupLocations deletes the BSLs mirrored from the DPA to namespace, except the ones named in keep
+func (r *DPAReconciler) deleteMirroredBackupLocations(log logr.Logger, dpa *oadpv1alpha1.DataProtectionApplication, namespace string, keep sets.String) error {
+	bslList := velerov1.BackupStorageLocationList{}
+	if err := r.uncachedReader().List(r.Context, &bslList, client.InNamespace(namespace), client.MatchingLabels{
+		oadpBackupLocationMirrorLabel: dpa.Namespace,
+		"app.kubernetes.io/instance":  dpa.Name,
+	}); err != nil {
+		return err
+	}
+	for i := range bslList.Items {
+		bsl := &bslList.Items[i]
+		if keep.Has(bsl.Name) {
+			continue
+		}
+		if err := r.Delete(r.Context, bsl); err != nil && !k8serror.IsNotFound(err) {
+			return err
+		}
+		log.Info(fmt.Sprintf("deleted mirrored backupstoragelocation %s/%s", bsl.Namespace, bsl.Name))
+		r.EventRecorder.Event(dpa,
+			corev1.EventTypeNormal,
+			"BackupStorageLocationMirrorDeleted",
+			fmt.Sprintf("mirrored backupstoragelocation %s/%s deleted", bsl.Namespace, bsl.Name),
+		)
+	}
+	return nil
+}
+
+// backupLocationFailoverActive returns true when velero reports the failover primary backup location as unavailable
+// and has not reported the secondary as unavailable. It also returns whether the last reconcile applied the failover,
+// as the secondary backup location is only the default one during failover.
+func (r *DPAReconciler) backupLocationFailoverActive(dpa *oadpv1alpha1.DataProtectionApplication) (active bool, applied bool, err error) {
+	failover := dpa.Spec.BackupLocationFailover
+	if failover == nil {
+		return false, false, nil
+	}
+	bsls := map[string]velerov1.BackupStorageLocation{}
+	for _, name := range []string{failover.Primary, failover.Secondary} {
+		bsl := velerov1.BackupStorageLocation{}
+		if err := r.Get(r.Context, types.NamespacedName{Name: name, Namespace: dpa.Namespace}, &bsl); err != nil {
+			if k8serror.IsNotFound(err) {
+				return false, false, nil
+			}
+			return false, false, err
+		}
+		bsls[name] = bsl
+	}
+	active = bsls[failover.Primary].Status.Phase == velerov1.BackupStorageLocationPhaseUnavailable &&
+		bsls[failover.Secondary].Status.Phase != velerov1.BackupStorageLocationPhaseUnavailable
+	return active, bsls[failover.Secondary].Spec.Default, nil
+}
+
+// applyBackupLocationFailover moves the default flag from the primary to the secondary backup location while
+// failover is active
+func applyBackupLocationFailover(bsl *velerov1.BackupStorageLocation, failover *oadpv1alpha1.BackupLocationFailover, active bool) {
+	if failover == nil || !active {
+		return
+	}
+	switch bsl.Name {
+	case failover.Primary:
+		bsl.Spec.Default = false
+	case failover.Secondary:
+		bsl.Spec.Default = true
+	}
+}
+
 func (r *DPAReconciler) UpdateCredentialsSecretLabels(secretName string, namespace string, dpaName string) (bool, error) {
 	var secret corev1.Secret
 	secret, err := r.getProviderSecret(secretName)
@@ -241,6 +733,20 @@ func (r *DPAReconciler) updateBSLFromSpec(bsl *velerov1.BackupStorageLocation, d
 			registryDeployment = "False"
 		}
 	}
+	// Likewise the registry has no service account key to use with GCP workload identity. The operator only
+	// key is removed as the GCP plugin rejects unknown config keys.
+	if _, found := bslSpec.Config[GCPUseWorkloadIdentity]; found {
+		if usesGCPWorkloadIdentity(&bslSpec) {
+			registryDeployment = "False"
+		}
+		config := make(map[string]string, len(bslSpec.Config))
+		for key, value := range bslSpec.Config {
+			if key != GCPUseWorkloadIdentity {
+				config[key] = value
+			}
+		}
+		bslSpec.Config = config
+	}
 	// The AWS SDK expects the server providing S3 blobs to remove default ports
 	// (80 for HTTP and 443 for HTTPS) before calculating a signature, and not
 	// all S3-compatible services do this. Remove the ports here to avoid 403
@@ -367,6 +873,9 @@ func (r *DPAReconciler) validateProviderPluginAndSecret(bslSpec velerov1.BackupS
 	if dpa.Spec.Configuration.Velero.HasFeatureFlag("no-secret") {
 		return nil
 	}
+	if usesGCPWorkloadIdentity(&bslSpec) {
+		return nil
+	}
 	// check for existence of provider plugin and warn if the plugin is absent
 	if !pluginExistsInVeleroCR(dpa.Spec.Configuration.Velero.DefaultPlugins, oadpv1alpha1.DefaultPlugin(bslSpec.Provider)) {
 		r.Log.Info(fmt.Sprintf("%s backupstoragelocation is configured but velero plugin for %s is not present", bslSpec.Provider, bslSpec.Provider))
@@ -383,13 +892,84 @@ func (r *DPAReconciler) validateProviderPluginAndSecret(bslSpec velerov1.BackupS
 	return nil
 }
 
-func (r *DPAReconciler) ensureBackupLocationHasVeleroOrCloudStorage(bsl *oadpv1alpha1.BackupLocation) error {
-	if bsl.CloudStorage == nil && bsl.Velero == nil {
-		return fmt.Errorf("BackupLocation must have velero or bucket configuration")
+// validateCloudStorageBucket checks the bucket of an AWS CloudStorage is reachable with the credential of the
+// backup location, when the location opted in with the validateBucket config key
+func (r *DPAReconciler) validateCloudStorageBucket(dpa *oadpv1alpha1.DataProtectionApplication, bsl *oadpv1alpha1.BackupLocation) error {
+	config := bsl.CloudStorage.Config
+	if config[CloudStorageValidateBucket] != "true" {
+		return nil
+	}
+	bucket := &oadpv1alpha1.CloudStorage{}
+	if err := r.Get(r.Context, client.ObjectKey{Namespace: dpa.Namespace, Name: bsl.CloudStorage.CloudStorageRef.Name}, bucket); err != nil {
+		if k8serror.IsNotFound(err) {
+			// CloudStorage may not be created yet, the bucket is checked once it exists
+			return nil
+		}
+		return err
+	}
+	if bucket.Spec.Provider != oadpv1alpha1.AWSBucketProvider {
+		return nil
+	}
+	secret := &corev1.Secret{}
+	if err := r.Get(r.Context, client.ObjectKey{Namespace: dpa.Namespace, Name: bsl.CloudStorage.Credential.Name}, secret); err != nil {
+		return err
+	}
+	credentialsFile, err := os.CreateTemp("", "cloudstorage-credentials")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(credentialsFile.Name())
+	_, err = credentialsFile.Write(secret.Data[bsl.CloudStorage.Credential.Key])
+	credentialsFile.Close()
+	if err != nil {
+		return err
+	}
+	region := config[Region]
+	if region == "" {
+		region = bucket.Spec.Region
+	}
+	if region == "" {
+		// the CloudStorage controller creates buckets without a region in us-east-1
+		region = "us-east-1"
+	}
+	profile := config[Profile]
+	if profile == "" {
+		profile = "default"
+	}
+	if err := headBucket(bucket.Spec.Name, region, config[S3URL], config[S3ForcePathStyle] == "true", credentialsFile.Name(), profile); err != nil {
+		return fmt.Errorf("CloudStorage %s: bucket %q not reachable: %v", bucket.Name, bucket.Spec.Name, err)
 	}
+	return nil
+}
 
-	if bsl.CloudStorage != nil && bsl.Velero != nil {
-		return fmt.Errorf("cannot have both backupstoragelocations and bucket provided for a single StorageLocation")
+// cloudStorageBSLConfig returns the config of a CloudStorage backup location without the keys only used by OADP
+func cloudStorageBSLConfig(config map[string]string) map[string]string {
+	if config == nil {
+		return nil
+	}
+	bslConfig := map[string]string{}
+	for key, value := range config {
+		if key != CloudStorageValidateBucket {
+			bslConfig[key] = value
+		}
+	}
+	return bslConfig
+}
+
+// validateCloudStorageProvider ensures the CloudStorage referenced by a backup location uses a
+// provider that the CloudStorage controller can create buckets for
+func (r *DPAReconciler) validateCloudStorageProvider(dpa *oadpv1alpha1.DataProtectionApplication, bsl *oadpv1alpha1.BackupLocation) error {
+	bucket := &oadpv1alpha1.CloudStorage{}
+	err := r.Get(r.Context, client.ObjectKey{Namespace: dpa.Namespace, Name: bsl.CloudStorage.CloudStorageRef.Name}, bucket)
+	if err != nil {
+		if k8serror.IsNotFound(err) {
+			// CloudStorage may not be created yet, the provider is validated once it exists
+			return nil
+		}
+		return err
+	}
+	if !supportedCloudStorageProviders.Contains(bucket.Spec.Provider) {
+		return fmt.Errorf("CloudStorage %s/%s provider %q is not supported by the CloudStorage controller", bucket.Namespace, bucket.Name, bucket.Spec.Provider)
 	}
 	return nil
 }
@@ -407,7 +987,153 @@ func (r *DPAReconciler) ensurePrefixWhenBackupImages(dpa *oadpv1alpha1.DataProte
 	return nil
 }
 
+const (
+	// object key length limit of S3, GCS and Azure Blob storage
+	maxObjectKeyLength = 1024
+	// length of the longest object key velero writes below a prefix, for a backup with the longest possible name:
+	// /backups/<backup>/<backup>-csi-volumesnapshotcontents.json.gz
+	backupObjectKeyHeadroom       = len("/backups/") + 2*validation.DNS1123SubdomainMaxLength + len("/-csi-volumesnapshotcontents.json.gz")
+	maxBackupLocationPrefixLength = maxObjectKeyLength - backupObjectKeyHeadroom
+)
+
+// validatePrefixLength returns an error if the backup location prefix does not leave room for the object keys
+// velero writes below it
+func validatePrefixLength(bsl *oadpv1alpha1.BackupLocation) error {
+	prefix := ""
+	if bsl.Velero != nil && bsl.Velero.ObjectStorage != nil {
+		prefix = bsl.Velero.ObjectStorage.Prefix
+	} else if bsl.CloudStorage != nil {
+		prefix = bsl.CloudStorage.Prefix
+	}
+	if len(prefix) > maxBackupLocationPrefixLength {
+		return fmt.Errorf("BackupLocation prefix is %d bytes long, it must not exceed %d bytes so backup object keys fit the %d byte object storage key limit",
+			len(prefix), maxBackupLocationPrefixLength, maxObjectKeyLength)
+	}
+	return nil
+}
+
+// validateCredentialKey returns an error if the credential of a backup location references a key that
+// cannot exist in a secret, such as a key containing a path separator
+func validateCredentialKey(bsl *oadpv1alpha1.BackupLocation) error {
+	var credential *corev1.SecretKeySelector
+	if bsl.Velero != nil && bsl.Velero.Credential != nil {
+		credential = bsl.Velero.Credential
+	} else if bsl.CloudStorage != nil && bsl.CloudStorage.Credential != nil {
+		credential = bsl.CloudStorage.Credential
+	}
+	if credential == nil || credential.Key == "" {
+		return nil
+	}
+	// secret keys follow the same rules as configmap keys
+	if errs := validation.IsConfigMapKey(credential.Key); len(errs) > 0 {
+		return fmt.Errorf("BackupLocation credential key %q of secret %s is not a valid secret key, keys may only contain alphanumeric characters, '-', '_' or '.'", credential.Key, credential.Name)
+	}
+	return nil
+}
+
+// matches go template actions such as {{.ClusterID}}
+var templateTokenRegexp = regexp.MustCompile(`\{\{[^{}]*\}\}`)
+
+// warnPrefixTemplateTokens emits a warning for every backup location prefix containing template tokens,
+// as prefixes are not templated and the token would be used literally as part of the object storage path
+func (r *DPAReconciler) warnPrefixTemplateTokens(log logr.Logger, dpa *oadpv1alpha1.DataProtectionApplication) {
+	for _, msg := range prefixTemplateTokens(dpa) {
+		r.warnEvent(log, dpa, "PrefixTemplateToken", msg)
+	}
+}
+
+// prefixTemplateTokens returns a message for each backup location prefix containing template tokens
+func prefixTemplateTokens(dpa *oadpv1alpha1.DataProtectionApplication) []string {
+	messages := []string{}
+	for i, bslSpec := range dpa.Spec.BackupLocations {
+		prefix := ""
+		if bslSpec.Velero != nil && bslSpec.Velero.ObjectStorage != nil {
+			prefix = bslSpec.Velero.ObjectStorage.Prefix
+		}
+		if bslSpec.CloudStorage != nil {
+			prefix = bslSpec.CloudStorage.Prefix
+		}
+		if tokens := templateTokenRegexp.FindAllString(prefix, -1); len(tokens) > 0 {
+			messages = append(messages, fmt.Sprintf("backupLocations[%d] prefix %q contains %s, prefixes are not templated and the token is used literally", i, prefix, strings.Join(tokens, ", ")))
+		}
+	}
+	return messages
+}
+
+// warnSharedBackupImagePrefixes emits a warning for every backup location sharing its bucket and prefix with
+// another backup location while backupImages is enabled
+func (r *DPAReconciler) warnSharedBackupImagePrefixes(log logr.Logger, dpa *oadpv1alpha1.DataProtectionApplication) {
+	for _, msg := range sharedBackupImagePrefixes(dpa) {
+		r.warnEvent(log, dpa, "SharedBackupImagePrefix", msg)
+	}
+}
+
+// sharedBackupImagePrefixes returns a message for each backup location using the same bucket and prefix as
+// an earlier backup location, as image backup data of both locations is written below the same path
+func sharedBackupImagePrefixes(dpa *oadpv1alpha1.DataProtectionApplication) []string {
+	messages := []string{}
+	if !dpa.BackupImages() {
+		return messages
+	}
+	firstLocation := map[string]int{}
+	for i, bslSpec := range dpa.Spec.BackupLocations {
+		location := ""
+		switch {
+		case bslSpec.Velero != nil && bslSpec.Velero.ObjectStorage != nil:
+			location = path.Join(strings.TrimPrefix(bslSpec.Velero.Provider, veleroIOPrefix), bslSpec.Velero.ObjectStorage.Bucket, bslSpec.Velero.ObjectStorage.Prefix)
+		case bslSpec.CloudStorage != nil:
+			location = path.Join("cloudStorage", bslSpec.CloudStorage.CloudStorageRef.Name, bslSpec.CloudStorage.Prefix)
+		default:
+			continue
+		}
+		if first, found := firstLocation[location]; found {
+			messages = append(messages, fmt.Sprintf("backupLocations[%d] uses the same bucket and prefix as backupLocations[%d], image backup data of the locations may collide, use distinct prefixes", i, first))
+			continue
+		}
+		firstLocation[location] = i
+	}
+	return messages
+}
+
+// object storage request limits that image backups, writing an object per image layer, are known to run into,
+// by provider
+var imageBackupRequestLimits = map[string]string{
+	GCPProvider: "new GCS buckets initially sustain about 1000 object writes per second and only scale up gradually",
+}
+
+// warnImageBackupRequestLimits emits a warning for every backup location storing image backups with a provider
+// known to throttle bursts of object writes
+func (r *DPAReconciler) warnImageBackupRequestLimits(log logr.Logger, dpa *oadpv1alpha1.DataProtectionApplication) {
+	for _, msg := range imageBackupRequestLimitMessages(dpa) {
+		r.warnEvent(log, dpa, "ImageBackupRequestLimits", msg)
+	}
+}
+
+// imageBackupRequestLimitMessages returns a message for each backup location image backups are stored in whose
+// provider has request limits in imageBackupRequestLimits
+func imageBackupRequestLimitMessages(dpa *oadpv1alpha1.DataProtectionApplication) []string {
+	messages := []string{}
+	if !dpa.BackupImages() {
+		return messages
+	}
+	for i, bslSpec := range dpa.Spec.BackupLocations {
+		// no registry is deployed for backup locations using workload identity
+		if bslSpec.Velero == nil || usesGCPWorkloadIdentity(bslSpec.Velero) {
+			continue
+		}
+		provider := strings.TrimPrefix(bslSpec.Velero.Provider, veleroIOPrefix)
+		if limit, found := imageBackupRequestLimits[provider]; found {
+			messages = append(messages, fmt.Sprintf("backupLocations[%d] stores image backups with provider %s, %s, backups of many images may be throttled; set backupImages to false if image backup is not needed", i, provider, limit))
+		}
+	}
+	return messages
+}
+
 func (r *DPAReconciler) ensureSecretDataExists(dpa *oadpv1alpha1.DataProtectionApplication, bsl *oadpv1alpha1.BackupLocation) error {
+	// backup locations using workload identity have no secret
+	if usesGCPWorkloadIdentity(bsl.Velero) {
+		return nil
+	}
 	// Check if the Velero feature flag 'no-secret' is not set
 	if !(dpa.Spec.Configuration.Velero.HasFeatureFlag("no-secret")) {
 		// Check if the user specified credential under velero
@@ -434,3 +1160,51 @@ func (r *DPAReconciler) ensureSecretDataExists(dpa *oadpv1alpha1.DataProtectionA
 	}
 	return nil
 }
+
+// reservedVeleroMountPaths returns the paths mounted in the Velero pod by the operator, keyed by path
+func reservedVeleroMountPaths() map[string]string {
+	usedMountPaths := map[string]string{
+		"/plugins":         "plugins volume",
+		"/scratch":         "scratch volume",
+		"/etc/ssl/certs":   "certs volume",
+		"/tmp/credentials": "velero credentials file store",
+		"/var/run/secrets/openshift/serviceaccount": "service account token volume",
+	}
+	for plugin, fields := range credentials.PluginSpecificFields {
+		if fields.MountPath != "" {
+			usedMountPaths[fields.MountPath] = fmt.Sprintf("%s plugin credentials", plugin)
+		}
+	}
+	return usedMountPaths
+}
+
+// validateCredentialMountPaths ensures every credentialMountPath override is an absolute path
+// that does not collide with another mount in the Velero pod
+func validateCredentialMountPaths(dpa *oadpv1alpha1.DataProtectionApplication) error {
+	usedMountPaths := reservedVeleroMountPaths()
+	for i, bslSpec := range dpa.Spec.BackupLocations {
+		if bslSpec.CredentialMountPath == "" {
+			continue
+		}
+		mountPath := bslSpec.CredentialMountPath
+		if !path.IsAbs(mountPath) {
+			return fmt.Errorf("backupLocations[%d] credentialMountPath %s must be an absolute path", i, mountPath)
+		}
+		mountPath = path.Clean(mountPath)
+		if mountPath == "/" {
+			return fmt.Errorf("backupLocations[%d] credentialMountPath cannot be the root directory", i)
+		}
+		for usedPath, usedBy := range usedMountPaths {
+			if mountPathsOverlap(mountPath, usedPath) {
+				return fmt.Errorf("backupLocations[%d] credentialMountPath %s collides with %s mounted at %s", i, bslSpec.CredentialMountPath, usedBy, usedPath)
+			}
+		}
+		usedMountPaths[mountPath] = fmt.Sprintf("backupLocations[%d] credentials", i)
+	}
+	return nil
+}
+
+// mountPathsOverlap returns true if both paths are equal or one is nested under the other
+func mountPathsOverlap(a, b string) bool {
+	return a == b || strings.HasPrefix(a, b+"/") || strings.HasPrefix(b, a+"/")
+}
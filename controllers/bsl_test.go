@@ -2,16 +2,29 @@ package controllers
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
 	"fmt"
+	"math/big"
+	"os"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/google/go-cmp/cmp"
 	velerov1 "github.com/vmware-tanzu/velero/pkg/apis/velero/v1"
 	corev1 "k8s.io/api/core/v1"
+	k8serror "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/record"
@@ -20,6 +33,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	oadpv1alpha1 "github.com/openshift/oadp-operator/api/v1alpha1"
+	"github.com/openshift/oadp-operator/pkg/storage/aws"
 )
 
 // A bucket that region can be automatically discovered
@@ -45,7 +59,16 @@ func getFakeClientFromObjects(objs ...client.Object) (client.WithWatch, error) {
 		return nil, err
 	}
 
-	return fake.NewClientBuilder().WithScheme(schemeForFakeClient).WithObjects(objs...).Build(), nil
+	return fake.NewClientBuilder().WithScheme(schemeForFakeClient).WithRESTMapper(getRESTMapperForFakeClient()).WithObjects(objs...).Build(), nil
+}
+
+// getRESTMapperForFakeClient returns a RESTMapper serving the cluster APIs required by default plugins
+func getRESTMapperForFakeClient() meta.RESTMapper {
+	restMapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{})
+	for _, requiredAPI := range pluginRequiredAPIs {
+		restMapper.Add(requiredAPI.gvk, meta.RESTScopeNamespace)
+	}
+	return restMapper
 }
 
 func TestDPAReconciler_ValidateBackupStorageLocations(t *testing.T) {
@@ -1516,10 +1539,12 @@ func newContextForTest(name string) context.Context {
 
 func TestDPAReconciler_updateBSLFromSpec(t *testing.T) {
 	tests := []struct {
-		name    string
-		bsl     *velerov1.BackupStorageLocation
-		dpa     *oadpv1alpha1.DataProtectionApplication
-		wantErr bool
+		name                   string
+		bsl                    *velerov1.BackupStorageLocation
+		dpa                    *oadpv1alpha1.DataProtectionApplication
+		wantErr                bool
+		wantRegistryDeployment string
+		wantConfig             map[string]string
 	}{
 		{
 			name: "BSL without owner reference and labels",
@@ -1536,9 +1561,33 @@ func TestDPAReconciler_updateBSLFromSpec(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "BSL using GCP workload identity",
+			bsl: &velerov1.BackupStorageLocation{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "foo-1",
+					Namespace: "bar",
+				},
+				Spec: velerov1.BackupStorageLocationSpec{
+					Provider: "gcp",
+					Config:   map[string]string{GCPUseWorkloadIdentity: "true", GCPServiceAccount: "velero@project.iam.gserviceaccount.com"},
+				},
+			},
+			dpa: &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "foo",
+					Namespace: "bar",
+				},
+			},
+			wantRegistryDeployment: "False",
+			wantConfig:             map[string]string{GCPServiceAccount: "velero@project.iam.gserviceaccount.com"},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			if tt.wantRegistryDeployment == "" {
+				tt.wantRegistryDeployment = "True"
+			}
 			scheme, err := getSchemeForFakeClient()
 			if err != nil {
 				t.Errorf("error getting scheme for the test: %#v", err)
@@ -1558,7 +1607,7 @@ func TestDPAReconciler_updateBSLFromSpec(t *testing.T) {
 						"app.kubernetes.io/managed-by":       "oadp-operator",
 						"app.kubernetes.io/component":        "bsl",
 						oadpv1alpha1.OadpOperatorLabel:       "True",
-						oadpv1alpha1.RegistryDeploymentLabel: "True",
+						oadpv1alpha1.RegistryDeploymentLabel: tt.wantRegistryDeployment,
 					},
 					OwnerReferences: []metav1.OwnerReference{{
 						APIVersion:         oadpv1alpha1.SchemeBuilder.GroupVersion.String(),
@@ -1582,15 +1631,19 @@ func TestDPAReconciler_updateBSLFromSpec(t *testing.T) {
 			if !reflect.DeepEqual(tt.bsl.OwnerReferences, wantBSl.OwnerReferences) {
 				t.Errorf("expected bsl owner references to be %#v, got %#v", wantBSl.OwnerReferences, tt.bsl.OwnerReferences)
 			}
+			if tt.wantConfig != nil && !reflect.DeepEqual(tt.bsl.Spec.Config, tt.wantConfig) {
+				t.Errorf("expected bsl config to be %#v, got %#v", tt.wantConfig, tt.bsl.Spec.Config)
+			}
 		})
 	}
 }
 
-func TestDPAReconciler_ensureBackupLocationHasVeleroOrCloudStorage(t *testing.T) {
+func TestBackupLocation_ValidateVeleroOrCloudStorage(t *testing.T) {
 	tests := []struct {
-		name    string
-		dpa     *oadpv1alpha1.DataProtectionApplication
-		wantErr bool
+		name           string
+		dpa            *oadpv1alpha1.DataProtectionApplication
+		wantErr        bool
+		wantErrMessage string
 	}{
 		{
 			name: "one bsl configured per provider",
@@ -1643,7 +1696,38 @@ func TestDPAReconciler_ensureBackupLocationHasVeleroOrCloudStorage(t *testing.T)
 					},
 				},
 			},
-			wantErr: true,
+			wantErr:        true,
+			wantErrMessage: "BackupLocation cannot have both velero and cloudStorage configuration, velero objectStorage and cloudStorage bucket foo are mutually exclusive",
+		},
+		{
+			name: "wantErr: a bsl has both velero objectStorage and cloudstorage configured",
+			dpa: &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "foo",
+					Namespace: "test-ns",
+				},
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					BackupLocations: []oadpv1alpha1.BackupLocation{
+						{
+							Velero: &velerov1.BackupStorageLocationSpec{
+								Provider: "aws",
+								StorageType: velerov1.StorageType{
+									ObjectStorage: &velerov1.ObjectStorageLocation{
+										Bucket: "velero-bucket",
+									},
+								},
+							},
+							CloudStorage: &oadpv1alpha1.CloudStorageLocation{
+								CloudStorageRef: corev1.LocalObjectReference{
+									Name: "cloud-bucket",
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr:        true,
+			wantErrMessage: "BackupLocation cannot have both velero and cloudStorage configuration, velero objectStorage and cloudStorage bucket cloud-bucket are mutually exclusive",
 		},
 		{
 			name: "two bsl configured per provider",
@@ -1692,16 +1776,13 @@ func TestDPAReconciler_ensureBackupLocationHasVeleroOrCloudStorage(t *testing.T)
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			scheme, err := getSchemeForFakeClient()
-			if err != nil {
-				t.Errorf("error getting scheme for the test: %#v", err)
-			}
-			r := &DPAReconciler{
-				Scheme: scheme,
-			}
 			for _, bsl := range tt.dpa.Spec.BackupLocations {
-				if err := r.ensureBackupLocationHasVeleroOrCloudStorage(&bsl); (err != nil) != tt.wantErr {
-					t.Errorf("ensureBSLProviderMapping() error = %v, wantErr %v", err, tt.wantErr)
+				err := bsl.ValidateVeleroOrCloudStorage()
+				if (err != nil) != tt.wantErr {
+					t.Errorf("ValidateVeleroOrCloudStorage() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				if err != nil && tt.wantErrMessage != "" && err.Error() != tt.wantErrMessage {
+					t.Errorf("ValidateVeleroOrCloudStorage() error = %v, want %v", err, tt.wantErrMessage)
 				}
 			}
 
@@ -2349,3 +2430,1240 @@ func TestDPAReconciler_ReconcileBackupStorageLocations(t *testing.T) {
 		})
 	}
 }
+
+func Test_validateCredentialMountPaths(t *testing.T) {
+	tests := []struct {
+		name         string
+		mountPaths   []string
+		wantErr      bool
+		wantErrorMsg string
+	}{
+		{
+			name:       "no credentialMountPath set",
+			mountPaths: []string{""},
+			wantErr:    false,
+		},
+		{
+			name:       "distinct absolute credentialMountPaths",
+			mountPaths: []string{"/opt/plugin-a/credentials", "/opt/plugin-b/credentials"},
+			wantErr:    false,
+		},
+		{
+			name:         "relative credentialMountPath",
+			mountPaths:   []string{"opt/credentials"},
+			wantErr:      true,
+			wantErrorMsg: "backupLocations[0] credentialMountPath opt/credentials must be an absolute path",
+		},
+		{
+			name:         "credentialMountPath collides with default plugin credentials",
+			mountPaths:   []string{"/credentials"},
+			wantErr:      true,
+			wantErrorMsg: "backupLocations[0] credentialMountPath /credentials collides with aws plugin credentials mounted at /credentials",
+		},
+		{
+			name:         "credentialMountPath nested under plugins volume",
+			mountPaths:   []string{"/plugins/credentials"},
+			wantErr:      true,
+			wantErrorMsg: "backupLocations[0] credentialMountPath /plugins/credentials collides with plugins volume mounted at /plugins",
+		},
+		{
+			name:         "credentialMountPath collides with another backup location",
+			mountPaths:   []string{"/opt/credentials", "/opt/credentials/"},
+			wantErr:      true,
+			wantErrorMsg: "backupLocations[1] credentialMountPath /opt/credentials/ collides with backupLocations[0] credentials mounted at /opt/credentials",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dpa := &oadpv1alpha1.DataProtectionApplication{}
+			for _, mountPath := range tt.mountPaths {
+				dpa.Spec.BackupLocations = append(dpa.Spec.BackupLocations, oadpv1alpha1.BackupLocation{
+					CredentialMountPath: mountPath,
+				})
+			}
+			err := validateCredentialMountPaths(dpa)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateCredentialMountPaths() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr && err.Error() != tt.wantErrorMsg {
+				t.Errorf("validateCredentialMountPaths() error = %v, want %v", err, tt.wantErrorMsg)
+			}
+		})
+	}
+}
+
+func TestDPAReconciler_warnPrefixTemplateTokens(t *testing.T) {
+	tests := []struct {
+		name         string
+		prefix       string
+		wantMessages []string
+	}{
+		{
+			name:         "prefix without template tokens",
+			prefix:       "velero/cluster-a",
+			wantMessages: []string{},
+		},
+		{
+			name:   "prefix with literal template token",
+			prefix: "velero/{{.ClusterID}}",
+			wantMessages: []string{
+				"backupLocations[0] prefix \"velero/{{.ClusterID}}\" contains {{.ClusterID}}, prefixes are not templated and the token is used literally",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dpa := &oadpv1alpha1.DataProtectionApplication{
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					BackupLocations: []oadpv1alpha1.BackupLocation{
+						{
+							Velero: &velerov1.BackupStorageLocationSpec{
+								Provider: "aws",
+								StorageType: velerov1.StorageType{
+									ObjectStorage: &velerov1.ObjectStorageLocation{
+										Bucket: "bucket",
+										Prefix: tt.prefix,
+									},
+								},
+							},
+						},
+					},
+				},
+			}
+			got := prefixTemplateTokens(dpa)
+			if !reflect.DeepEqual(got, tt.wantMessages) {
+				t.Errorf("prefixTemplateTokens() got = %v, want %v", got, tt.wantMessages)
+			}
+			recorder := record.NewFakeRecorder(10)
+			r := &DPAReconciler{EventRecorder: recorder}
+			r.warnPrefixTemplateTokens(logr.Discard(), dpa)
+			if len(recorder.Events) != len(tt.wantMessages) {
+				t.Errorf("warnPrefixTemplateTokens() emitted %d events, want %d", len(recorder.Events), len(tt.wantMessages))
+			}
+		})
+	}
+}
+
+func TestDPAReconciler_warnSharedBackupImagePrefixes(t *testing.T) {
+	tests := []struct {
+		name         string
+		backupImages *bool
+		prefixes     []string
+		wantMessages []string
+	}{
+		{
+			name:         "distinct prefixes with image backup",
+			prefixes:     []string{"velero/cluster-a", "velero/cluster-b"},
+			wantMessages: []string{},
+		},
+		{
+			name:     "shared prefix with image backup",
+			prefixes: []string{"velero/cluster-a", "velero/cluster-a/"},
+			wantMessages: []string{
+				"backupLocations[1] uses the same bucket and prefix as backupLocations[0], image backup data of the locations may collide, use distinct prefixes",
+			},
+		},
+		{
+			name:         "shared prefix without image backup",
+			backupImages: pointer.Bool(false),
+			prefixes:     []string{"velero/cluster-a", "velero/cluster-a"},
+			wantMessages: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dpa := &oadpv1alpha1.DataProtectionApplication{
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					BackupImages: tt.backupImages,
+				},
+			}
+			for _, prefix := range tt.prefixes {
+				dpa.Spec.BackupLocations = append(dpa.Spec.BackupLocations, oadpv1alpha1.BackupLocation{
+					Velero: &velerov1.BackupStorageLocationSpec{
+						Provider: "aws",
+						StorageType: velerov1.StorageType{
+							ObjectStorage: &velerov1.ObjectStorageLocation{
+								Bucket: "bucket",
+								Prefix: prefix,
+							},
+						},
+					},
+				})
+			}
+			got := sharedBackupImagePrefixes(dpa)
+			if !reflect.DeepEqual(got, tt.wantMessages) {
+				t.Errorf("sharedBackupImagePrefixes() got = %v, want %v", got, tt.wantMessages)
+			}
+			recorder := record.NewFakeRecorder(10)
+			r := &DPAReconciler{EventRecorder: recorder}
+			r.warnSharedBackupImagePrefixes(logr.Discard(), dpa)
+			if len(recorder.Events) != len(tt.wantMessages) {
+				t.Errorf("warnSharedBackupImagePrefixes() emitted %d events, want %d", len(recorder.Events), len(tt.wantMessages))
+			}
+		})
+	}
+}
+
+func TestDPAReconciler_warnImageBackupRequestLimits(t *testing.T) {
+	tests := []struct {
+		name         string
+		backupImages *bool
+		providers    []string
+		wantMessages []string
+	}{
+		{
+			name:      "gcp with image backup",
+			providers: []string{"aws", "velero.io/gcp"},
+			wantMessages: []string{
+				"backupLocations[1] stores image backups with provider gcp, new GCS buckets initially sustain about 1000 object writes per second and only scale up gradually, backups of many images may be throttled; set backupImages to false if image backup is not needed",
+			},
+		},
+		{
+			name:         "gcp without image backup",
+			backupImages: pointer.Bool(false),
+			providers:    []string{"gcp"},
+			wantMessages: []string{},
+		},
+		{
+			name:         "providers without known limits with image backup",
+			providers:    []string{"aws", "azure"},
+			wantMessages: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dpa := &oadpv1alpha1.DataProtectionApplication{
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					BackupImages: tt.backupImages,
+				},
+			}
+			for _, provider := range tt.providers {
+				dpa.Spec.BackupLocations = append(dpa.Spec.BackupLocations, oadpv1alpha1.BackupLocation{
+					Velero: &velerov1.BackupStorageLocationSpec{Provider: provider},
+				})
+			}
+			got := imageBackupRequestLimitMessages(dpa)
+			if !reflect.DeepEqual(got, tt.wantMessages) {
+				t.Errorf("imageBackupRequestLimitMessages() got = %v, want %v", got, tt.wantMessages)
+			}
+			recorder := record.NewFakeRecorder(10)
+			r := &DPAReconciler{EventRecorder: recorder}
+			r.warnImageBackupRequestLimits(logr.Discard(), dpa)
+			if len(recorder.Events) != len(tt.wantMessages) {
+				t.Errorf("warnImageBackupRequestLimits() emitted %d events, want %d", len(recorder.Events), len(tt.wantMessages))
+			}
+		})
+	}
+}
+
+func Test_validateBackupLocationFailover(t *testing.T) {
+	backupLocations := []oadpv1alpha1.BackupLocation{
+		{
+			Name: "primary",
+			Velero: &velerov1.BackupStorageLocationSpec{
+				Provider: "aws",
+				Default:  true,
+			},
+		},
+		{
+			Name: "secondary",
+			Velero: &velerov1.BackupStorageLocationSpec{
+				Provider: "aws",
+			},
+		},
+	}
+	tests := []struct {
+		name           string
+		failover       *oadpv1alpha1.BackupLocationFailover
+		wantErrMessage string
+	}{
+		{
+			name: "failover not configured",
+		},
+		{
+			name:     "valid failover",
+			failover: &oadpv1alpha1.BackupLocationFailover{Primary: "primary", Secondary: "secondary"},
+		},
+		{
+			name:           "secondary not set",
+			failover:       &oadpv1alpha1.BackupLocationFailover{Primary: "primary"},
+			wantErrMessage: "backupLocationFailover primary and secondary must be set",
+		},
+		{
+			name:           "primary and secondary are the same",
+			failover:       &oadpv1alpha1.BackupLocationFailover{Primary: "primary", Secondary: "primary"},
+			wantErrMessage: "backupLocationFailover primary and secondary must be different backup locations, both are primary",
+		},
+		{
+			name:           "secondary does not exist",
+			failover:       &oadpv1alpha1.BackupLocationFailover{Primary: "primary", Secondary: "missing"},
+			wantErrMessage: "backupLocationFailover backup location missing does not exist in backupLocations",
+		},
+		{
+			name:           "primary is not the default",
+			failover:       &oadpv1alpha1.BackupLocationFailover{Primary: "secondary", Secondary: "primary"},
+			wantErrMessage: "backupLocationFailover primary backup location secondary must be the default backup location",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dpa := &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-dpa",
+					Namespace: "test-ns",
+				},
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					BackupLocations:        backupLocations,
+					BackupLocationFailover: tt.failover,
+				},
+			}
+			err := validateBackupLocationFailover(dpa)
+			if tt.wantErrMessage == "" {
+				if err != nil {
+					t.Errorf("validateBackupLocationFailover() unexpected error = %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErrMessage {
+				t.Errorf("validateBackupLocationFailover() error = %v, want %v", err, tt.wantErrMessage)
+			}
+		})
+	}
+}
+
+func TestDPAReconciler_ReconcileBackupStorageLocationsFailover(t *testing.T) {
+	tests := []struct {
+		name                 string
+		primaryPhase         velerov1.BackupStorageLocationPhase
+		secondaryPhase       velerov1.BackupStorageLocationPhase
+		secondaryDefault     bool
+		wantPrimaryDefault   bool
+		wantSecondaryDefault bool
+		wantEvent            string
+	}{
+		{
+			name:               "primary available",
+			primaryPhase:       velerov1.BackupStorageLocationPhaseAvailable,
+			secondaryPhase:     velerov1.BackupStorageLocationPhaseAvailable,
+			wantPrimaryDefault: true,
+		},
+		{
+			name:                 "primary unavailable fails over to secondary",
+			primaryPhase:         velerov1.BackupStorageLocationPhaseUnavailable,
+			secondaryPhase:       velerov1.BackupStorageLocationPhaseAvailable,
+			wantSecondaryDefault: true,
+			wantEvent:            "Warning BackupLocationFailover backup location primary is unavailable, secondary is set as the default backup location",
+		},
+		{
+			name:                 "primary still unavailable after failover",
+			primaryPhase:         velerov1.BackupStorageLocationPhaseUnavailable,
+			secondaryPhase:       velerov1.BackupStorageLocationPhaseAvailable,
+			secondaryDefault:     true,
+			wantSecondaryDefault: true,
+		},
+		{
+			name:               "primary available again after failover",
+			primaryPhase:       velerov1.BackupStorageLocationPhaseAvailable,
+			secondaryPhase:     velerov1.BackupStorageLocationPhaseAvailable,
+			secondaryDefault:   true,
+			wantPrimaryDefault: true,
+			wantEvent:          "Normal BackupLocationFailback backup location primary is set back as the default backup location",
+		},
+		{
+			name:               "primary and secondary unavailable",
+			primaryPhase:       velerov1.BackupStorageLocationPhaseUnavailable,
+			secondaryPhase:     velerov1.BackupStorageLocationPhaseUnavailable,
+			wantPrimaryDefault: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dpa := &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-dpa",
+					Namespace: "test-ns",
+				},
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					BackupLocations: []oadpv1alpha1.BackupLocation{
+						{
+							Name: "primary",
+							Velero: &velerov1.BackupStorageLocationSpec{
+								Provider: "aws",
+								Default:  true,
+							},
+						},
+						{
+							Name: "secondary",
+							Velero: &velerov1.BackupStorageLocationSpec{
+								Provider: "aws",
+							},
+						},
+					},
+					BackupLocationFailover: &oadpv1alpha1.BackupLocationFailover{
+						Primary:   "primary",
+						Secondary: "secondary",
+					},
+				},
+			}
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "cloud-credentials",
+					Namespace: "test-ns",
+				},
+				Data: map[string][]byte{"credentials": {}},
+			}
+			primary := &velerov1.BackupStorageLocation{
+				ObjectMeta: metav1.ObjectMeta{Name: "primary", Namespace: "test-ns"},
+				Status:     velerov1.BackupStorageLocationStatus{Phase: tt.primaryPhase},
+			}
+			secondary := &velerov1.BackupStorageLocation{
+				ObjectMeta: metav1.ObjectMeta{Name: "secondary", Namespace: "test-ns"},
+				Spec:       velerov1.BackupStorageLocationSpec{Default: tt.secondaryDefault},
+				Status:     velerov1.BackupStorageLocationStatus{Phase: tt.secondaryPhase},
+			}
+			fakeClient, err := getFakeClientFromObjects(dpa, secret, primary, secondary)
+			if err != nil {
+				t.Errorf("error in creating fake client, likely programmer error")
+			}
+			recorder := record.NewFakeRecorder(10)
+			r := &DPAReconciler{
+				Client:  fakeClient,
+				Scheme:  fakeClient.Scheme(),
+				Log:     logr.Discard(),
+				Context: newContextForTest(tt.name),
+				NamespacedName: types.NamespacedName{
+					Namespace: dpa.Namespace,
+					Name:      dpa.Name,
+				},
+				EventRecorder: recorder,
+			}
+			if _, err := r.ReconcileBackupStorageLocations(r.Log); err != nil {
+				t.Errorf("ReconcileBackupStorageLocations() unexpected error = %v", err)
+				return
+			}
+			for name, wantDefault := range map[string]bool{"primary": tt.wantPrimaryDefault, "secondary": tt.wantSecondaryDefault} {
+				bsl := &velerov1.BackupStorageLocation{}
+				if err := r.Get(r.Context, types.NamespacedName{Name: name, Namespace: "test-ns"}, bsl); err != nil {
+					t.Errorf("ReconcileBackupStorageLocations() unable to get BSL %s: %v", name, err)
+					continue
+				}
+				if bsl.Spec.Default != wantDefault {
+					t.Errorf("ReconcileBackupStorageLocations() BSL %s default = %v, want %v", name, bsl.Spec.Default, wantDefault)
+				}
+			}
+			gotEvent := ""
+			for len(recorder.Events) > 0 {
+				if event := <-recorder.Events; strings.Contains(event, "BackupLocationFail") {
+					gotEvent = event
+				}
+			}
+			if gotEvent != tt.wantEvent {
+				t.Errorf("ReconcileBackupStorageLocations() failover event = %q, want %q", gotEvent, tt.wantEvent)
+			}
+		})
+	}
+}
+
+func Test_validatePrefixLength(t *testing.T) {
+	tests := []struct {
+		name           string
+		bsl            *oadpv1alpha1.BackupLocation
+		wantErrMessage string
+	}{
+		{
+			name: "velero prefix at the length limit",
+			bsl: &oadpv1alpha1.BackupLocation{
+				Velero: &velerov1.BackupStorageLocationSpec{
+					StorageType: velerov1.StorageType{
+						ObjectStorage: &velerov1.ObjectStorageLocation{
+							Bucket: "bucket",
+							Prefix: strings.Repeat("p", 473),
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "velero prefix above the length limit",
+			bsl: &oadpv1alpha1.BackupLocation{
+				Velero: &velerov1.BackupStorageLocationSpec{
+					StorageType: velerov1.StorageType{
+						ObjectStorage: &velerov1.ObjectStorageLocation{
+							Bucket: "bucket",
+							Prefix: strings.Repeat("p", 474),
+						},
+					},
+				},
+			},
+			wantErrMessage: "BackupLocation prefix is 474 bytes long, it must not exceed 473 bytes so backup object keys fit the 1024 byte object storage key limit",
+		},
+		{
+			name: "cloud storage prefix above the length limit",
+			bsl: &oadpv1alpha1.BackupLocation{
+				CloudStorage: &oadpv1alpha1.CloudStorageLocation{
+					Prefix: strings.Repeat("p", 1000),
+				},
+			},
+			wantErrMessage: "BackupLocation prefix is 1000 bytes long, it must not exceed 473 bytes so backup object keys fit the 1024 byte object storage key limit",
+		},
+		{
+			name: "velero without object storage",
+			bsl: &oadpv1alpha1.BackupLocation{
+				Velero: &velerov1.BackupStorageLocationSpec{},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validatePrefixLength(tt.bsl)
+			if tt.wantErrMessage == "" {
+				if err != nil {
+					t.Errorf("validatePrefixLength() unexpected error = %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErrMessage {
+				t.Errorf("validatePrefixLength() error = %v, want %v", err, tt.wantErrMessage)
+			}
+		})
+	}
+}
+
+func Test_validateCredentialKey(t *testing.T) {
+	tests := []struct {
+		name           string
+		bsl            *oadpv1alpha1.BackupLocation
+		wantErrMessage string
+	}{
+		{
+			name: "velero credential with valid key",
+			bsl: &oadpv1alpha1.BackupLocation{
+				Velero: &velerov1.BackupStorageLocationSpec{
+					Credential: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "cloud-credentials"},
+						Key:                  "cloud",
+					},
+				},
+			},
+		},
+		{
+			name: "velero credential key with a slash",
+			bsl: &oadpv1alpha1.BackupLocation{
+				Velero: &velerov1.BackupStorageLocationSpec{
+					Credential: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "cloud-credentials"},
+						Key:                  "creds/default",
+					},
+				},
+			},
+			wantErrMessage: "BackupLocation credential key \"creds/default\" of secret cloud-credentials is not a valid secret key, keys may only contain alphanumeric characters, '-', '_' or '.'",
+		},
+		{
+			name: "cloud storage credential key with a slash",
+			bsl: &oadpv1alpha1.BackupLocation{
+				CloudStorage: &oadpv1alpha1.CloudStorageLocation{
+					Credential: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "cloud-credentials"},
+						Key:                  "creds/default",
+					},
+				},
+			},
+			wantErrMessage: "BackupLocation credential key \"creds/default\" of secret cloud-credentials is not a valid secret key, keys may only contain alphanumeric characters, '-', '_' or '.'",
+		},
+		{
+			name: "velero without credential",
+			bsl: &oadpv1alpha1.BackupLocation{
+				Velero: &velerov1.BackupStorageLocationSpec{},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateCredentialKey(tt.bsl)
+			if tt.wantErrMessage == "" {
+				if err != nil {
+					t.Errorf("validateCredentialKey() unexpected error = %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErrMessage {
+				t.Errorf("validateCredentialKey() error = %v, want %v", err, tt.wantErrMessage)
+			}
+		})
+	}
+}
+
+func Test_validateBackupLocationConfig(t *testing.T) {
+	tests := []struct {
+		name           string
+		bslSpec        *velerov1.BackupStorageLocationSpec
+		wantErrMessage string
+	}{
+		{
+			name: "aws without s3Url",
+			bslSpec: &velerov1.BackupStorageLocationSpec{
+				Provider: "aws",
+				Config:   map[string]string{Region: "us-east-1"},
+			},
+		},
+		{
+			name: "aws s3Url without s3ForcePathStyle",
+			bslSpec: &velerov1.BackupStorageLocationSpec{
+				Provider: "aws",
+				Config:   map[string]string{S3URL: "https://minio.example.com", Region: "minio"},
+			},
+			wantErrMessage: "BackupLocation test-bsl: s3Url requires s3ForcePathStyle to be set to true",
+		},
+		{
+			name: "aws s3Url with s3ForcePathStyle false",
+			bslSpec: &velerov1.BackupStorageLocationSpec{
+				Provider: "velero.io/aws",
+				Config:   map[string]string{S3URL: "https://minio.example.com", S3ForcePathStyle: "false", Region: "minio"},
+			},
+			wantErrMessage: "BackupLocation test-bsl: s3Url requires s3ForcePathStyle to be set to true",
+		},
+		{
+			name: "aws s3Url without region",
+			bslSpec: &velerov1.BackupStorageLocationSpec{
+				Provider: "aws",
+				Config:   map[string]string{S3URL: "https://minio.example.com", S3ForcePathStyle: "true"},
+			},
+			wantErrMessage: "BackupLocation test-bsl: s3Url requires region to be set",
+		},
+		{
+			name: "aws profile and s3Url without s3ForcePathStyle",
+			bslSpec: &velerov1.BackupStorageLocationSpec{
+				Provider: "aws",
+				Config:   map[string]string{Profile: "default", S3URL: "https://rgw.example.com", Region: "us-east-1"},
+			},
+			wantErrMessage: "BackupLocation test-bsl: s3Url requires s3ForcePathStyle to be set to true",
+		},
+		{
+			name: "aws profile and s3Url with s3ForcePathStyle and region",
+			bslSpec: &velerov1.BackupStorageLocationSpec{
+				Provider: "aws",
+				Config:   map[string]string{Profile: "default", S3URL: "https://rgw.example.com", S3ForcePathStyle: "true", Region: "us-east-1"},
+			},
+		},
+		{
+			name: "gcp workload identity with service account",
+			bslSpec: &velerov1.BackupStorageLocationSpec{
+				Provider: "gcp",
+				Config:   map[string]string{GCPUseWorkloadIdentity: "true", GCPServiceAccount: "velero@project.iam.gserviceaccount.com"},
+			},
+		},
+		{
+			name: "gcp workload identity without service account",
+			bslSpec: &velerov1.BackupStorageLocationSpec{
+				Provider: "velero.io/gcp",
+				Config:   map[string]string{GCPUseWorkloadIdentity: "true"},
+			},
+			wantErrMessage: "BackupLocation test-bsl: useWorkloadIdentity requires serviceAccount to be set to the GCP service account of the workload identity",
+		},
+		{
+			name: "gcp workload identity with credential",
+			bslSpec: &velerov1.BackupStorageLocationSpec{
+				Provider:   "gcp",
+				Config:     map[string]string{GCPUseWorkloadIdentity: "true", GCPServiceAccount: "velero@project.iam.gserviceaccount.com"},
+				Credential: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "cloud-credentials-gcp"}, Key: "cloud"},
+			},
+			wantErrMessage: "BackupLocation test-bsl: useWorkloadIdentity cannot be used with credential cloud-credentials-gcp",
+		},
+		{
+			name: "gcp workload identity not a boolean",
+			bslSpec: &velerov1.BackupStorageLocationSpec{
+				Provider: "gcp",
+				Config:   map[string]string{GCPUseWorkloadIdentity: "yes"},
+			},
+			wantErrMessage: "BackupLocation test-bsl: useWorkloadIdentity must be true or false, got \"yes\"",
+		},
+		{
+			name: "gcp workload identity disabled",
+			bslSpec: &velerov1.BackupStorageLocationSpec{
+				Provider: "gcp",
+				Config:   map[string]string{GCPUseWorkloadIdentity: "false"},
+			},
+		},
+		{
+			name: "s3Url is only checked for aws",
+			bslSpec: &velerov1.BackupStorageLocationSpec{
+				Provider: "gcp",
+				Config:   map[string]string{S3URL: "https://minio.example.com"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateBackupLocationConfig("test-bsl", tt.bslSpec)
+			if tt.wantErrMessage == "" {
+				if err != nil {
+					t.Errorf("validateBackupLocationConfig() unexpected error = %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErrMessage {
+				t.Errorf("validateBackupLocationConfig() error = %v, want %v", err, tt.wantErrMessage)
+			}
+		})
+	}
+}
+
+func Test_validateBackupLocationNames(t *testing.T) {
+	tests := []struct {
+		name            string
+		backupLocations []oadpv1alpha1.BackupLocation
+		wantErrMessage  string
+	}{
+		{
+			name:            "distinct names",
+			backupLocations: []oadpv1alpha1.BackupLocation{{Name: "default"}, {Name: "secondary"}},
+		},
+		{
+			name:            "multiple unnamed locations get distinct generated names",
+			backupLocations: []oadpv1alpha1.BackupLocation{{}, {}},
+		},
+		{
+			name:            "duplicate names",
+			backupLocations: []oadpv1alpha1.BackupLocation{{Name: "default"}, {Name: "default"}},
+			wantErrMessage:  "duplicate BackupLocation name \"default\" found",
+		},
+		{
+			name:            "name collides with generated name of an unnamed location",
+			backupLocations: []oadpv1alpha1.BackupLocation{{}, {Name: "test-DPA-CR-1"}},
+			wantErrMessage:  "duplicate BackupLocation name \"test-DPA-CR-1\" found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dpa := &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-DPA-CR", Namespace: "test-ns"},
+				Spec:       oadpv1alpha1.DataProtectionApplicationSpec{BackupLocations: tt.backupLocations},
+			}
+			err := validateBackupLocationNames(dpa)
+			if tt.wantErrMessage == "" {
+				if err != nil {
+					t.Errorf("validateBackupLocationNames() unexpected error = %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErrMessage {
+				t.Errorf("validateBackupLocationNames() error = %v, want %v", err, tt.wantErrMessage)
+			}
+		})
+	}
+}
+
+func Test_validateCACert(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("error generating key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "test-ca"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+		IsCA:         true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("error creating certificate: %v", err)
+	}
+	caCert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("error marshaling key: %v", err)
+	}
+	privateKey := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})
+
+	tests := []struct {
+		name           string
+		bsl            *oadpv1alpha1.BackupLocation
+		wantErrMessage string
+	}{
+		{
+			name: "velero without caCert",
+			bsl: &oadpv1alpha1.BackupLocation{
+				Velero: &velerov1.BackupStorageLocationSpec{StorageType: velerov1.StorageType{ObjectStorage: &velerov1.ObjectStorageLocation{Bucket: "bucket"}}},
+			},
+		},
+		{
+			name: "velero with certificate",
+			bsl: &oadpv1alpha1.BackupLocation{
+				Velero: &velerov1.BackupStorageLocationSpec{StorageType: velerov1.StorageType{ObjectStorage: &velerov1.ObjectStorageLocation{Bucket: "bucket", CACert: caCert}}},
+			},
+		},
+		{
+			name: "velero with private key before certificate",
+			bsl: &oadpv1alpha1.BackupLocation{
+				Velero: &velerov1.BackupStorageLocationSpec{StorageType: velerov1.StorageType{ObjectStorage: &velerov1.ObjectStorageLocation{Bucket: "bucket", CACert: append(privateKey, caCert...)}}},
+			},
+		},
+		{
+			name: "velero with non PEM caCert",
+			bsl: &oadpv1alpha1.BackupLocation{
+				Velero: &velerov1.BackupStorageLocationSpec{StorageType: velerov1.StorageType{ObjectStorage: &velerov1.ObjectStorageLocation{Bucket: "bucket", CACert: []byte("test-ca")}}},
+			},
+			wantErrMessage: "BackupLocation test-bsl: caCert is not valid PEM",
+		},
+		{
+			name: "velero with private key only",
+			bsl: &oadpv1alpha1.BackupLocation{
+				Velero: &velerov1.BackupStorageLocationSpec{StorageType: velerov1.StorageType{ObjectStorage: &velerov1.ObjectStorageLocation{Bucket: "bucket", CACert: privateKey}}},
+			},
+			wantErrMessage: "BackupLocation test-bsl: caCert is not valid PEM",
+		},
+		{
+			name: "velero with corrupt certificate block",
+			bsl: &oadpv1alpha1.BackupLocation{
+				Velero: &velerov1.BackupStorageLocationSpec{StorageType: velerov1.StorageType{ObjectStorage: &velerov1.ObjectStorageLocation{
+					Bucket: "bucket",
+					CACert: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte("not a certificate")}),
+				}}},
+			},
+			wantErrMessage: "BackupLocation test-bsl: caCert is not valid PEM",
+		},
+		{
+			name: "cloud storage with non PEM caCert",
+			bsl: &oadpv1alpha1.BackupLocation{
+				CloudStorage: &oadpv1alpha1.CloudStorageLocation{CACert: []byte("test-ca")},
+			},
+			wantErrMessage: "BackupLocation test-bsl: caCert is not valid PEM",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateCACert("test-bsl", tt.bsl)
+			if tt.wantErrMessage == "" {
+				if err != nil {
+					t.Errorf("validateCACert() unexpected error = %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErrMessage {
+				t.Errorf("validateCACert() error = %v, want %v", err, tt.wantErrMessage)
+			}
+		})
+	}
+}
+
+func Test_validateBackupSyncPeriod(t *testing.T) {
+	tests := []struct {
+		name           string
+		bsl            *oadpv1alpha1.BackupLocation
+		wantErrMessage string
+	}{
+		{
+			name: "velero without backup sync period",
+			bsl:  &oadpv1alpha1.BackupLocation{Velero: &velerov1.BackupStorageLocationSpec{}},
+		},
+		{
+			name: "velero with backup sync period",
+			bsl: &oadpv1alpha1.BackupLocation{
+				Velero: &velerov1.BackupStorageLocationSpec{BackupSyncPeriod: &metav1.Duration{Duration: 30 * time.Second}},
+			},
+		},
+		{
+			name: "velero with backup sync disabled",
+			bsl: &oadpv1alpha1.BackupLocation{
+				Velero: &velerov1.BackupStorageLocationSpec{BackupSyncPeriod: &metav1.Duration{}},
+			},
+		},
+		{
+			name: "velero with negative backup sync period",
+			bsl: &oadpv1alpha1.BackupLocation{
+				Velero: &velerov1.BackupStorageLocationSpec{BackupSyncPeriod: &metav1.Duration{Duration: -time.Minute}},
+			},
+			wantErrMessage: "BackupLocation test-bsl: backupSyncPeriod -1m0s must not be negative, use 0 to disable backup sync",
+		},
+		{
+			name: "velero with backup sync period in config",
+			bsl: &oadpv1alpha1.BackupLocation{
+				Velero: &velerov1.BackupStorageLocationSpec{Config: map[string]string{"backupSyncPeriod": "30"}},
+			},
+			wantErrMessage: "BackupLocation test-bsl: backupSyncPeriod \"30\" must be set in the velero backupSyncPeriod field instead of config",
+		},
+		{
+			name: "cloud storage with negative backup sync period",
+			bsl: &oadpv1alpha1.BackupLocation{
+				CloudStorage: &oadpv1alpha1.CloudStorageLocation{BackupSyncPeriod: &metav1.Duration{Duration: -time.Second}},
+			},
+			wantErrMessage: "BackupLocation test-bsl: backupSyncPeriod -1s must not be negative, use 0 to disable backup sync",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateBackupSyncPeriod("test-bsl", tt.bsl)
+			if tt.wantErrMessage == "" {
+				if err != nil {
+					t.Errorf("validateBackupSyncPeriod() unexpected error = %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErrMessage {
+				t.Errorf("validateBackupSyncPeriod() error = %v, want %v", err, tt.wantErrMessage)
+			}
+		})
+	}
+}
+
+func TestDPAReconciler_validateCloudStorageBucket(t *testing.T) {
+	type headBucketCall struct {
+		bucket, region, s3URL string
+		forcePathStyle        bool
+		credentials, profile  string
+	}
+	tests := []struct {
+		name           string
+		config         map[string]string
+		headBucketErr  error
+		wantCall       *headBucketCall
+		wantErrMessage string
+	}{
+		{
+			name:   "bucket validation not enabled",
+			config: map[string]string{"region": "us-west-2"},
+		},
+		{
+			name:   "reachable bucket",
+			config: map[string]string{CloudStorageValidateBucket: "true", "s3Url": "https://minio.example.com", "s3ForcePathStyle": "true"},
+			wantCall: &headBucketCall{
+				bucket:         "my-bucket",
+				region:         "us-east-1",
+				s3URL:          "https://minio.example.com",
+				forcePathStyle: true,
+				credentials:    "[default]\naws_access_key_id=key\naws_secret_access_key=secret\n",
+				profile:        "default",
+			},
+		},
+		{
+			name:          "unreachable bucket",
+			config:        map[string]string{CloudStorageValidateBucket: "true", "region": "eu-west-1", "profile": "backup"},
+			headBucketErr: fmt.Errorf("Forbidden: Forbidden"),
+			wantCall: &headBucketCall{
+				bucket:      "my-bucket",
+				region:      "eu-west-1",
+				credentials: "[default]\naws_access_key_id=key\naws_secret_access_key=secret\n",
+				profile:     "backup",
+			},
+			wantErrMessage: "CloudStorage testing: bucket \"my-bucket\" not reachable: Forbidden: Forbidden",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dpa := &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-DPA-CR", Namespace: "test-ns"},
+			}
+			cloudStorage := &oadpv1alpha1.CloudStorage{
+				ObjectMeta: metav1.ObjectMeta{Name: "testing", Namespace: "test-ns"},
+				Spec:       oadpv1alpha1.CloudStorageSpec{Name: "my-bucket", Provider: oadpv1alpha1.AWSBucketProvider},
+			}
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "cloud-credentials", Namespace: "test-ns"},
+				Data:       map[string][]byte{"cloud": []byte("[default]\naws_access_key_id=key\naws_secret_access_key=secret\n")},
+			}
+			fakeClient, err := getFakeClientFromObjects(dpa, cloudStorage, secret)
+			if err != nil {
+				t.Errorf("error in creating fake client, likely programmer error")
+			}
+			r := &DPAReconciler{
+				Client:  fakeClient,
+				Scheme:  fakeClient.Scheme(),
+				Log:     logr.Discard(),
+				Context: newContextForTest(tt.name),
+			}
+			var gotCall *headBucketCall
+			headBucket = func(bucket, region, s3URL string, forcePathStyle bool, credentialsFile, profile string) error {
+				credentials, err := os.ReadFile(credentialsFile)
+				if err != nil {
+					t.Errorf("unable to read credentials file: %v", err)
+				}
+				gotCall = &headBucketCall{bucket, region, s3URL, forcePathStyle, string(credentials), profile}
+				return tt.headBucketErr
+			}
+			defer func() { headBucket = aws.HeadBucket }()
+			bsl := &oadpv1alpha1.BackupLocation{
+				CloudStorage: &oadpv1alpha1.CloudStorageLocation{
+					CloudStorageRef: corev1.LocalObjectReference{Name: "testing"},
+					Config:          tt.config,
+					Credential: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "cloud-credentials"},
+						Key:                  "cloud",
+					},
+				},
+			}
+			err = r.validateCloudStorageBucket(dpa, bsl)
+			if !reflect.DeepEqual(gotCall, tt.wantCall) {
+				t.Errorf("validateCloudStorageBucket() head bucket call = %+v, want %+v", gotCall, tt.wantCall)
+			}
+			if tt.wantErrMessage == "" {
+				if err != nil {
+					t.Errorf("validateCloudStorageBucket() unexpected error = %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErrMessage {
+				t.Errorf("validateCloudStorageBucket() error = %v, want %v", err, tt.wantErrMessage)
+			}
+		})
+	}
+}
+
+func Test_cloudStorageBSLConfig(t *testing.T) {
+	config := map[string]string{CloudStorageValidateBucket: "true", "region": "us-west-2"}
+	got := cloudStorageBSLConfig(config)
+	if !reflect.DeepEqual(got, map[string]string{"region": "us-west-2"}) {
+		t.Errorf("cloudStorageBSLConfig() = %v, want the config without %s", got, CloudStorageValidateBucket)
+	}
+	if _, found := config[CloudStorageValidateBucket]; !found {
+		t.Errorf("cloudStorageBSLConfig() modified the backup location config")
+	}
+}
+
+func Test_unrecognizedBackupLocationConfigKeys(t *testing.T) {
+	tests := []struct {
+		name            string
+		backupLocations []oadpv1alpha1.BackupLocation
+		want            []string
+	}{
+		{
+			name: "recognized config keys",
+			backupLocations: []oadpv1alpha1.BackupLocation{
+				{Velero: &velerov1.BackupStorageLocationSpec{Provider: AWSProvider, Config: map[string]string{Region: "us-east-1", Profile: "default"}}},
+				{Velero: &velerov1.BackupStorageLocationSpec{Provider: "velero.io/azure", Config: map[string]string{ResourceGroup: "rg", StorageAccount: "account"}}},
+			},
+			want: []string{},
+		},
+		{
+			name: "azure config key on an aws backup location",
+			backupLocations: []oadpv1alpha1.BackupLocation{
+				{Name: "aws-bsl", Velero: &velerov1.BackupStorageLocationSpec{Provider: AWSProvider, Config: map[string]string{Region: "us-east-1", ResourceGroup: "rg"}}},
+			},
+			want: []string{"BackupLocation aws-bsl: config key \"resourceGroup\" is not recognized by the aws provider"},
+		},
+		{
+			name: "unnamed gcp backup location",
+			backupLocations: []oadpv1alpha1.BackupLocation{
+				{Velero: &velerov1.BackupStorageLocationSpec{Provider: AWSProvider}},
+				{Velero: &velerov1.BackupStorageLocationSpec{Provider: GCPProvider, Config: map[string]string{Region: "us-east1", "project": "example"}}},
+			},
+			want: []string{
+				"BackupLocation test-DPA-CR-2: config key \"project\" is not recognized by the gcp provider",
+				"BackupLocation test-DPA-CR-2: config key \"region\" is not recognized by the gcp provider",
+			},
+		},
+		{
+			name: "custom provider and cloudStorage backup locations",
+			backupLocations: []oadpv1alpha1.BackupLocation{
+				{Velero: &velerov1.BackupStorageLocationSpec{Provider: "example.com/custom", Config: map[string]string{"endpoint": "example.com"}}},
+				{CloudStorage: &oadpv1alpha1.CloudStorageLocation{Config: map[string]string{"anyKey": "value"}}},
+			},
+			want: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dpa := &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-DPA-CR"},
+				Spec:       oadpv1alpha1.DataProtectionApplicationSpec{BackupLocations: tt.backupLocations},
+			}
+			if got := unrecognizedBackupLocationConfigKeys(dpa); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("unrecognizedBackupLocationConfigKeys() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDPAReconciler_validateBackupLocationMirror(t *testing.T) {
+	tests := []struct {
+		name           string
+		mirror         *oadpv1alpha1.BackupLocationMirror
+		wantErrMessage string
+	}{
+		{
+			name: "mirror not configured",
+		},
+		{
+			name:   "existing namespace",
+			mirror: &oadpv1alpha1.BackupLocationMirror{Namespace: "dr-ns"},
+		},
+		{
+			name:           "DPA namespace",
+			mirror:         &oadpv1alpha1.BackupLocationMirror{Namespace: "test-ns"},
+			wantErrMessage: "backupLocationMirror namespace must be different from the DPA namespace test-ns",
+		},
+		{
+			name:           "invalid namespace name",
+			mirror:         &oadpv1alpha1.BackupLocationMirror{Namespace: "DR_ns"},
+			wantErrMessage: "backupLocationMirror namespace \"DR_ns\" is not a valid namespace name: a lowercase RFC 1123 label must consist of lower case alphanumeric characters or '-', and must start and end with an alphanumeric character (e.g. 'my-name',  or '123-abc', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?')",
+		},
+		{
+			name:           "missing namespace",
+			mirror:         &oadpv1alpha1.BackupLocationMirror{Namespace: "missing-ns"},
+			wantErrMessage: "backupLocationMirror namespace missing-ns: namespaces \"missing-ns\" not found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dpa := &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-dpa", Namespace: "test-ns"},
+				Spec:       oadpv1alpha1.DataProtectionApplicationSpec{BackupLocationMirror: tt.mirror},
+			}
+			fakeClient, err := getFakeClientFromObjects(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "dr-ns"}})
+			if err != nil {
+				t.Errorf("error in creating fake client, likely programmer error")
+			}
+			r := &DPAReconciler{
+				Client:  fakeClient,
+				Context: newContextForTest(tt.name),
+			}
+			err = r.validateBackupLocationMirror(dpa)
+			if tt.wantErrMessage == "" {
+				if err != nil {
+					t.Errorf("validateBackupLocationMirror() unexpected error = %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErrMessage {
+				t.Errorf("validateBackupLocationMirror() error = %v, want %v", err, tt.wantErrMessage)
+			}
+		})
+	}
+}
+
+func TestDPAReconciler_ReconcileBackupLocationMirror(t *testing.T) {
+	mirrorLabels := map[string]string{oadpBackupLocationMirrorLabel: "test-ns", "app.kubernetes.io/instance": "test-dpa"}
+	tests := []struct {
+		name            string
+		mirror          *oadpv1alpha1.BackupLocationMirror
+		mirroredTo      string
+		objects         []client.Object
+		wantErrMessage  string
+		wantMirrored    []types.NamespacedName
+		wantNotMirrored []types.NamespacedName
+	}{
+		{
+			name:         "backup location mirrored read only",
+			mirror:       &oadpv1alpha1.BackupLocationMirror{Namespace: "dr-ns"},
+			wantMirrored: []types.NamespacedName{{Namespace: "dr-ns", Name: "primary"}},
+		},
+		{
+			name:       "mirror of a removed backup location is deleted",
+			mirror:     &oadpv1alpha1.BackupLocationMirror{Namespace: "dr-ns"},
+			mirroredTo: "dr-ns",
+			objects: []client.Object{
+				&velerov1.BackupStorageLocation{ObjectMeta: metav1.ObjectMeta{Name: "removed", Namespace: "dr-ns", Labels: mirrorLabels}},
+			},
+			wantMirrored:    []types.NamespacedName{{Namespace: "dr-ns", Name: "primary"}},
+			wantNotMirrored: []types.NamespacedName{{Namespace: "dr-ns", Name: "removed"}},
+		},
+		{
+			name:       "mirrors in the previous namespace are deleted",
+			mirror:     &oadpv1alpha1.BackupLocationMirror{Namespace: "dr-ns"},
+			mirroredTo: "old-dr-ns",
+			objects: []client.Object{
+				&velerov1.BackupStorageLocation{ObjectMeta: metav1.ObjectMeta{Name: "primary", Namespace: "old-dr-ns", Labels: mirrorLabels}},
+			},
+			wantMirrored:    []types.NamespacedName{{Namespace: "dr-ns", Name: "primary"}},
+			wantNotMirrored: []types.NamespacedName{{Namespace: "old-dr-ns", Name: "primary"}},
+		},
+		{
+			name:       "mirrors are deleted when the mirror is removed",
+			mirroredTo: "dr-ns",
+			objects: []client.Object{
+				&velerov1.BackupStorageLocation{ObjectMeta: metav1.ObjectMeta{Name: "primary", Namespace: "dr-ns", Labels: mirrorLabels}},
+			},
+			wantNotMirrored: []types.NamespacedName{{Namespace: "dr-ns", Name: "primary"}},
+		},
+		{
+			name:   "backup location not mirrored from the DPA is not overwritten",
+			mirror: &oadpv1alpha1.BackupLocationMirror{Namespace: "dr-ns"},
+			objects: []client.Object{
+				&velerov1.BackupStorageLocation{ObjectMeta: metav1.ObjectMeta{Name: "primary", Namespace: "dr-ns"}},
+			},
+			wantErrMessage: "backupstoragelocation dr-ns/primary already exists and is not mirrored from DPA test-ns/test-dpa",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dpa := &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-dpa",
+					Namespace: "test-ns",
+				},
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					BackupLocations: []oadpv1alpha1.BackupLocation{
+						{
+							Name: "primary",
+							Velero: &velerov1.BackupStorageLocationSpec{
+								Provider: "aws",
+								Default:  true,
+							},
+						},
+					},
+					BackupLocationMirror: tt.mirror,
+				},
+				Status: oadpv1alpha1.DataProtectionApplicationStatus{MirroredBackupLocationsNamespace: tt.mirroredTo},
+			}
+			source := &velerov1.BackupStorageLocation{
+				ObjectMeta: metav1.ObjectMeta{Name: "primary", Namespace: "test-ns"},
+				Spec: velerov1.BackupStorageLocationSpec{
+					Provider: "aws",
+					Default:  true,
+					StorageType: velerov1.StorageType{
+						ObjectStorage: &velerov1.ObjectStorageLocation{Bucket: "bucket", Prefix: "velero"},
+					},
+					Config: map[string]string{Region: "us-east-1"},
+				},
+			}
+			fakeClient, err := getFakeClientFromObjects(append(tt.objects, dpa, source)...)
+			if err != nil {
+				t.Errorf("error in creating fake client, likely programmer error")
+			}
+			r := &DPAReconciler{
+				Client:  fakeClient,
+				Scheme:  fakeClient.Scheme(),
+				Log:     logr.Discard(),
+				Context: newContextForTest(tt.name),
+				NamespacedName: types.NamespacedName{
+					Namespace: dpa.Namespace,
+					Name:      dpa.Name,
+				},
+				EventRecorder: record.NewFakeRecorder(10),
+			}
+			_, err = r.ReconcileBackupLocationMirror(r.Log)
+			if tt.wantErrMessage != "" {
+				if err == nil || err.Error() != tt.wantErrMessage {
+					t.Errorf("ReconcileBackupLocationMirror() error = %v, want %v", err, tt.wantErrMessage)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("ReconcileBackupLocationMirror() unexpected error = %v", err)
+				return
+			}
+			for _, key := range tt.wantMirrored {
+				mirror := &velerov1.BackupStorageLocation{}
+				if err := r.Get(r.Context, key, mirror); err != nil {
+					t.Errorf("ReconcileBackupLocationMirror() unable to get mirrored BSL %s: %v", key, err)
+					continue
+				}
+				if mirror.Spec.AccessMode != velerov1.BackupStorageLocationAccessModeReadOnly || mirror.Spec.Default {
+					t.Errorf("ReconcileBackupLocationMirror() mirrored BSL %s access mode = %s, default = %v, want read only and not default", key, mirror.Spec.AccessMode, mirror.Spec.Default)
+				}
+				if !reflect.DeepEqual(mirror.Spec.ObjectStorage, source.Spec.ObjectStorage) || !reflect.DeepEqual(mirror.Spec.Config, source.Spec.Config) {
+					t.Errorf("ReconcileBackupLocationMirror() mirrored BSL %s spec = %v, want the spec of %s/%s", key, mirror.Spec, source.Namespace, source.Name)
+				}
+				if !mirroredFrom(mirror, dpa) {
+					t.Errorf("ReconcileBackupLocationMirror() mirrored BSL %s labels = %v, want mirror labels", key, mirror.Labels)
+				}
+			}
+			for _, key := range tt.wantNotMirrored {
+				if err := r.Get(r.Context, key, &velerov1.BackupStorageLocation{}); !k8serror.IsNotFound(err) {
+					t.Errorf("ReconcileBackupLocationMirror() expected mirrored BSL %s to be deleted, got error = %v", key, err)
+				}
+			}
+		})
+	}
+}
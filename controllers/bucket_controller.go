@@ -126,6 +126,10 @@ func (b BucketReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 			logger.Error(err, "unable to fetch secert created by CCO")
 			return result, err
 		}
+	} else if err := b.validateCreationSecret(ctx, &bucket); err != nil {
+		logger.Error(err, "invalid creation secret")
+		b.EventRecorder.Event(&bucket, corev1.EventTypeWarning, "InvalidCreationSecret", fmt.Sprintf("invalid creation secret: %v", err))
+		return ctrl.Result{RequeueAfter: 1 * time.Minute}, nil
 	}
 	// Now continue with bucket creation as secret exists and we are good to go !!!
 	if ok, err = clnt.Exists(); !ok && err == nil {
@@ -188,6 +192,16 @@ func bucketPredicate() predicate.Predicate {
 	}
 }
 
+// validateCreationSecret checks the creation secret of the CloudStorage exists and has the credentials format
+// of its provider
+func (b *BucketReconciler) validateCreationSecret(ctx context.Context, bucket *oadpv1alpha1.CloudStorage) error {
+	secret := corev1.Secret{}
+	if err := b.Client.Get(ctx, types.NamespacedName{Name: bucket.Spec.CreationSecret.Name, Namespace: bucket.Namespace}, &secret); err != nil {
+		return err
+	}
+	return validateCloudStorageSecretContent(bucket.Spec.Provider, secret.Data, bucket.Spec.CreationSecret.Key)
+}
+
 func containFinalizer(finalizers []string, f string) bool {
 	for _, finalizer := range finalizers {
 		if finalizer == f {
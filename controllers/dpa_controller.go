@@ -18,7 +18,11 @@ package controllers
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	routev1 "github.com/openshift/api/route/v1"
@@ -26,6 +30,8 @@ import (
 	velerov1 "github.com/vmware-tanzu/velero/pkg/apis/velero/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -51,10 +57,33 @@ type DPAReconciler struct {
 	Context        context.Context
 	NamespacedName types.NamespacedName
 	EventRecorder  record.EventRecorder
+	// APIReader reads objects outside of the watched namespace, which the cached client does not see
+	APIReader client.Reader
+	// validationOutcomes holds the validation result last recorded as an event for each DPA
+	validationOutcomes map[types.NamespacedName]validationOutcome
+	// advisoryOutcomes holds the advisories recorded as events for the last validated generation of each DPA
+	advisoryOutcomes map[types.NamespacedName]map[validationOutcome]bool
+	// nodeAgentConflictChecks holds the generation of each DPA last checked for conflicting node agents
+	nodeAgentConflictChecks map[types.NamespacedName]int64
+	// validationRetries counts the consecutive retriable validation failures of each DPA
+	validationRetries map[types.NamespacedName]int
 }
 
 var debugMode = os.Getenv("DEBUG") == "true"
 
+// DPAs with this annotation set to true are only validated, velero resources are not created or updated for them
+const oadpValidateOnlyAnnotation = "oadp.openshift.io/validate-only"
+
+const (
+	// overrides the maximum delay between retries of a DPA failing validation on a missing or unwatched object, as a duration
+	ValidationRetryMaxDelayOverride = "VALIDATION_RETRY_MAX_DELAY"
+
+	// objects missing at validation, such as secrets, are usually applied along with the DPA, so validation is
+	// first retried after validationRetryBaseDelay, doubling the delay up to the maximum delay
+	validationRetryBaseDelay       = 5 * time.Second
+	defaultValidationRetryMaxDelay = 2 * time.Minute
+)
+
 //TODO!!! FIX THIS!!!!
 
 //+kubebuilder:rbac:groups=*,resources=*,verbs=*
@@ -90,10 +119,28 @@ func (r *DPAReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.R
 	// set client to pkg/client for use in non-reconcile functions
 	oadpclient.SetClient(r.Client)
 
+	if dpa.DeletionTimestamp != nil && containFinalizer(dpa.Finalizers, oadpFinalizerDPACleanup) {
+		// Clean up velero resources created for the DPA before it is removed
+		return result, r.FinalizeDataProtectionApplication(logger, &dpa)
+	}
+	if dpa.DeletionTimestamp == nil {
+		if err := r.ReconcileCleanupFinalizer(&dpa); err != nil {
+			return result, err
+		}
+	}
+
+	if validateOnlyEnabled(&dpa) {
+		err := r.reconcileValidateOnly(&dpa)
+		return result, r.requeueValidationFailure(&result, err)
+	}
+
 	_, err := ReconcileBatch(r.Log,
+		// velero runs the schedules even while the DPA fails validation, so they are paused and resumed first
+		r.ReconcileBackupMaintenanceWindow,
 		r.ValidateDataProtectionCR,
 		r.ReconcileFsRestoreHelperConfig,
 		r.ReconcileBackupStorageLocations,
+		r.ReconcileBackupLocationMirror,
 		r.ReconcileRegistrySecrets,
 		r.ReconcileRegistries,
 		r.ReconcileRegistrySVCs,
@@ -102,8 +149,11 @@ func (r *DPAReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.R
 		r.LabelVSLSecrets,
 		r.ReconcileVolumeSnapshotLocations,
 		r.ReconcileVeleroDeployment,
+		r.ReconcileVeleroPodDisruptionBudget,
+		r.ReconcileVeleroEgressNetworkPolicy,
 		r.ReconcileNodeAgentDaemonset,
 		r.ReconcileVeleroMetricsSVC,
+		r.ReconcileNodeAgentMetricsSVC,
 		r.ReconcileNonAdminController,
 	)
 
@@ -127,12 +177,208 @@ func (r *DPAReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.R
 			},
 		)
 	}
+	dpa.Status.ValidationErrors = validationErrors(err)
+	setFeatureFlagsCondition(&dpa)
+	setBackupLocationConfigCondition(&dpa)
+	// the Validated condition is only kept up to date in validate only mode
+	apimeta.RemoveStatusCondition(&dpa.Status.Conditions, oadpv1alpha1.ConditionValidated)
+	if resolutions, resolveErr := r.getCredentialResolutions(&dpa); resolveErr == nil {
+		dpa.Status.CredentialResolutions = resolutions
+	}
+	if plugins, pluginsErr := r.pluginsFailingCredentialValidation(&dpa); pluginsErr == nil {
+		dpa.Status.PluginsFailedCredentialValidation = plugins
+	}
+	if err == nil {
+		if args, argsErr := r.buildVeleroServerArgs(&dpa); argsErr == nil {
+			dpa.Status.VeleroServerArgs = args
+		}
+		dpa.Status.MirroredBackupLocationsNamespace = backupLocationMirrorNamespace(&dpa)
+		dpa.Status.VeleroImage = getVeleroImage(&dpa)
+		if plugins, pluginsErr := r.installedPlugins(&dpa); pluginsErr == nil {
+			dpa.Status.PluginsInstalled = plugins
+		}
+	}
 	statusErr := r.Client.Status().Update(ctx, &dpa)
 	if err == nil { // Don't mask previous error
 		err = statusErr
 	}
 
-	return ctrl.Result{}, err
+	if statusErr == nil {
+		err = r.requeueValidationFailure(&result, err)
+	}
+
+	// BSL status updates do not trigger a reconcile, so periodically check whether failover is needed
+	if dpa.Spec.BackupLocationFailover != nil &&
+		(result.RequeueAfter == 0 || backupLocationFailoverRequeueInterval < result.RequeueAfter) {
+		result.RequeueAfter = backupLocationFailoverRequeueInterval
+	}
+	// schedules are paused and resumed at the boundaries of the backup maintenance window
+	if requeueAfter := maintenanceWindowRequeueAfter(&dpa, time.Now()); requeueAfter > 0 &&
+		(result.RequeueAfter == 0 || requeueAfter < result.RequeueAfter) {
+		result.RequeueAfter = requeueAfter
+	}
+	return result, err
+}
+
+// requeueValidationFailure returns err unless it only holds validation failures, which are reported in the DPA
+// status. Failures on a missing object, or on an object that is not watched such as a schedule or a CRD, may resolve
+// without triggering a reconcile, so the DPA is requeued with an exponential backoff. Other validation failures need
+// a DPA change, which triggers a reconcile, and the DPA is not requeued.
+func (r *DPAReconciler) requeueValidationFailure(result *ctrl.Result, err error) error {
+	var validationErr *codedValidationError
+	if err == nil || !errors.As(err, &validationErr) {
+		delete(r.validationRetries, r.NamespacedName)
+		return err
+	}
+	if !isRetriableValidationFailure(err) {
+		delete(r.validationRetries, r.NamespacedName)
+		return nil
+	}
+	if r.validationRetries == nil {
+		r.validationRetries = map[types.NamespacedName]int{}
+	}
+	retryAfter := validationRetryDelay(r.validationRetries[r.NamespacedName], getValidationRetryMaxDelay())
+	r.validationRetries[r.NamespacedName]++
+	r.Log.Info(fmt.Sprintf("DPA validation failed on a missing or unwatched object, retrying in %s", retryAfter))
+	if result.RequeueAfter == 0 || retryAfter < result.RequeueAfter {
+		result.RequeueAfter = retryAfter
+	}
+	return nil
+}
+
+// validationRetryDelay returns the delay before retrying validation after the given number of retries
+func validationRetryDelay(retries int, maxDelay time.Duration) time.Duration {
+	delay := validationRetryBaseDelay
+	for i := 0; i < retries && delay < maxDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxDelay {
+		return maxDelay
+	}
+	return delay
+}
+
+// getValidationRetryMaxDelay returns the maximum delay between validation retries
+func getValidationRetryMaxDelay() time.Duration {
+	if value, present := os.LookupEnv(ValidationRetryMaxDelayOverride); present {
+		if maxDelay, err := time.ParseDuration(value); err == nil && maxDelay > 0 {
+			return maxDelay
+		}
+	}
+	return defaultValidationRetryMaxDelay
+}
+
+func validateOnlyEnabled(dpa *oadpv1alpha1.DataProtectionApplication) bool {
+	return dpa.Annotations[oadpValidateOnlyAnnotation] == TrueVal
+}
+
+// uncachedReader returns the reader for objects outside of the watched namespace
+func (r *DPAReconciler) uncachedReader() client.Reader {
+	if r.APIReader != nil {
+		return r.APIReader
+	}
+	return r.Client
+}
+
+// warnEvent logs msg at the warn level and records it as a warning event on the DPA, unless the same advisory was
+// already recorded for the DPA generation, so the periodic requeues do not repeat it
+func (r *DPAReconciler) warnEvent(log logr.Logger, dpa *oadpv1alpha1.DataProtectionApplication, reason, msg string) {
+	outcome := validationOutcome{generation: dpa.Generation, message: reason + ": " + msg}
+	key := types.NamespacedName{Namespace: dpa.Namespace, Name: dpa.Name}
+	recorded := r.advisoryOutcomes[key]
+	if recorded[outcome] {
+		return
+	}
+	for last := range recorded {
+		// advisories of a previous generation are recorded again if they still apply
+		if last.generation != dpa.Generation {
+			recorded = nil
+		}
+		break
+	}
+	if recorded == nil {
+		recorded = map[validationOutcome]bool{}
+		if r.advisoryOutcomes == nil {
+			r.advisoryOutcomes = map[types.NamespacedName]map[validationOutcome]bool{}
+		}
+		r.advisoryOutcomes[key] = recorded
+	}
+	recorded[outcome] = true
+	// V(-1) corresponds to the warn level
+	log.V(-1).Info(msg)
+	r.EventRecorder.Event(dpa, corev1.EventTypeWarning, reason, msg)
+}
+
+// setFeatureFlagsCondition reports feature flags velero ignores in the FeatureFlagsRecognized condition. These are
+// not validation errors, as velero runs as if the flags were not set. DPAs without feature flags have no condition.
+func setFeatureFlagsCondition(dpa *oadpv1alpha1.DataProtectionApplication) {
+	if dpa.Spec.Configuration == nil || dpa.Spec.Configuration.Velero == nil || len(dpa.Spec.Configuration.Velero.FeatureFlags) == 0 {
+		apimeta.RemoveStatusCondition(&dpa.Status.Conditions, oadpv1alpha1.ConditionFeatureFlagsRecognized)
+		return
+	}
+	condition := metav1.Condition{
+		Type:    oadpv1alpha1.ConditionFeatureFlagsRecognized,
+		Status:  metav1.ConditionTrue,
+		Reason:  oadpv1alpha1.FeatureFlagsReasonRecognized,
+		Message: oadpv1alpha1.FeatureFlagsRecognizedMessage,
+	}
+	if messages := unrecognizedFeatureFlags(dpa); len(messages) > 0 {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = oadpv1alpha1.FeatureFlagsReasonUnrecognized
+		condition.Message = strings.Join(messages, "; ")
+	}
+	apimeta.SetStatusCondition(&dpa.Status.Conditions, condition)
+}
+
+// setBackupLocationConfigCondition reports backup location config keys the provider plugins ignore in the
+// BackupLocationConfigRecognized condition. DPAs without velero backup locations have no condition.
+func setBackupLocationConfigCondition(dpa *oadpv1alpha1.DataProtectionApplication) {
+	hasVeleroLocation := false
+	for _, bslSpec := range dpa.Spec.BackupLocations {
+		hasVeleroLocation = hasVeleroLocation || bslSpec.Velero != nil
+	}
+	if !hasVeleroLocation {
+		apimeta.RemoveStatusCondition(&dpa.Status.Conditions, oadpv1alpha1.ConditionBackupLocationConfigRecognized)
+		return
+	}
+	condition := metav1.Condition{
+		Type:    oadpv1alpha1.ConditionBackupLocationConfigRecognized,
+		Status:  metav1.ConditionTrue,
+		Reason:  oadpv1alpha1.BackupLocationConfigReasonRecognized,
+		Message: oadpv1alpha1.BackupLocationConfigRecognizedMessage,
+	}
+	if messages := unrecognizedBackupLocationConfigKeys(dpa); len(messages) > 0 {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = oadpv1alpha1.BackupLocationConfigReasonUnrecognized
+		condition.Message = strings.Join(messages, "; ")
+	}
+	apimeta.SetStatusCondition(&dpa.Status.Conditions, condition)
+}
+
+// reconcileValidateOnly validates the DPA and reports the result in the Validated condition and the validation
+// errors of the DPA status, without reconciling any velero resources. Resources created before the DPA was
+// annotated are left as they are.
+func (r *DPAReconciler) reconcileValidateOnly(dpa *oadpv1alpha1.DataProtectionApplication) error {
+	_, err := r.ValidateDataProtectionCR(r.Log)
+	condition := metav1.Condition{
+		Type:    oadpv1alpha1.ConditionValidated,
+		Status:  metav1.ConditionTrue,
+		Reason:  oadpv1alpha1.ValidatedReasonPassed,
+		Message: oadpv1alpha1.ValidatedPassedMessage,
+	}
+	if err != nil {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = oadpv1alpha1.ValidatedReasonFailed
+		condition.Message = err.Error()
+	}
+	apimeta.SetStatusCondition(&dpa.Status.Conditions, condition)
+	dpa.Status.ValidationErrors = validationErrors(err)
+	setFeatureFlagsCondition(dpa)
+	setBackupLocationConfigCondition(dpa)
+	if statusErr := r.Client.Status().Update(r.Context, dpa); statusErr != nil {
+		return statusErr
+	}
+	return err
 }
 
 // SetupWithManager sets up the controller with the Manager.
@@ -147,6 +393,8 @@ func (r *DPAReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Owns(&corev1.Service{}).
 		Owns(&routev1.Route{}).
 		Owns(&corev1.ConfigMap{}).
+		Owns(&policyv1.PodDisruptionBudget{}).
+		Owns(&networkingv1.NetworkPolicy{}).
 		Watches(&source.Kind{Type: &corev1.Secret{}}, &labelHandler{}).
 		WithEventFilter(veleroPredicate(r.Scheme)).
 		Complete(r)
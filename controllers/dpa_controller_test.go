@@ -0,0 +1,376 @@
+package controllers
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	velerov1 "github.com/vmware-tanzu/velero/pkg/apis/velero/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serror "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	oadpv1alpha1 "github.com/openshift/oadp-operator/api/v1alpha1"
+	"github.com/openshift/oadp-operator/pkg/common"
+)
+
+func TestDPAReconciler_ReconcileValidateOnly(t *testing.T) {
+	tests := []struct {
+		name             string
+		velero           *oadpv1alpha1.VeleroConfig
+		backupLocations  []oadpv1alpha1.BackupLocation
+		wantStatus       metav1.ConditionStatus
+		wantReason       string
+		wantErrorCode    oadpv1alpha1.ValidationErrorCode
+		wantRequeueAfter time.Duration
+	}{
+		{
+			name:       "valid DPA",
+			velero:     &oadpv1alpha1.VeleroConfig{NoDefaultBackupLocation: true},
+			wantStatus: metav1.ConditionTrue,
+			wantReason: oadpv1alpha1.ValidatedReasonPassed,
+		},
+		{
+			name: "invalid DPA",
+			velero: &oadpv1alpha1.VeleroConfig{
+				NoDefaultBackupLocation: true,
+				Sysctls:                 []corev1.Sysctl{{Name: "vm.swappiness", Value: "10"}},
+			},
+			wantStatus:    metav1.ConditionFalse,
+			wantReason:    oadpv1alpha1.ValidatedReasonFailed,
+			wantErrorCode: oadpv1alpha1.ValidationErrorCodeUnsafeSysctl,
+		},
+		{
+			name: "DPA with a missing credentials secret",
+			velero: &oadpv1alpha1.VeleroConfig{
+				DefaultPlugins: []oadpv1alpha1.DefaultPlugin{oadpv1alpha1.DefaultPluginAWS},
+			},
+			backupLocations: []oadpv1alpha1.BackupLocation{
+				{
+					Velero: &velerov1.BackupStorageLocationSpec{
+						Provider: AWSProvider,
+						Config:   map[string]string{AWSRegion: "us-east-1"},
+						Credential: &corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "bsl-credentials"},
+							Key:                  "cloud",
+						},
+						StorageType: velerov1.StorageType{
+							ObjectStorage: &velerov1.ObjectStorageLocation{Bucket: "bucket", Prefix: "prefix"},
+						},
+						Default: true,
+					},
+				},
+			},
+			wantStatus:       metav1.ConditionFalse,
+			wantReason:       oadpv1alpha1.ValidatedReasonFailed,
+			wantRequeueAfter: validationRetryBaseDelay,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dpa := &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "test-DPA-CR",
+					Namespace:   "test-ns",
+					Annotations: map[string]string{oadpValidateOnlyAnnotation: "true"},
+				},
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration:   &oadpv1alpha1.ApplicationConfig{Velero: tt.velero},
+					BackupLocations: tt.backupLocations,
+					BackupImages:    pointer.Bool(false),
+				},
+			}
+			fakeClient, err := getFakeClientFromObjects(dpa)
+			if err != nil {
+				t.Errorf("error in creating fake client, likely programmer error")
+			}
+			r := &DPAReconciler{
+				Client:        fakeClient,
+				Scheme:        fakeClient.Scheme(),
+				EventRecorder: record.NewFakeRecorder(10),
+			}
+			namespacedName := types.NamespacedName{Namespace: dpa.Namespace, Name: dpa.Name}
+			// validation failures are reported in the DPA status instead of returned
+			result, err := r.Reconcile(newContextForTest(tt.name), ctrl.Request{NamespacedName: namespacedName})
+			if err != nil {
+				t.Errorf("Reconcile() unexpected error = %v", err)
+			}
+			if result.RequeueAfter != tt.wantRequeueAfter {
+				t.Errorf("Reconcile() requeueAfter = %v, want %v", result.RequeueAfter, tt.wantRequeueAfter)
+			}
+
+			got := &oadpv1alpha1.DataProtectionApplication{}
+			if err := r.Get(r.Context, namespacedName, got); err != nil {
+				t.Errorf("Reconcile() unable to get DPA: %v", err)
+				return
+			}
+			condition := apimeta.FindStatusCondition(got.Status.Conditions, oadpv1alpha1.ConditionValidated)
+			if condition == nil || condition.Status != tt.wantStatus || condition.Reason != tt.wantReason {
+				t.Errorf("Reconcile() Validated condition = %v, want status %s reason %s", condition, tt.wantStatus, tt.wantReason)
+			}
+			if apimeta.FindStatusCondition(got.Status.Conditions, oadpv1alpha1.ConditionReconciled) != nil {
+				t.Errorf("Reconcile() set the Reconciled condition in validate only mode")
+			}
+			if tt.wantErrorCode != "" && (len(got.Status.ValidationErrors) != 1 || got.Status.ValidationErrors[0].Code != tt.wantErrorCode) {
+				t.Errorf("Reconcile() validation errors = %v, want code %s", got.Status.ValidationErrors, tt.wantErrorCode)
+			}
+			deployment := &appsv1.Deployment{}
+			err = r.Get(r.Context, types.NamespacedName{Namespace: dpa.Namespace, Name: common.Velero}, deployment)
+			if !k8serror.IsNotFound(err) {
+				t.Errorf("Reconcile() expected no velero deployment in validate only mode, got error = %v", err)
+			}
+		})
+	}
+}
+
+func Test_setFeatureFlagsCondition(t *testing.T) {
+	tests := []struct {
+		name          string
+		featureFlags  []string
+		wantCondition bool
+		wantStatus    metav1.ConditionStatus
+		wantReason    string
+	}{
+		{
+			name: "no feature flags",
+		},
+		{
+			name:          "recognized feature flags",
+			featureFlags:  []string{"EnableCSI"},
+			wantCondition: true,
+			wantStatus:    metav1.ConditionTrue,
+			wantReason:    oadpv1alpha1.FeatureFlagsReasonRecognized,
+		},
+		{
+			name:          "unrecognized feature flag",
+			featureFlags:  []string{"EnableCSI", "csi-snapshot"},
+			wantCondition: true,
+			wantStatus:    metav1.ConditionFalse,
+			wantReason:    oadpv1alpha1.FeatureFlagsReasonUnrecognized,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dpa := &oadpv1alpha1.DataProtectionApplication{
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{FeatureFlags: tt.featureFlags},
+					},
+				},
+				Status: oadpv1alpha1.DataProtectionApplicationStatus{
+					Conditions: []metav1.Condition{{Type: oadpv1alpha1.ConditionFeatureFlagsRecognized, Status: metav1.ConditionFalse}},
+				},
+			}
+			setFeatureFlagsCondition(dpa)
+			condition := apimeta.FindStatusCondition(dpa.Status.Conditions, oadpv1alpha1.ConditionFeatureFlagsRecognized)
+			if !tt.wantCondition {
+				if condition != nil {
+					t.Errorf("setFeatureFlagsCondition() unexpected condition %v", condition)
+				}
+				return
+			}
+			if condition == nil || condition.Status != tt.wantStatus || condition.Reason != tt.wantReason {
+				t.Errorf("setFeatureFlagsCondition() condition = %v, want status %s reason %s", condition, tt.wantStatus, tt.wantReason)
+			}
+		})
+	}
+}
+
+func Test_setBackupLocationConfigCondition(t *testing.T) {
+	tests := []struct {
+		name            string
+		backupLocations []oadpv1alpha1.BackupLocation
+		wantCondition   bool
+		wantStatus      metav1.ConditionStatus
+		wantReason      string
+	}{
+		{
+			name: "no backup locations",
+		},
+		{
+			name: "recognized config keys",
+			backupLocations: []oadpv1alpha1.BackupLocation{
+				{Velero: &velerov1.BackupStorageLocationSpec{Provider: AWSProvider, Config: map[string]string{Region: "us-east-1"}}},
+			},
+			wantCondition: true,
+			wantStatus:    metav1.ConditionTrue,
+			wantReason:    oadpv1alpha1.BackupLocationConfigReasonRecognized,
+		},
+		{
+			name: "unrecognized config key",
+			backupLocations: []oadpv1alpha1.BackupLocation{
+				{Velero: &velerov1.BackupStorageLocationSpec{Provider: AWSProvider, Config: map[string]string{Region: "us-east-1", ResourceGroup: "rg"}}},
+			},
+			wantCondition: true,
+			wantStatus:    metav1.ConditionFalse,
+			wantReason:    oadpv1alpha1.BackupLocationConfigReasonUnrecognized,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dpa := &oadpv1alpha1.DataProtectionApplication{
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{BackupLocations: tt.backupLocations},
+				Status: oadpv1alpha1.DataProtectionApplicationStatus{
+					Conditions: []metav1.Condition{{Type: oadpv1alpha1.ConditionBackupLocationConfigRecognized, Status: metav1.ConditionFalse}},
+				},
+			}
+			setBackupLocationConfigCondition(dpa)
+			condition := apimeta.FindStatusCondition(dpa.Status.Conditions, oadpv1alpha1.ConditionBackupLocationConfigRecognized)
+			if !tt.wantCondition {
+				if condition != nil {
+					t.Errorf("setBackupLocationConfigCondition() unexpected condition %v", condition)
+				}
+				return
+			}
+			if condition == nil || condition.Status != tt.wantStatus || condition.Reason != tt.wantReason {
+				t.Errorf("setBackupLocationConfigCondition() condition = %v, want status %s reason %s", condition, tt.wantStatus, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestDPAReconciler_warnEvent(t *testing.T) {
+	dpa := &oadpv1alpha1.DataProtectionApplication{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-DPA-CR", Namespace: "test-ns", Generation: 1},
+	}
+	recorder := record.NewFakeRecorder(10)
+	r := &DPAReconciler{EventRecorder: recorder}
+	steps := []struct {
+		name       string
+		generation int64
+		reason     string
+		wantEvent  string
+	}{
+		{
+			name:       "advisory is recorded",
+			generation: 1,
+			reason:     "NodeAgentHostPID",
+			wantEvent:  "Warning NodeAgentHostPID node agent runs with hostPID",
+		},
+		{
+			name:       "repeated advisory is not recorded",
+			generation: 1,
+			reason:     "NodeAgentHostPID",
+		},
+		{
+			name:       "same message with a different reason is recorded",
+			generation: 1,
+			reason:     "NodeAgentPrivileged",
+			wantEvent:  "Warning NodeAgentPrivileged node agent runs with hostPID",
+		},
+		{
+			name:       "advisory for a new generation is recorded",
+			generation: 2,
+			reason:     "NodeAgentHostPID",
+			wantEvent:  "Warning NodeAgentHostPID node agent runs with hostPID",
+		},
+		{
+			name:       "repeated advisory for the new generation is not recorded",
+			generation: 2,
+			reason:     "NodeAgentHostPID",
+		},
+	}
+	for _, step := range steps {
+		dpa.Generation = step.generation
+		r.warnEvent(logr.Discard(), dpa, step.reason, "node agent runs with hostPID")
+		select {
+		case event := <-recorder.Events:
+			if event != step.wantEvent {
+				t.Errorf("%s: warnEvent() recorded %q, want %q", step.name, event, step.wantEvent)
+			}
+		default:
+			if step.wantEvent != "" {
+				t.Errorf("%s: warnEvent() recorded no event, want %q", step.name, step.wantEvent)
+			}
+		}
+	}
+}
+
+func TestDPAReconciler_requeueValidationFailure(t *testing.T) {
+	missingSecret := withValidationCode(oadpv1alpha1.ValidationErrorCodeInvalidPluginCredential,
+		k8serror.NewNotFound(corev1.Resource("secrets"), "cloud-credentials"))
+	invalidSpec := withValidationCode(oadpv1alpha1.ValidationErrorCodeUnsafeSysctl, errors.New("sysctl vm.swappiness is not allowed"))
+	activeSchedules := withValidationCode(oadpv1alpha1.ValidationErrorCodeInvalidRestoreOnlyMode,
+		retriable(errors.New("restoreOnlyMode cannot be enabled while schedules are active, pause or delete schedules: daily")))
+	tests := []struct {
+		name             string
+		errs             []error
+		wantErr          bool
+		wantRequeueAfter []time.Duration
+	}{
+		{
+			name:             "no error",
+			errs:             []error{nil},
+			wantRequeueAfter: []time.Duration{0},
+		},
+		{
+			name:             "error other than a validation failure is returned",
+			errs:             []error{errors.New("unable to update deployment")},
+			wantErr:          true,
+			wantRequeueAfter: []time.Duration{0},
+		},
+		{
+			name:             "validation failure needing a DPA change is not requeued",
+			errs:             []error{invalidSpec, invalidSpec},
+			wantRequeueAfter: []time.Duration{0, 0},
+		},
+		{
+			name:             "validation failure on an unwatched object is requeued with a backoff",
+			errs:             []error{activeSchedules, errors.Join(invalidSpec, activeSchedules)},
+			wantRequeueAfter: []time.Duration{5 * time.Second, 10 * time.Second},
+		},
+		{
+			name:             "missing secret is requeued with a backoff",
+			errs:             []error{missingSecret, missingSecret, errors.Join(invalidSpec, missingSecret)},
+			wantRequeueAfter: []time.Duration{5 * time.Second, 10 * time.Second, 20 * time.Second},
+		},
+		{
+			name:             "backoff is reset once validation passes",
+			errs:             []error{missingSecret, missingSecret, nil, missingSecret},
+			wantRequeueAfter: []time.Duration{5 * time.Second, 10 * time.Second, 0, 5 * time.Second},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &DPAReconciler{
+				Log:            logr.Discard(),
+				NamespacedName: types.NamespacedName{Namespace: "test-ns", Name: "test-DPA-CR"},
+			}
+			for i, err := range tt.errs {
+				result := ctrl.Result{}
+				gotErr := r.requeueValidationFailure(&result, err)
+				if (gotErr != nil) != tt.wantErr {
+					t.Errorf("requeueValidationFailure() error = %v, wantErr %v", gotErr, tt.wantErr)
+				}
+				if result.RequeueAfter != tt.wantRequeueAfter[i] {
+					t.Errorf("requeueValidationFailure() retry %d requeueAfter = %v, want %v", i, result.RequeueAfter, tt.wantRequeueAfter[i])
+				}
+			}
+		})
+	}
+}
+
+func Test_validationRetryDelay(t *testing.T) {
+	tests := []struct {
+		retries int
+		want    time.Duration
+	}{
+		{retries: 0, want: 5 * time.Second},
+		{retries: 3, want: 40 * time.Second},
+		{retries: 4, want: 80 * time.Second},
+		{retries: 5, want: 2 * time.Minute},
+		{retries: 100, want: 2 * time.Minute},
+	}
+	for _, tt := range tests {
+		if got := validationRetryDelay(tt.retries, defaultValidationRetryMaxDelay); got != tt.want {
+			t.Errorf("validationRetryDelay(%d) = %v, want %v", tt.retries, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package controllers
+
+import (
+	"fmt"
+
+	"github.com/go-logr/logr"
+	velerov1 "github.com/vmware-tanzu/velero/pkg/apis/velero/v1"
+	"github.com/vmware-tanzu/velero/pkg/label"
+	corev1 "k8s.io/api/core/v1"
+	k8serror "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	oadpv1alpha1 "github.com/openshift/oadp-operator/api/v1alpha1"
+)
+
+const oadpFinalizerDPACleanup = "oadp.openshift.io/dpa-cleanup"
+
+func cleanupOnDeletionEnabled(dpa *oadpv1alpha1.DataProtectionApplication) bool {
+	return dpa.Spec.CleanupOnDeletion != nil && *dpa.Spec.CleanupOnDeletion
+}
+
+// ReconcileCleanupFinalizer adds the cleanup finalizer to the DPA when cleanupOnDeletion is enabled
+// and removes it when it is disabled
+func (r *DPAReconciler) ReconcileCleanupFinalizer(dpa *oadpv1alpha1.DataProtectionApplication) error {
+	hasFinalizer := containFinalizer(dpa.Finalizers, oadpFinalizerDPACleanup)
+	switch {
+	case cleanupOnDeletionEnabled(dpa) && !hasFinalizer:
+		dpa.Finalizers = append(dpa.Finalizers, oadpFinalizerDPACleanup)
+	case !cleanupOnDeletionEnabled(dpa) && hasFinalizer:
+		dpa.Finalizers = removeKey(dpa.Finalizers, oadpFinalizerDPACleanup)
+	default:
+		return nil
+	}
+	if err := r.Update(r.Context, dpa); err != nil {
+		r.EventRecorder.Event(dpa, corev1.EventTypeWarning, "UnableToUpdateFinalizer", fmt.Sprintf("unable to update finalizer: %v", err))
+		return err
+	}
+	return nil
+}
+
+// FinalizeDataProtectionApplication deletes the velero resources created for a DPA being deleted, then
+// removes the cleanup finalizer. Locations not controlled by the DPA, and the repositories of those
+// locations, are left untouched.
+func (r *DPAReconciler) FinalizeDataProtectionApplication(log logr.Logger, dpa *oadpv1alpha1.DataProtectionApplication) error {
+	if !containFinalizer(dpa.Finalizers, oadpFinalizerDPACleanup) {
+		return nil
+	}
+
+	bslList := velerov1.BackupStorageLocationList{}
+	if err := r.List(r.Context, &bslList, client.InNamespace(dpa.Namespace)); err != nil {
+		return err
+	}
+	// BackupRepositories are labeled with the name of their BackupStorageLocation
+	ownedLocations := sets.NewString()
+	for i := range bslList.Items {
+		bsl := &bslList.Items[i]
+		if !metav1.IsControlledBy(bsl, dpa) {
+			continue
+		}
+		ownedLocations.Insert(label.GetValidName(bsl.Name))
+		if err := r.deleteVeleroResource(log, dpa, "BackupStorageLocation", bsl); err != nil {
+			return err
+		}
+	}
+
+	repoList := velerov1.BackupRepositoryList{}
+	if err := r.List(r.Context, &repoList, client.InNamespace(dpa.Namespace)); err != nil {
+		return err
+	}
+	for i := range repoList.Items {
+		repo := &repoList.Items[i]
+		if !ownedLocations.Has(repo.Labels[velerov1.StorageLocationLabel]) {
+			continue
+		}
+		if err := r.deleteVeleroResource(log, dpa, "BackupRepository", repo); err != nil {
+			return err
+		}
+	}
+
+	vslList := velerov1.VolumeSnapshotLocationList{}
+	if err := r.List(r.Context, &vslList, client.InNamespace(dpa.Namespace)); err != nil {
+		return err
+	}
+	for i := range vslList.Items {
+		vsl := &vslList.Items[i]
+		if !metav1.IsControlledBy(vsl, dpa) {
+			continue
+		}
+		if err := r.deleteVeleroResource(log, dpa, "VolumeSnapshotLocation", vsl); err != nil {
+			return err
+		}
+	}
+
+	if namespace := dpa.Status.MirroredBackupLocationsNamespace; namespace != "" {
+		if err := r.deleteMirroredBackupLocations(log, dpa, namespace, nil); err != nil {
+			return err
+		}
+	}
+
+	dpa.Finalizers = removeKey(dpa.Finalizers, oadpFinalizerDPACleanup)
+	if err := r.Update(r.Context, dpa); err != nil {
+		r.EventRecorder.Event(dpa, corev1.EventTypeWarning, "UnableToRemoveFinalizer", fmt.Sprintf("unable to remove finalizer: %v", err))
+		return err
+	}
+	return nil
+}
+
+func (r *DPAReconciler) deleteVeleroResource(log logr.Logger, dpa *oadpv1alpha1.DataProtectionApplication, kind string, obj client.Object) error {
+	if err := r.Delete(r.Context, obj); err != nil && !k8serror.IsNotFound(err) {
+		return err
+	}
+	log.Info(fmt.Sprintf("deleted %s %s/%s on DPA deletion", kind, obj.GetNamespace(), obj.GetName()))
+	r.EventRecorder.Event(dpa,
+		corev1.EventTypeNormal,
+		"VeleroResourceDeleted",
+		fmt.Sprintf("%s %s/%s deleted on DPA deletion", kind, obj.GetNamespace(), obj.GetName()),
+	)
+	return nil
+}
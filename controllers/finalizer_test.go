@@ -0,0 +1,178 @@
+package controllers
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	velerov1 "github.com/vmware-tanzu/velero/pkg/apis/velero/v1"
+	k8serror "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	oadpv1alpha1 "github.com/openshift/oadp-operator/api/v1alpha1"
+)
+
+func TestDPAReconciler_ReconcileCleanupFinalizer(t *testing.T) {
+	tests := []struct {
+		name              string
+		cleanupOnDeletion *bool
+		finalizers        []string
+		wantFinalizer     bool
+	}{
+		{
+			name:              "cleanupOnDeletion enabled adds finalizer",
+			cleanupOnDeletion: pointer.Bool(true),
+			wantFinalizer:     true,
+		},
+		{
+			name:              "cleanupOnDeletion disabled removes finalizer",
+			cleanupOnDeletion: pointer.Bool(false),
+			finalizers:        []string{oadpFinalizerDPACleanup},
+			wantFinalizer:     false,
+		},
+		{
+			name:          "cleanupOnDeletion unset",
+			wantFinalizer: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dpa := &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       "test-DPA-CR",
+					Namespace:  "test-ns",
+					Finalizers: tt.finalizers,
+				},
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					CleanupOnDeletion: tt.cleanupOnDeletion,
+				},
+			}
+			fakeClient, err := getFakeClientFromObjects(dpa)
+			if err != nil {
+				t.Errorf("error in creating fake client, likely programmer error")
+			}
+			r := &DPAReconciler{
+				Client:        fakeClient,
+				Scheme:        fakeClient.Scheme(),
+				Log:           logr.Discard(),
+				Context:       newContextForTest(tt.name),
+				EventRecorder: record.NewFakeRecorder(10),
+			}
+			if err := r.ReconcileCleanupFinalizer(dpa); err != nil {
+				t.Errorf("ReconcileCleanupFinalizer() unexpected error = %v", err)
+				return
+			}
+			got := &oadpv1alpha1.DataProtectionApplication{}
+			if err := r.Get(r.Context, types.NamespacedName{Name: dpa.Name, Namespace: dpa.Namespace}, got); err != nil {
+				t.Errorf("ReconcileCleanupFinalizer() unable to get DPA: %v", err)
+				return
+			}
+			if containFinalizer(got.Finalizers, oadpFinalizerDPACleanup) != tt.wantFinalizer {
+				t.Errorf("ReconcileCleanupFinalizer() finalizers = %v, want finalizer %v", got.Finalizers, tt.wantFinalizer)
+			}
+		})
+	}
+}
+
+func TestDPAReconciler_FinalizeDataProtectionApplication(t *testing.T) {
+	deletionTimestamp := metav1.Now()
+	dpa := &oadpv1alpha1.DataProtectionApplication{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "test-DPA-CR",
+			Namespace:         "test-ns",
+			UID:               "test-DPA-CR-uid",
+			DeletionTimestamp: &deletionTimestamp,
+			Finalizers:        []string{oadpFinalizerDPACleanup},
+		},
+		Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+			CleanupOnDeletion: pointer.Bool(true),
+		},
+	}
+	ownerReferences := []metav1.OwnerReference{
+		{
+			APIVersion: oadpv1alpha1.SchemeBuilder.GroupVersion.String(),
+			Kind:       "DataProtectionApplication",
+			Name:       dpa.Name,
+			UID:        dpa.UID,
+			Controller: pointer.Bool(true),
+		},
+	}
+	objects := []client.Object{
+		dpa,
+		&velerov1.BackupStorageLocation{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-DPA-CR-1", Namespace: "test-ns", OwnerReferences: ownerReferences},
+		},
+		&velerov1.BackupStorageLocation{
+			ObjectMeta: metav1.ObjectMeta{Name: "user-bsl", Namespace: "test-ns"},
+		},
+		&velerov1.BackupRepository{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-DPA-CR-1-kopia",
+				Namespace: "test-ns",
+				Labels:    map[string]string{velerov1.StorageLocationLabel: "test-DPA-CR-1"},
+			},
+		},
+		&velerov1.BackupRepository{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "user-bsl-kopia",
+				Namespace: "test-ns",
+				Labels:    map[string]string{velerov1.StorageLocationLabel: "user-bsl"},
+			},
+		},
+		&velerov1.VolumeSnapshotLocation{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-DPA-CR-1", Namespace: "test-ns", OwnerReferences: ownerReferences},
+		},
+		&velerov1.VolumeSnapshotLocation{
+			ObjectMeta: metav1.ObjectMeta{Name: "user-vsl", Namespace: "test-ns"},
+		},
+	}
+	fakeClient, err := getFakeClientFromObjects(objects...)
+	if err != nil {
+		t.Errorf("error in creating fake client, likely programmer error")
+	}
+	r := &DPAReconciler{
+		Client:        fakeClient,
+		Scheme:        fakeClient.Scheme(),
+		Log:           logr.Discard(),
+		Context:       newContextForTest("finalize DPA"),
+		EventRecorder: record.NewFakeRecorder(10),
+	}
+	if err := r.FinalizeDataProtectionApplication(r.Log, dpa); err != nil {
+		t.Errorf("FinalizeDataProtectionApplication() unexpected error = %v", err)
+		return
+	}
+
+	tests := []struct {
+		name        string
+		objName     string
+		obj         client.Object
+		wantDeleted bool
+	}{
+		{name: "BSL created for the DPA is deleted", objName: "test-DPA-CR-1", obj: &velerov1.BackupStorageLocation{}, wantDeleted: true},
+		{name: "user created BSL is kept", objName: "user-bsl", obj: &velerov1.BackupStorageLocation{}},
+		{name: "BackupRepository of BSL created for the DPA is deleted", objName: "test-DPA-CR-1-kopia", obj: &velerov1.BackupRepository{}, wantDeleted: true},
+		{name: "BackupRepository of user created BSL is kept", objName: "user-bsl-kopia", obj: &velerov1.BackupRepository{}},
+		{name: "VSL created for the DPA is deleted", objName: "test-DPA-CR-1", obj: &velerov1.VolumeSnapshotLocation{}, wantDeleted: true},
+		{name: "user created VSL is kept", objName: "user-vsl", obj: &velerov1.VolumeSnapshotLocation{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := r.Get(r.Context, types.NamespacedName{Name: tt.objName, Namespace: "test-ns"}, tt.obj)
+			if tt.wantDeleted && !k8serror.IsNotFound(err) {
+				t.Errorf("FinalizeDataProtectionApplication() expected %s to be deleted, got error = %v", tt.objName, err)
+			}
+			if !tt.wantDeleted && err != nil {
+				t.Errorf("FinalizeDataProtectionApplication() expected %s to be kept, got error = %v", tt.objName, err)
+			}
+		})
+	}
+
+	// removing the last finalizer of a DPA being deleted removes the DPA
+	err = r.Get(r.Context, types.NamespacedName{Name: dpa.Name, Namespace: dpa.Namespace}, &oadpv1alpha1.DataProtectionApplication{})
+	if !k8serror.IsNotFound(err) {
+		t.Errorf("FinalizeDataProtectionApplication() expected finalizer to be removed and DPA deleted, got error = %v", err)
+	}
+}
@@ -2,14 +2,28 @@ package controllers
 
 import (
 	"fmt"
+	"net"
 
 	"github.com/go-logr/logr"
+	monitor "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	corev1 "k8s.io/api/core/v1"
+	k8serror "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	oadpv1alpha1 "github.com/openshift/oadp-operator/api/v1alpha1"
+	"github.com/openshift/oadp-operator/pkg/common"
+)
+
+const (
+	veleroMetricsSVCName    = "openshift-adp-velero-metrics-svc"
+	nodeAgentMetricsSVCName = "openshift-adp-node-agent-metrics-svc"
+	// port the node agent serves metrics on, velero does not allow changing it
+	nodeAgentMetricsPort = int32(8085)
 )
 
 func (r *DPAReconciler) ReconcileVeleroMetricsSVC(log logr.Logger) (bool, error) {
@@ -20,11 +34,27 @@ func (r *DPAReconciler) ReconcileVeleroMetricsSVC(log logr.Logger) (bool, error)
 
 	svc := corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      "openshift-adp-velero-metrics-svc",
+			Name:      veleroMetricsSVCName,
 			Namespace: r.NamespacedName.Namespace,
 		},
 	}
 
+	// metrics bound to localhost are not reachable through a Service, delete (possible) previously created one
+	if isMetricsLocalhostOnly(&dpa) {
+		if err := r.Delete(r.Context, &svc); err != nil {
+			if k8serror.IsNotFound(err) {
+				return true, nil
+			}
+			return false, err
+		}
+		r.EventRecorder.Event(&svc,
+			corev1.EventTypeNormal,
+			"VeleroMetricsServiceDeleted",
+			fmt.Sprintf("deleted dpa metrics service %s/%s as velero metrics are bound to localhost", svc.Namespace, svc.Name),
+		)
+		return true, nil
+	}
+
 	// Create SVC
 	op, err := controllerutil.CreateOrPatch(r.Context, r.Client, &svc, func() error {
 		// TODO: check for svc status condition errors and respond here
@@ -46,6 +76,95 @@ func (r *DPAReconciler) ReconcileVeleroMetricsSVC(log logr.Logger) (bool, error)
 	return true, nil
 }
 
+func (r *DPAReconciler) ReconcileNodeAgentMetricsSVC(log logr.Logger) (bool, error) {
+	dpa := oadpv1alpha1.DataProtectionApplication{}
+	if err := r.Get(r.Context, r.NamespacedName, &dpa); err != nil {
+		return false, err
+	}
+
+	svc := corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      nodeAgentMetricsSVCName,
+			Namespace: r.NamespacedName.Namespace,
+		},
+	}
+
+	// Delete (possible) previously created SVC
+	if !nodeAgentMetricsEnabled(&dpa) {
+		if err := r.Delete(r.Context, &svc); err != nil {
+			if k8serror.IsNotFound(err) {
+				return true, nil
+			}
+			return false, err
+		}
+		r.EventRecorder.Event(&svc,
+			corev1.EventTypeNormal,
+			"NodeAgentMetricsServiceDeleted",
+			fmt.Sprintf("deleted node agent metrics service %s/%s", svc.Namespace, svc.Name),
+		)
+		return true, nil
+	}
+
+	// Create SVC
+	op, err := controllerutil.CreateOrPatch(r.Context, r.Client, &svc, func() error {
+		return r.updateNodeAgentMetricsSVC(&svc, &dpa)
+	})
+	if err != nil {
+		return false, err
+	}
+	if op == controllerutil.OperationResultCreated || op == controllerutil.OperationResultUpdated {
+		// Trigger event to indicate SVC was created or updated
+		r.EventRecorder.Event(&svc,
+			corev1.EventTypeNormal,
+			"NodeAgentMetricsServiceReconciled",
+			fmt.Sprintf("performed %s on node agent metrics service %s/%s", op, svc.Namespace, svc.Name),
+		)
+	}
+
+	return true, nil
+}
+
+// isMetricsLocalhostOnly returns true if the velero metrics address is bound to a loopback address
+func isMetricsLocalhostOnly(dpa *oadpv1alpha1.DataProtectionApplication) bool {
+	if dpa.Spec.Configuration == nil || dpa.Spec.Configuration.Velero == nil ||
+		dpa.Spec.Configuration.Velero.Args == nil || dpa.Spec.Configuration.Velero.Args.MetricsAddress == "" {
+		return false
+	}
+	host, _, err := net.SplitHostPort(dpa.Spec.Configuration.Velero.Args.MetricsAddress)
+	if err != nil {
+		return false
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// warnServiceMonitorsForLocalhostMetrics emits a warning for every ServiceMonitor selecting the velero
+// metrics service while velero metrics are bound to localhost, as it has no endpoints to scrape
+func (r *DPAReconciler) warnServiceMonitorsForLocalhostMetrics(log logr.Logger, dpa *oadpv1alpha1.DataProtectionApplication) {
+	if !isMetricsLocalhostOnly(dpa) {
+		return
+	}
+	serviceMonitors := monitor.ServiceMonitorList{}
+	if err := r.List(r.Context, &serviceMonitors, client.InNamespace(dpa.Namespace)); err != nil {
+		// ServiceMonitor CRD is not installed, nothing can scrape the metrics service
+		if !apimeta.IsNoMatchError(err) {
+			log.Error(err, "unable to list ServiceMonitors selecting the velero metrics service")
+		}
+		return
+	}
+	for _, serviceMonitor := range serviceMonitors.Items {
+		selector, err := metav1.LabelSelectorAsSelector(&serviceMonitor.Spec.Selector)
+		if err != nil || !selector.Matches(labels.Set(getDpaAppLabels(dpa))) {
+			continue
+		}
+		msg := fmt.Sprintf("ServiceMonitor %s/%s selects the velero metrics service, which is not created while velero metrics are bound to localhost", serviceMonitor.Namespace, serviceMonitor.Name)
+		r.warnEvent(log, dpa, "LocalhostMetricsServiceMonitor", msg)
+	}
+}
+
 func (r *DPAReconciler) updateVeleroMetricsSVC(svc *corev1.Service, dpa *oadpv1alpha1.DataProtectionApplication) error {
 	// Setting controller owner reference on the metrics svc
 	err := controllerutil.SetControllerReference(dpa, svc, r.Scheme)
@@ -72,3 +191,27 @@ func (r *DPAReconciler) updateVeleroMetricsSVC(svc *corev1.Service, dpa *oadpv1a
 	svc.Labels = getDpaAppLabels(dpa)
 	return nil
 }
+
+func (r *DPAReconciler) updateNodeAgentMetricsSVC(svc *corev1.Service, dpa *oadpv1alpha1.DataProtectionApplication) error {
+	// Setting controller owner reference on the metrics svc
+	err := controllerutil.SetControllerReference(dpa, svc, r.Scheme)
+	if err != nil {
+		return err
+	}
+
+	svc.Spec.Selector = nodeAgentMatchLabels
+
+	svc.Spec.Type = corev1.ServiceTypeClusterIP
+	svc.Spec.Ports = []corev1.ServicePort{
+		{
+			Protocol:   corev1.ProtocolTCP,
+			Name:       "monitoring",
+			Port:       nodeAgentMetricsPort,
+			TargetPort: intstr.FromInt(int(nodeAgentMetricsPort)),
+		},
+	}
+
+	// distinguish the node agent metrics svc from the velero one for ServiceMonitors selecting the DPA labels
+	svc.Labels = common.AppendTTMapAsCopy(getDpaAppLabels(dpa), map[string]string{"app.kubernetes.io/component": common.NodeAgent})
+	return nil
+}
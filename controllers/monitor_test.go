@@ -11,17 +11,20 @@ import (
 	velerov1 "github.com/vmware-tanzu/velero/pkg/apis/velero/v1"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	k8serror "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	oadpv1alpha1 "github.com/openshift/oadp-operator/api/v1alpha1"
 	"github.com/openshift/oadp-operator/pkg/common"
+	"github.com/openshift/oadp-operator/pkg/velero/server"
 )
 
 func getSchemeForFakeClientForMonitor() (*runtime.Scheme, error) {
@@ -171,3 +174,248 @@ func TestDPAReconciler_updateVeleroMetricsSVC(t *testing.T) {
 		})
 	}
 }
+
+func TestDPAReconciler_ReconcileVeleroMetricsSVC(t *testing.T) {
+	tests := []struct {
+		name           string
+		metricsAddress string
+		objects        []client.Object
+		wantSVC        bool
+	}{
+		{
+			name:    "metrics service created for default metrics address",
+			wantSVC: true,
+		},
+		{
+			name:           "metrics service created for metrics bound to all interfaces",
+			metricsAddress: ":8085",
+			wantSVC:        true,
+		},
+		{
+			name:           "no metrics service for metrics bound to localhost",
+			metricsAddress: "127.0.0.1:8085",
+			wantSVC:        false,
+		},
+		{
+			name:           "existing metrics service deleted for metrics bound to localhost",
+			metricsAddress: "localhost:8085",
+			objects: []client.Object{
+				&corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      veleroMetricsSVCName,
+						Namespace: "test-ns",
+					},
+				},
+			},
+			wantSVC: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dpa := &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-dpa",
+					Namespace: "test-ns",
+				},
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{
+							Args: &server.Args{
+								ServerConfig: server.ServerConfig{
+									MetricsAddress: tt.metricsAddress,
+								},
+							},
+						},
+					},
+				},
+			}
+			fakeClient, err := getFakeClientFromObjectsForMonitor(append(tt.objects, dpa)...)
+			if err != nil {
+				t.Errorf("error in creating fake client, likely programmer error")
+			}
+			r := &DPAReconciler{
+				Client:  fakeClient,
+				Scheme:  fakeClient.Scheme(),
+				Log:     logr.Discard(),
+				Context: newContextForTest(tt.name),
+				NamespacedName: types.NamespacedName{
+					Namespace: dpa.Namespace,
+					Name:      dpa.Name,
+				},
+				EventRecorder: record.NewFakeRecorder(10),
+			}
+			if _, err := r.ReconcileVeleroMetricsSVC(r.Log); err != nil {
+				t.Errorf("ReconcileVeleroMetricsSVC() unexpected error = %v", err)
+				return
+			}
+			svc := &corev1.Service{}
+			err = r.Get(r.Context, types.NamespacedName{Namespace: dpa.Namespace, Name: veleroMetricsSVCName}, svc)
+			if tt.wantSVC && err != nil {
+				t.Errorf("ReconcileVeleroMetricsSVC() expected metrics service, got error = %v", err)
+			}
+			if !tt.wantSVC && !k8serror.IsNotFound(err) {
+				t.Errorf("ReconcileVeleroMetricsSVC() expected no metrics service, got error = %v", err)
+			}
+		})
+	}
+}
+
+func TestDPAReconciler_ReconcileNodeAgentMetricsSVC(t *testing.T) {
+	tests := []struct {
+		name      string
+		nodeAgent *oadpv1alpha1.NodeAgentConfig
+		objects   []client.Object
+		wantSVC   bool
+	}{
+		{
+			name: "metrics service created for node agent with metrics enabled",
+			nodeAgent: &oadpv1alpha1.NodeAgentConfig{
+				NodeAgentCommonFields: oadpv1alpha1.NodeAgentCommonFields{Enable: pointer.Bool(true)},
+				Metrics:               pointer.Bool(true),
+			},
+			wantSVC: true,
+		},
+		{
+			name: "no metrics service for node agent with metrics disabled",
+			nodeAgent: &oadpv1alpha1.NodeAgentConfig{
+				NodeAgentCommonFields: oadpv1alpha1.NodeAgentCommonFields{Enable: pointer.Bool(true)},
+			},
+			wantSVC: false,
+		},
+		{
+			name: "no metrics service for disabled node agent with metrics enabled",
+			nodeAgent: &oadpv1alpha1.NodeAgentConfig{
+				NodeAgentCommonFields: oadpv1alpha1.NodeAgentCommonFields{Enable: pointer.Bool(false)},
+				Metrics:               pointer.Bool(true),
+			},
+			wantSVC: false,
+		},
+		{
+			name: "existing metrics service deleted for node agent with metrics disabled",
+			nodeAgent: &oadpv1alpha1.NodeAgentConfig{
+				NodeAgentCommonFields: oadpv1alpha1.NodeAgentCommonFields{Enable: pointer.Bool(true)},
+				Metrics:               pointer.Bool(false),
+			},
+			objects: []client.Object{
+				&corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      nodeAgentMetricsSVCName,
+						Namespace: "test-ns",
+					},
+				},
+			},
+			wantSVC: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dpa := &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-dpa",
+					Namespace: "test-ns",
+				},
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero:    &oadpv1alpha1.VeleroConfig{},
+						NodeAgent: tt.nodeAgent,
+					},
+				},
+			}
+			fakeClient, err := getFakeClientFromObjectsForMonitor(append(tt.objects, dpa)...)
+			if err != nil {
+				t.Errorf("error in creating fake client, likely programmer error")
+			}
+			r := &DPAReconciler{
+				Client:  fakeClient,
+				Scheme:  fakeClient.Scheme(),
+				Log:     logr.Discard(),
+				Context: newContextForTest(tt.name),
+				NamespacedName: types.NamespacedName{
+					Namespace: dpa.Namespace,
+					Name:      dpa.Name,
+				},
+				EventRecorder: record.NewFakeRecorder(10),
+			}
+			if _, err := r.ReconcileNodeAgentMetricsSVC(r.Log); err != nil {
+				t.Errorf("ReconcileNodeAgentMetricsSVC() unexpected error = %v", err)
+				return
+			}
+			svc := &corev1.Service{}
+			err = r.Get(r.Context, types.NamespacedName{Namespace: dpa.Namespace, Name: nodeAgentMetricsSVCName}, svc)
+			if !tt.wantSVC {
+				if !k8serror.IsNotFound(err) {
+					t.Errorf("ReconcileNodeAgentMetricsSVC() expected no metrics service, got error = %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("ReconcileNodeAgentMetricsSVC() expected metrics service, got error = %v", err)
+				return
+			}
+			if !reflect.DeepEqual(svc.Spec.Selector, nodeAgentMatchLabels) {
+				t.Errorf("ReconcileNodeAgentMetricsSVC() selector = %v, want %v", svc.Spec.Selector, nodeAgentMatchLabels)
+			}
+			wantPorts := []corev1.ServicePort{
+				{
+					Protocol:   corev1.ProtocolTCP,
+					Name:       "monitoring",
+					Port:       nodeAgentMetricsPort,
+					TargetPort: intstr.FromInt(int(nodeAgentMetricsPort)),
+				},
+			}
+			if !reflect.DeepEqual(svc.Spec.Ports, wantPorts) {
+				t.Errorf("ReconcileNodeAgentMetricsSVC() ports = %v, want %v", svc.Spec.Ports, wantPorts)
+			}
+			if svc.Labels["app.kubernetes.io/component"] != common.NodeAgent {
+				t.Errorf("ReconcileNodeAgentMetricsSVC() labels = %v, want component %s", svc.Labels, common.NodeAgent)
+			}
+		})
+	}
+}
+
+func TestDPAReconciler_warnServiceMonitorsForLocalhostMetrics(t *testing.T) {
+	dpa := &oadpv1alpha1.DataProtectionApplication{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-dpa",
+			Namespace: "test-ns",
+		},
+		Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+			Configuration: &oadpv1alpha1.ApplicationConfig{
+				Velero: &oadpv1alpha1.VeleroConfig{
+					Args: &server.Args{
+						ServerConfig: server.ServerConfig{
+							MetricsAddress: "127.0.0.1:8085",
+						},
+					},
+				},
+			},
+		},
+	}
+	serviceMonitor := &monitor.ServiceMonitor{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "oadp-service-monitor",
+			Namespace: "test-ns",
+		},
+		Spec: monitor.ServiceMonitorSpec{
+			Selector: metav1.LabelSelector{
+				MatchLabels: map[string]string{"app.kubernetes.io/name": "velero"},
+			},
+		},
+	}
+	fakeClient, err := getFakeClientFromObjectsForMonitor(dpa, serviceMonitor)
+	if err != nil {
+		t.Errorf("error in creating fake client, likely programmer error")
+	}
+	recorder := record.NewFakeRecorder(10)
+	r := &DPAReconciler{
+		Client:        fakeClient,
+		Scheme:        fakeClient.Scheme(),
+		Log:           logr.Discard(),
+		Context:       newContextForTest("localhost metrics service monitor"),
+		EventRecorder: recorder,
+	}
+	r.warnServiceMonitorsForLocalhostMetrics(r.Log, dpa)
+	if len(recorder.Events) != 1 {
+		t.Errorf("warnServiceMonitorsForLocalhostMetrics() emitted %d events, want 1", len(recorder.Events))
+	}
+}
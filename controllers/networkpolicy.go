@@ -0,0 +1,207 @@
+package controllers
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	k8serror "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	oadpv1alpha1 "github.com/openshift/oadp-operator/api/v1alpha1"
+	"github.com/openshift/oadp-operator/pkg/common"
+)
+
+const veleroEgressNetworkPolicyName = common.Velero + "-egress"
+
+// object storage endpoints are usually hostnames, so lookups are allowed on the standard DNS port and the
+// port OpenShift DNS pods listen on
+var dnsEgressPorts = []int{53, 5353}
+
+// the service of the kubernetes API server, velero and node agent cannot work without reaching it
+var kubernetesAPIServerService = types.NamespacedName{Namespace: "default", Name: "kubernetes"}
+
+func (r *DPAReconciler) ReconcileVeleroEgressNetworkPolicy(log logr.Logger) (bool, error) {
+	dpa := oadpv1alpha1.DataProtectionApplication{}
+	if err := r.Get(r.Context, r.NamespacedName, &dpa); err != nil {
+		return false, err
+	}
+
+	networkPolicy := &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      veleroEgressNetworkPolicyName,
+			Namespace: r.NamespacedName.Namespace,
+		},
+	}
+
+	// Delete (possible) previously created NetworkPolicy
+	if dpa.Spec.EgressNetworkPolicy == nil {
+		if err := r.Get(r.Context, types.NamespacedName{Name: networkPolicy.Name, Namespace: networkPolicy.Namespace}, networkPolicy); err != nil {
+			if k8serror.IsNotFound(err) {
+				return true, nil
+			}
+			return false, err
+		}
+		if err := r.Delete(r.Context, networkPolicy); err != nil {
+			return false, err
+		}
+		r.EventRecorder.Event(networkPolicy,
+			corev1.EventTypeNormal,
+			"VeleroEgressNetworkPolicyDeleted",
+			fmt.Sprintf("velero egress network policy %s/%s deleted", networkPolicy.Namespace, networkPolicy.Name),
+		)
+		return true, nil
+	}
+
+	apiServerRules, err := r.getAPIServerEgressRules()
+	if err != nil {
+		return false, err
+	}
+
+	op, err := controllerutil.CreateOrUpdate(r.Context, r.Client, networkPolicy, func() error {
+		buildVeleroEgressNetworkPolicy(networkPolicy, &dpa, apiServerRules)
+
+		// Setting controller owner reference on the velero egress network policy
+		return controllerutil.SetControllerReference(&dpa, networkPolicy, r.Scheme)
+	})
+	if err != nil {
+		return false, err
+	}
+
+	if op == controllerutil.OperationResultCreated || op == controllerutil.OperationResultUpdated {
+		// Trigger event to indicate velero egress network policy was created or updated
+		r.EventRecorder.Event(networkPolicy,
+			corev1.EventTypeNormal,
+			"VeleroEgressNetworkPolicyReconciled",
+			fmt.Sprintf("performed %s on velero egress network policy %s/%s", op, networkPolicy.Namespace, networkPolicy.Name),
+		)
+	}
+	return true, nil
+}
+
+// getAPIServerEgressRules returns the egress rules allowing the kubernetes API server, by its service IPs and
+// ports and by the addresses and ports of its endpoints, as network plugins match policies before or after the
+// service address is translated
+func (r *DPAReconciler) getAPIServerEgressRules() ([]networkingv1.NetworkPolicyEgressRule, error) {
+	tcp := corev1.ProtocolTCP
+	reader := r.uncachedReader()
+	service := corev1.Service{}
+	if err := reader.Get(r.Context, kubernetesAPIServerService, &service); err != nil {
+		return nil, fmt.Errorf("unable to get the kubernetes API server service to allow egress to it: %w", err)
+	}
+	endpoints := corev1.Endpoints{}
+	if err := reader.Get(r.Context, kubernetesAPIServerService, &endpoints); err != nil {
+		return nil, fmt.Errorf("unable to get the kubernetes API server endpoints to allow egress to them: %w", err)
+	}
+
+	serviceRule := networkingv1.NetworkPolicyEgressRule{}
+	clusterIPs := service.Spec.ClusterIPs
+	if len(clusterIPs) == 0 && service.Spec.ClusterIP != "" {
+		clusterIPs = []string{service.Spec.ClusterIP}
+	}
+	for _, ip := range clusterIPs {
+		if cidr := hostCIDR(ip); cidr != "" {
+			serviceRule.To = append(serviceRule.To, networkingv1.NetworkPolicyPeer{IPBlock: &networkingv1.IPBlock{CIDR: cidr}})
+		}
+	}
+	for _, servicePort := range service.Spec.Ports {
+		port := intstr.FromInt(int(servicePort.Port))
+		serviceRule.Ports = append(serviceRule.Ports, networkingv1.NetworkPolicyPort{Protocol: &tcp, Port: &port})
+	}
+
+	endpointsRule := networkingv1.NetworkPolicyEgressRule{}
+	for _, subset := range endpoints.Subsets {
+		for _, address := range subset.Addresses {
+			if cidr := hostCIDR(address.IP); cidr != "" {
+				endpointsRule.To = append(endpointsRule.To, networkingv1.NetworkPolicyPeer{IPBlock: &networkingv1.IPBlock{CIDR: cidr}})
+			}
+		}
+		for _, endpointPort := range subset.Ports {
+			port := intstr.FromInt(int(endpointPort.Port))
+			endpointsRule.Ports = append(endpointsRule.Ports, networkingv1.NetworkPolicyPort{Protocol: &tcp, Port: &port})
+		}
+	}
+
+	rules := []networkingv1.NetworkPolicyEgressRule{}
+	for _, rule := range []networkingv1.NetworkPolicyEgressRule{serviceRule, endpointsRule} {
+		// a rule without peers would allow egress to every destination
+		if len(rule.To) > 0 {
+			rules = append(rules, rule)
+		}
+	}
+	if len(rules) == 0 {
+		return nil, fmt.Errorf("kubernetes API server service %s has no addresses to allow egress to", kubernetesAPIServerService)
+	}
+	return rules, nil
+}
+
+// hostCIDR returns the single address CIDR of ip, or an empty string if ip is not an IP address
+func hostCIDR(ip string) string {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return ""
+	}
+	if parsed.To4() != nil {
+		return ip + "/32"
+	}
+	return ip + "/128"
+}
+
+func buildVeleroEgressNetworkPolicy(networkPolicy *networkingv1.NetworkPolicy, dpa *oadpv1alpha1.DataProtectionApplication, apiServerRules []networkingv1.NetworkPolicyEgressRule) {
+	tcp := corev1.ProtocolTCP
+	udp := corev1.ProtocolUDP
+	dnsPorts := []networkingv1.NetworkPolicyPort{}
+	for _, port := range dnsEgressPorts {
+		port := intstr.FromInt(port)
+		dnsPorts = append(dnsPorts,
+			networkingv1.NetworkPolicyPort{Protocol: &udp, Port: &port},
+			networkingv1.NetworkPolicyPort{Protocol: &tcp, Port: &port},
+		)
+	}
+	egress := []networkingv1.NetworkPolicyEgressRule{{Ports: dnsPorts}}
+	egress = append(egress, apiServerRules...)
+	for _, endpoint := range dpa.Spec.EgressNetworkPolicy.Endpoints {
+		rule := networkingv1.NetworkPolicyEgressRule{
+			To: []networkingv1.NetworkPolicyPeer{{IPBlock: &networkingv1.IPBlock{CIDR: endpoint.CIDR}}},
+		}
+		for _, port := range endpoint.Ports {
+			port := intstr.FromInt(int(port))
+			rule.Ports = append(rule.Ports, networkingv1.NetworkPolicyPort{Protocol: &tcp, Port: &port})
+		}
+		egress = append(egress, rule)
+	}
+
+	networkPolicy.Labels = getDpaAppLabels(dpa)
+	// component label is set on both velero and node agent pods
+	networkPolicy.Spec = networkingv1.NetworkPolicySpec{
+		PodSelector: metav1.LabelSelector{MatchLabels: map[string]string{"component": common.Velero}},
+		PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeEgress},
+		Egress:      egress,
+	}
+}
+
+// validateEgressNetworkPolicy checks the endpoints of the velero egress network policy are valid CIDRs and ports
+func validateEgressNetworkPolicy(dpa *oadpv1alpha1.DataProtectionApplication) error {
+	if dpa.Spec.EgressNetworkPolicy == nil {
+		return nil
+	}
+	if len(dpa.Spec.EgressNetworkPolicy.Endpoints) == 0 {
+		return fmt.Errorf("egressNetworkPolicy must have at least one endpoint")
+	}
+	for i, endpoint := range dpa.Spec.EgressNetworkPolicy.Endpoints {
+		if _, _, err := net.ParseCIDR(endpoint.CIDR); err != nil {
+			return fmt.Errorf("egressNetworkPolicy endpoints[%d] cidr %q is not a valid CIDR, use a /32 prefix for a single IPv4 address", i, endpoint.CIDR)
+		}
+		for _, port := range endpoint.Ports {
+			if port < 1 || port > 65535 {
+				return fmt.Errorf("egressNetworkPolicy endpoints[%d] port %d must be between 1 and 65535", i, port)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,235 @@
+package controllers
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	k8serror "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	oadpv1alpha1 "github.com/openshift/oadp-operator/api/v1alpha1"
+	"github.com/openshift/oadp-operator/pkg/common"
+)
+
+func TestDPAReconciler_ReconcileVeleroEgressNetworkPolicy(t *testing.T) {
+	tcp := corev1.ProtocolTCP
+	port443 := intstr.FromInt(443)
+	port6443 := intstr.FromInt(6443)
+	port9000 := intstr.FromInt(9000)
+	apiServerObjects := []client.Object{
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "kubernetes", Namespace: "default"},
+			Spec: corev1.ServiceSpec{
+				ClusterIP:  "172.30.0.1",
+				ClusterIPs: []string{"172.30.0.1"},
+				Ports:      []corev1.ServicePort{{Name: "https", Port: 443}},
+			},
+		},
+		&corev1.Endpoints{
+			ObjectMeta: metav1.ObjectMeta{Name: "kubernetes", Namespace: "default"},
+			Subsets: []corev1.EndpointSubset{
+				{
+					Addresses: []corev1.EndpointAddress{{IP: "10.0.1.10"}, {IP: "10.0.1.11"}},
+					Ports:     []corev1.EndpointPort{{Name: "https", Port: 6443}},
+				},
+			},
+		},
+	}
+	wantAPIServerRules := []networkingv1.NetworkPolicyEgressRule{
+		{
+			To:    []networkingv1.NetworkPolicyPeer{{IPBlock: &networkingv1.IPBlock{CIDR: "172.30.0.1/32"}}},
+			Ports: []networkingv1.NetworkPolicyPort{{Protocol: &tcp, Port: &port443}},
+		},
+		{
+			To: []networkingv1.NetworkPolicyPeer{
+				{IPBlock: &networkingv1.IPBlock{CIDR: "10.0.1.10/32"}},
+				{IPBlock: &networkingv1.IPBlock{CIDR: "10.0.1.11/32"}},
+			},
+			Ports: []networkingv1.NetworkPolicyPort{{Protocol: &tcp, Port: &port6443}},
+		},
+	}
+	tests := []struct {
+		name              string
+		egressPolicy      *oadpv1alpha1.EgressNetworkPolicy
+		objects           []client.Object
+		wantNetworkPolicy bool
+		wantErr           bool
+		wantEndpointRules []networkingv1.NetworkPolicyEgressRule
+	}{
+		{
+			name: "egressNetworkPolicy set creates network policy",
+			egressPolicy: &oadpv1alpha1.EgressNetworkPolicy{
+				Endpoints: []oadpv1alpha1.EgressEndpoint{
+					{CIDR: "10.0.0.0/16"},
+					{CIDR: "192.168.1.10/32", Ports: []int32{9000}},
+				},
+			},
+			objects:           apiServerObjects,
+			wantNetworkPolicy: true,
+			wantEndpointRules: []networkingv1.NetworkPolicyEgressRule{
+				{
+					To: []networkingv1.NetworkPolicyPeer{{IPBlock: &networkingv1.IPBlock{CIDR: "10.0.0.0/16"}}},
+				},
+				{
+					To:    []networkingv1.NetworkPolicyPeer{{IPBlock: &networkingv1.IPBlock{CIDR: "192.168.1.10/32"}}},
+					Ports: []networkingv1.NetworkPolicyPort{{Protocol: &tcp, Port: &port9000}},
+				},
+			},
+		},
+		{
+			name: "egressNetworkPolicy set without kubernetes API server service",
+			egressPolicy: &oadpv1alpha1.EgressNetworkPolicy{
+				Endpoints: []oadpv1alpha1.EgressEndpoint{{CIDR: "10.0.0.0/16"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "egressNetworkPolicy unset deletes existing network policy",
+			objects: []client.Object{
+				&networkingv1.NetworkPolicy{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      veleroEgressNetworkPolicyName,
+						Namespace: "test-ns",
+					},
+				},
+			},
+			wantNetworkPolicy: false,
+		},
+		{
+			name:              "egressNetworkPolicy unset",
+			wantNetworkPolicy: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dpa := &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-DPA-CR",
+					Namespace: "test-ns",
+				},
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{},
+					},
+					EgressNetworkPolicy: tt.egressPolicy,
+				},
+			}
+			fakeClient, err := getFakeClientFromObjects(append(tt.objects, dpa)...)
+			if err != nil {
+				t.Errorf("error in creating fake client, likely programmer error")
+			}
+			r := &DPAReconciler{
+				Client:  fakeClient,
+				Scheme:  fakeClient.Scheme(),
+				Log:     logr.Discard(),
+				Context: newContextForTest(tt.name),
+				NamespacedName: types.NamespacedName{
+					Namespace: dpa.Namespace,
+					Name:      dpa.Name,
+				},
+				EventRecorder: record.NewFakeRecorder(10),
+			}
+			if _, err := r.ReconcileVeleroEgressNetworkPolicy(r.Log); (err != nil) != tt.wantErr {
+				t.Errorf("ReconcileVeleroEgressNetworkPolicy() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			networkPolicy := &networkingv1.NetworkPolicy{}
+			err = r.Get(r.Context, types.NamespacedName{Name: veleroEgressNetworkPolicyName, Namespace: dpa.Namespace}, networkPolicy)
+			if !tt.wantNetworkPolicy {
+				if !k8serror.IsNotFound(err) {
+					t.Errorf("ReconcileVeleroEgressNetworkPolicy() expected no network policy, got error = %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("ReconcileVeleroEgressNetworkPolicy() expected network policy, got error = %v", err)
+				return
+			}
+			wantSelector := map[string]string{"component": common.Velero}
+			if !reflect.DeepEqual(networkPolicy.Spec.PodSelector.MatchLabels, wantSelector) {
+				t.Errorf("ReconcileVeleroEgressNetworkPolicy() pod selector = %v, want %v", networkPolicy.Spec.PodSelector.MatchLabels, wantSelector)
+			}
+			if !reflect.DeepEqual(networkPolicy.Spec.PolicyTypes, []networkingv1.PolicyType{networkingv1.PolicyTypeEgress}) {
+				t.Errorf("ReconcileVeleroEgressNetworkPolicy() policy types = %v, want egress only", networkPolicy.Spec.PolicyTypes)
+			}
+			// the first rule allows DNS
+			if len(networkPolicy.Spec.Egress) == 0 || len(networkPolicy.Spec.Egress[0].To) != 0 || len(networkPolicy.Spec.Egress[0].Ports) != 2*len(dnsEgressPorts) {
+				t.Errorf("ReconcileVeleroEgressNetworkPolicy() expected a DNS egress rule first, got %v", networkPolicy.Spec.Egress)
+				return
+			}
+			// the kubernetes API server rules follow
+			wantRules := append(append([]networkingv1.NetworkPolicyEgressRule{}, wantAPIServerRules...), tt.wantEndpointRules...)
+			if !reflect.DeepEqual(networkPolicy.Spec.Egress[1:], wantRules) {
+				t.Errorf("ReconcileVeleroEgressNetworkPolicy() egress rules = %v, want %v", networkPolicy.Spec.Egress[1:], wantRules)
+			}
+		})
+	}
+}
+
+func Test_validateEgressNetworkPolicy(t *testing.T) {
+	tests := []struct {
+		name           string
+		egressPolicy   *oadpv1alpha1.EgressNetworkPolicy
+		wantErrMessage string
+	}{
+		{
+			name: "egressNetworkPolicy unset",
+		},
+		{
+			name: "valid endpoints",
+			egressPolicy: &oadpv1alpha1.EgressNetworkPolicy{
+				Endpoints: []oadpv1alpha1.EgressEndpoint{{CIDR: "10.0.0.0/16", Ports: []int32{443}}, {CIDR: "fd00::/64"}},
+			},
+		},
+		{
+			name:           "no endpoints",
+			egressPolicy:   &oadpv1alpha1.EgressNetworkPolicy{},
+			wantErrMessage: "egressNetworkPolicy must have at least one endpoint",
+		},
+		{
+			name: "address without prefix length",
+			egressPolicy: &oadpv1alpha1.EgressNetworkPolicy{
+				Endpoints: []oadpv1alpha1.EgressEndpoint{{CIDR: "192.168.1.10"}},
+			},
+			wantErrMessage: "egressNetworkPolicy endpoints[0] cidr \"192.168.1.10\" is not a valid CIDR, use a /32 prefix for a single IPv4 address",
+		},
+		{
+			name: "hostname",
+			egressPolicy: &oadpv1alpha1.EgressNetworkPolicy{
+				Endpoints: []oadpv1alpha1.EgressEndpoint{{CIDR: "10.0.0.0/8"}, {CIDR: "s3.amazonaws.com"}},
+			},
+			wantErrMessage: "egressNetworkPolicy endpoints[1] cidr \"s3.amazonaws.com\" is not a valid CIDR, use a /32 prefix for a single IPv4 address",
+		},
+		{
+			name: "port out of range",
+			egressPolicy: &oadpv1alpha1.EgressNetworkPolicy{
+				Endpoints: []oadpv1alpha1.EgressEndpoint{{CIDR: "10.0.0.0/16", Ports: []int32{70000}}},
+			},
+			wantErrMessage: "egressNetworkPolicy endpoints[0] port 70000 must be between 1 and 65535",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dpa := &oadpv1alpha1.DataProtectionApplication{
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{EgressNetworkPolicy: tt.egressPolicy},
+			}
+			err := validateEgressNetworkPolicy(dpa)
+			if tt.wantErrMessage == "" {
+				if err != nil {
+					t.Errorf("validateEgressNetworkPolicy() unexpected error = %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErrMessage {
+				t.Errorf("validateEgressNetworkPolicy() error = %v, want %v", err, tt.wantErrMessage)
+			}
+		})
+	}
+}
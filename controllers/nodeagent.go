@@ -4,11 +4,17 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path"
 	"reflect"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/operator-framework/operator-lib/proxy"
+	velerov1 "github.com/vmware-tanzu/velero/pkg/apis/velero/v1"
 	"github.com/vmware-tanzu/velero/pkg/install"
+	"github.com/vmware-tanzu/velero/pkg/util/boolptr"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -83,10 +89,8 @@ func (r *DPAReconciler) ReconcileNodeAgentDaemonset(log logr.Logger) (bool, erro
 	}
 
 	if dpa.Spec.Configuration.Restic != nil {
-		// V(-1) corresponds to the warn level
 		var deprecationMsg string = "(Deprecation Warning) Use nodeAgent instead of restic, which is deprecated and will be removed with the OADP 1.4"
-		log.V(-1).Info(deprecationMsg)
-		r.EventRecorder.Event(&dpa, corev1.EventTypeWarning, "DeprecationResticConfig", deprecationMsg)
+		r.warnEvent(log, &dpa, "DeprecationResticConfig", deprecationMsg)
 	}
 
 	if dpa.Spec.Configuration.Restic != nil && dpa.Spec.Configuration.Restic.Enable != nil && *dpa.Spec.Configuration.Restic.Enable {
@@ -252,6 +256,13 @@ func (r *DPAReconciler) customizeNodeAgentDaemonset(dpa *oadpv1alpha1.DataProtec
 	if err != nil {
 		return nil, fmt.Errorf("NodeAgent daemonset template custom label: %s", err)
 	}
+	// add custom pod annotations
+	if podConfig := getNodeAgentPodConfig(dpa); podConfig != nil && podConfig.Annotations != nil {
+		ds.Spec.Template.Annotations, err = common.AppendUniqueKeyTOfTMaps(ds.Spec.Template.Annotations, podConfig.Annotations)
+		if err != nil {
+			return nil, fmt.Errorf("NodeAgent daemonset template custom annotation: %s", err)
+		}
+	}
 
 	// customize specs
 	ds.Spec.Selector = nodeAgentLabelSelector
@@ -271,6 +282,7 @@ func (r *DPAReconciler) customizeNodeAgentDaemonset(dpa *oadpv1alpha1.DataProtec
 			SupplementalGroups: dpa.Spec.Configuration.NodeAgent.SupplementalGroups,
 		}
 	}
+	ds.Spec.Template.Spec.HostPID = nodeAgentHostPID(dpa)
 
 	// append certs volume
 	ds.Spec.Template.Spec.Volumes = append(ds.Spec.Template.Spec.Volumes,
@@ -330,6 +342,14 @@ func (r *DPAReconciler) customizeNodeAgentDaemonset(dpa *oadpv1alpha1.DataProtec
 			Privileged: pointer.Bool(true),
 		}
 
+		if nodeAgentMetricsEnabled(dpa) {
+			nodeAgentContainer.Ports = append(nodeAgentContainer.Ports, corev1.ContainerPort{
+				Name:          "metrics",
+				ContainerPort: nodeAgentMetricsPort,
+				Protocol:      corev1.ProtocolTCP,
+			})
+		}
+
 		nodeAgentContainer.ImagePullPolicy = corev1.PullAlways
 		setContainerDefaults(nodeAgentContainer)
 	}
@@ -360,6 +380,10 @@ func (r *DPAReconciler) customizeNodeAgentDaemonset(dpa *oadpv1alpha1.DataProtec
 				IntVal: 0,
 			},
 		}
+		if podConfig := getNodeAgentPodConfig(dpa); podConfig != nil && podConfig.MaxUnavailable != nil {
+			maxUnavailable := *podConfig.MaxUnavailable
+			ds.Spec.UpdateStrategy.RollingUpdate.MaxUnavailable = &maxUnavailable
+		}
 	}
 	if ds.Spec.RevisionHistoryLimit == nil {
 		ds.Spec.RevisionHistoryLimit = pointer.Int32(10)
@@ -367,6 +391,201 @@ func (r *DPAReconciler) customizeNodeAgentDaemonset(dpa *oadpv1alpha1.DataProtec
 	return ds, nil
 }
 
+// nodeAgentMetricsEnabled returns true if the node agent is enabled with its metrics exposed
+func nodeAgentMetricsEnabled(dpa *oadpv1alpha1.DataProtectionApplication) bool {
+	return dpa.Spec.Configuration != nil && dpa.Spec.Configuration.NodeAgent != nil &&
+		boolptr.IsSetToTrue(dpa.Spec.Configuration.NodeAgent.Enable) && boolptr.IsSetToTrue(dpa.Spec.Configuration.NodeAgent.Metrics)
+}
+
+// nodeAgentEnabled returns true if the node agent is enabled by nodeAgent or the deprecated restic configuration
+func nodeAgentEnabled(dpa *oadpv1alpha1.DataProtectionApplication) bool {
+	return dpa.Spec.Configuration != nil &&
+		((dpa.Spec.Configuration.NodeAgent != nil && boolptr.IsSetToTrue(dpa.Spec.Configuration.NodeAgent.Enable)) ||
+			(dpa.Spec.Configuration.Restic != nil && boolptr.IsSetToTrue(dpa.Spec.Configuration.Restic.Enable)))
+}
+
+// nodeAgentHostPID returns true if the node agent runs in the host process namespace
+func nodeAgentHostPID(dpa *oadpv1alpha1.DataProtectionApplication) bool {
+	if dpa.Spec.Configuration.NodeAgent != nil {
+		return boolptr.IsSetToTrue(dpa.Spec.Configuration.NodeAgent.HostPID)
+	}
+	if dpa.Spec.Configuration.Restic != nil {
+		return boolptr.IsSetToTrue(dpa.Spec.Configuration.Restic.HostPID)
+	}
+	return false
+}
+
+// warnNodeAgentHostPID emits a warning when the node agent runs in the host process namespace, as its privileged
+// containers can see and signal every process of their node, including the processes of other pods
+func (r *DPAReconciler) warnNodeAgentHostPID(log logr.Logger, dpa *oadpv1alpha1.DataProtectionApplication) {
+	if !nodeAgentEnabled(dpa) || !nodeAgentHostPID(dpa) {
+		return
+	}
+	msg := "node agent hostPID is enabled, the privileged node agent containers can see and signal every process of their node; only enable hostPID if a CSI driver requires it"
+	r.warnEvent(log, dpa, "NodeAgentHostPID", msg)
+}
+
+// warnConflictingNodeAgents emits a warning for every DaemonSet outside of OADP mounting the host pods path of the
+// node agent, as another agent mounting the pod volumes on the same nodes can interfere with file system backups.
+// Listing every DaemonSet of the cluster is costly, so the check runs once per DPA generation.
+func (r *DPAReconciler) warnConflictingNodeAgents(log logr.Logger, dpa *oadpv1alpha1.DataProtectionApplication) {
+	if !nodeAgentEnabled(dpa) {
+		return
+	}
+	key := types.NamespacedName{Namespace: dpa.Namespace, Name: dpa.Name}
+	if generation, found := r.nodeAgentConflictChecks[key]; found && generation == dpa.Generation {
+		return
+	}
+	// DaemonSets of other namespaces are outside of the cache, the operator cluster role grants reading them
+	reader := r.uncachedReader()
+	daemonSets := appsv1.DaemonSetList{}
+	if err := reader.List(r.Context, &daemonSets); err != nil {
+		log.Error(err, "unable to list DaemonSets, skipping the check for node agents conflicting with the OADP node agent")
+		return
+	}
+	if r.nodeAgentConflictChecks == nil {
+		r.nodeAgentConflictChecks = map[types.NamespacedName]int64{}
+	}
+	r.nodeAgentConflictChecks[key] = dpa.Generation
+	for _, msg := range conflictingNodeAgentMessages(dpa, daemonSets.Items) {
+		r.warnEvent(log, dpa, "ConflictingNodeAgent", msg)
+	}
+}
+
+// conflictingNodeAgentMessages returns a message for each DaemonSet, other than the OADP node agent, with a hostPath
+// volume of the host pods path
+func conflictingNodeAgentMessages(dpa *oadpv1alpha1.DataProtectionApplication, daemonSets []appsv1.DaemonSet) []string {
+	hostPodsPath := path.Clean(getFsPvHostPath())
+	messages := []string{}
+	for _, daemonSet := range daemonSets {
+		if daemonSet.Namespace == dpa.Namespace && daemonSet.Name == common.NodeAgent {
+			continue
+		}
+		for _, volume := range daemonSet.Spec.Template.Spec.Volumes {
+			if volume.HostPath != nil && path.Clean(volume.HostPath.Path) == hostPodsPath {
+				messages = append(messages, fmt.Sprintf("DaemonSet %s/%s also mounts the host pods path %s used by the node agent, running another agent on the pod volumes of the same nodes can cause file system backups and restores to fail", daemonSet.Namespace, daemonSet.Name, hostPodsPath))
+				break
+			}
+		}
+	}
+	return messages
+}
+
+// getNodeAgentPodConfig returns the PodConfig of nodeAgent, or of restic if nodeAgent is not configured
+func getNodeAgentPodConfig(dpa *oadpv1alpha1.DataProtectionApplication) *oadpv1alpha1.PodConfig {
+	if dpa.Spec.Configuration.NodeAgent != nil {
+		return dpa.Spec.Configuration.NodeAgent.PodConfig
+	}
+	if dpa.Spec.Configuration.Restic != nil {
+		return dpa.Spec.Configuration.Restic.PodConfig
+	}
+	return nil
+}
+
+// validateNodeAgentTimeout checks the restic and node agent timeouts are positive durations, as velero only rejects
+// them when the server starts
+func validateNodeAgentTimeout(dpa *oadpv1alpha1.DataProtectionApplication) error {
+	timeouts := map[string]string{}
+	if dpa.Spec.Configuration.Restic != nil {
+		timeouts["restic.timeout"] = dpa.Spec.Configuration.Restic.Timeout
+	}
+	if dpa.Spec.Configuration.NodeAgent != nil {
+		timeouts["nodeAgent.timeout"] = dpa.Spec.Configuration.NodeAgent.Timeout
+	}
+	for _, field := range sortedKeys(timeouts) {
+		timeout := timeouts[field]
+		if timeout == "" {
+			continue
+		}
+		duration, err := time.ParseDuration(timeout)
+		if err != nil {
+			return fmt.Errorf("%s %q is not a valid duration: %v", field, timeout, err)
+		}
+		if duration <= 0 {
+			return fmt.Errorf("%s %q must be greater than zero", field, timeout)
+		}
+	}
+	return nil
+}
+
+// validateNodeAgentMaxUnavailable returns an error if the node agent maxUnavailable is not a positive
+// number or a percentage between 1% and 100%
+func validateNodeAgentMaxUnavailable(dpa *oadpv1alpha1.DataProtectionApplication) error {
+	podConfig := getNodeAgentPodConfig(dpa)
+	if podConfig == nil || podConfig.MaxUnavailable == nil {
+		return nil
+	}
+	maxUnavailable := podConfig.MaxUnavailable
+	if maxUnavailable.Type == intstr.String {
+		if !strings.HasSuffix(maxUnavailable.StrVal, "%") {
+			return fmt.Errorf("nodeAgent maxUnavailable %q must be an integer or a percentage", maxUnavailable.StrVal)
+		}
+		percent, err := strconv.Atoi(strings.TrimSuffix(maxUnavailable.StrVal, "%"))
+		if err != nil || percent < 1 || percent > 100 {
+			return fmt.Errorf("nodeAgent maxUnavailable %q must be a percentage between 1%% and 100%%", maxUnavailable.StrVal)
+		}
+		return nil
+	}
+	if maxUnavailable.IntVal < 1 {
+		return fmt.Errorf("nodeAgent maxUnavailable %d must be greater than 0", maxUnavailable.IntVal)
+	}
+	return nil
+}
+
+// validateDataMoverNodeAgent returns an error if snapshot data movement is enabled by default while the node agent,
+// which runs the data mover, is disabled
+func validateDataMoverNodeAgent(dpa *oadpv1alpha1.DataProtectionApplication) error {
+	if dpa.Spec.Configuration == nil || dpa.Spec.Configuration.Velero == nil ||
+		!boolptr.IsSetToTrue(dpa.Spec.Configuration.Velero.DefaultSnapshotMoveData) {
+		return nil
+	}
+	if dpa.Spec.Configuration.NodeAgent != nil && boolptr.IsSetToTrue(dpa.Spec.Configuration.NodeAgent.Enable) {
+		return nil
+	}
+	if dpa.Spec.Configuration.Restic != nil && boolptr.IsSetToTrue(dpa.Spec.Configuration.Restic.Enable) {
+		return nil
+	}
+	return fmt.Errorf("defaultSnapshotMoveData requires the node agent, which runs the data mover, set nodeAgent.enable to true")
+}
+
+// validateFsBackupLocation returns an error if file system backup is enabled by default with noDefaultBackupLocation
+// while no backup storage location exists in the DPA namespace, as the node agent stores the volume data in the
+// repository of a BSL. Volumes backed up with snapshots only are not affected.
+func (r *DPAReconciler) validateFsBackupLocation(dpa *oadpv1alpha1.DataProtectionApplication) error {
+	if !nodeAgentEnabled(dpa) || !dpa.Spec.Configuration.Velero.NoDefaultBackupLocation ||
+		!boolptr.IsSetToTrue(dpa.Spec.Configuration.Velero.DefaultVolumesToFSBackup) {
+		return nil
+	}
+	if found, err := r.hasBackupStorageLocation(dpa.Namespace); err != nil || found {
+		return err
+	}
+	return retriable(fmt.Errorf("defaultVolumesToFSBackup requires a backup storage location to store volume data, create a BackupStorageLocation in namespace %s or unset noDefaultBackupLocation", dpa.Namespace))
+}
+
+// validateDataMoverBackupLocation returns an error if snapshot data movement is enabled by default with
+// noDefaultBackupLocation while no backup storage location exists in the DPA namespace, as the data mover uploads
+// the snapshot data to the repository of a BSL
+func (r *DPAReconciler) validateDataMoverBackupLocation(dpa *oadpv1alpha1.DataProtectionApplication) error {
+	if !dpa.Spec.Configuration.Velero.NoDefaultBackupLocation ||
+		!boolptr.IsSetToTrue(dpa.Spec.Configuration.Velero.DefaultSnapshotMoveData) {
+		return nil
+	}
+	if found, err := r.hasBackupStorageLocation(dpa.Namespace); err != nil || found {
+		return err
+	}
+	return retriable(fmt.Errorf("noDefaultBackupLocation cannot be used when snapshotMoveData/data-mover is enabled because moved data requires a backup storage location"))
+}
+
+// hasBackupStorageLocation returns whether any backup storage location, including the ones not created by the DPA,
+// exists in the namespace
+func (r *DPAReconciler) hasBackupStorageLocation(namespace string) (bool, error) {
+	bslList := velerov1.BackupStorageLocationList{}
+	if err := r.List(r.Context, &bslList, client.InNamespace(namespace)); err != nil {
+		return false, err
+	}
+	return len(bslList.Items) > 0, nil
+}
+
 func (r *DPAReconciler) ReconcileFsRestoreHelperConfig(log logr.Logger) (bool, error) {
 	dpa := oadpv1alpha1.DataProtectionApplication{}
 	if err := r.Get(r.Context, r.NamespacedName, &dpa); err != nil {
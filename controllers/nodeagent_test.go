@@ -265,6 +265,161 @@ func TestDPAReconciler_buildNodeAgentDaemonset(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Valid velero and daemonset with NodeAgent maxUnavailable",
+			args: args{
+				&oadpv1alpha1.DataProtectionApplication{
+					Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+						Configuration: &oadpv1alpha1.ApplicationConfig{
+							NodeAgent: &oadpv1alpha1.NodeAgentConfig{
+								NodeAgentCommonFields: oadpv1alpha1.NodeAgentCommonFields{
+									PodConfig: &oadpv1alpha1.PodConfig{
+										MaxUnavailable: &intstr.IntOrString{Type: intstr.String, StrVal: "25%"},
+									},
+								},
+								UploaderType: "",
+							},
+							Velero: &oadpv1alpha1.VeleroConfig{
+								PodConfig: &oadpv1alpha1.PodConfig{},
+							},
+						},
+					},
+				}, &appsv1.DaemonSet{
+					ObjectMeta: getNodeAgentObjectMeta(r),
+				},
+			},
+			wantErr: false,
+			want: &appsv1.DaemonSet{
+				ObjectMeta: getNodeAgentObjectMeta(r),
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "DaemonSet",
+					APIVersion: appsv1.SchemeGroupVersion.String(),
+				},
+				Spec: appsv1.DaemonSetSpec{
+					UpdateStrategy: appsv1.DaemonSetUpdateStrategy{
+						Type: appsv1.RollingUpdateDaemonSetStrategyType,
+						RollingUpdate: &appsv1.RollingUpdateDaemonSet{
+							MaxUnavailable: &intstr.IntOrString{Type: intstr.String, StrVal: "25%"},
+							MaxSurge:       &intstr.IntOrString{Type: intstr.Int, IntVal: 0},
+						},
+					},
+					Selector: nodeAgentLabelSelector,
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{
+							Labels: map[string]string{
+								"component": common.Velero,
+								"name":      common.NodeAgent,
+							},
+						},
+						Spec: corev1.PodSpec{
+							NodeSelector:       dpa.Spec.Configuration.NodeAgent.PodConfig.NodeSelector,
+							ServiceAccountName: common.Velero,
+							SecurityContext: &corev1.PodSecurityContext{
+								RunAsUser:          pointer.Int64(0),
+								SupplementalGroups: dpa.Spec.Configuration.NodeAgent.SupplementalGroups,
+							},
+							Volumes: []corev1.Volume{
+								// Cloud Provider volumes are dynamically added in the for loop below
+								{
+									Name: HostPods,
+									VolumeSource: corev1.VolumeSource{
+										HostPath: &corev1.HostPathVolumeSource{
+											Path: fsPvHostPath,
+										},
+									},
+								},
+								{
+									Name: HostPlugins,
+									VolumeSource: corev1.VolumeSource{
+										HostPath: &corev1.HostPathVolumeSource{
+											Path: "/var/lib/kubelet/plugins",
+										},
+									},
+								},
+								{
+									Name: "scratch",
+									VolumeSource: corev1.VolumeSource{
+										EmptyDir: &corev1.EmptyDirVolumeSource{},
+									},
+								},
+								{
+									Name: "certs",
+									VolumeSource: corev1.VolumeSource{
+										EmptyDir: &corev1.EmptyDirVolumeSource{},
+									},
+								},
+							},
+							Tolerations: dpa.Spec.Configuration.NodeAgent.PodConfig.Tolerations,
+							Containers: []corev1.Container{
+								{
+									Name: common.NodeAgent,
+									SecurityContext: &corev1.SecurityContext{
+										Privileged: pointer.Bool(true),
+									},
+									Image:           getVeleroImage(&dpa),
+									ImagePullPolicy: corev1.PullAlways,
+									Command: []string{
+										"/velero",
+									},
+									Args: []string{
+										common.NodeAgent,
+										"server",
+									},
+									VolumeMounts: []corev1.VolumeMount{
+										{
+											Name:             HostPods,
+											MountPath:        "/host_pods",
+											MountPropagation: &mountPropagationToHostContainer,
+										},
+										{
+											Name:             HostPlugins,
+											MountPath:        "/var/lib/kubelet/plugins",
+											MountPropagation: &mountPropagationToHostContainer,
+										},
+										{
+											Name:      "scratch",
+											MountPath: "/scratch",
+										},
+										{
+											Name:      "certs",
+											MountPath: "/etc/ssl/certs",
+										},
+									},
+									Resources: corev1.ResourceRequirements{
+										Requests: corev1.ResourceList{
+											corev1.ResourceCPU:    resource.MustParse("500m"),
+											corev1.ResourceMemory: resource.MustParse("128Mi"),
+										},
+									},
+									Env: []corev1.EnvVar{
+										{
+											Name: "NODE_NAME",
+											ValueFrom: &corev1.EnvVarSource{
+												FieldRef: &corev1.ObjectFieldSelector{
+													FieldPath: "spec.nodeName",
+												},
+											},
+										},
+										{
+											Name: "VELERO_NAMESPACE",
+											ValueFrom: &corev1.EnvVarSource{
+												FieldRef: &corev1.ObjectFieldSelector{
+													FieldPath: "metadata.namespace",
+												},
+											},
+										},
+										{
+											Name:  "VELERO_SCRATCH_DIR",
+											Value: "/scratch",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "Valid velero with Env PodConfig and daemonset",
 			args: args{
@@ -2462,7 +2617,7 @@ func TestDPAReconciler_buildNodeAgentDaemonset(t *testing.T) {
 				if len(tt.want.Spec.Template.Spec.Containers) > 0 {
 					setContainerDefaults(&tt.want.Spec.Template.Spec.Containers[0])
 				}
-				if tt.want.Spec.UpdateStrategy.Type == appsv1.RollingUpdateDaemonSetStrategyType {
+				if tt.want.Spec.UpdateStrategy.Type == appsv1.RollingUpdateDaemonSetStrategyType && tt.want.Spec.UpdateStrategy.RollingUpdate == nil {
 					tt.want.Spec.UpdateStrategy.RollingUpdate = &appsv1.RollingUpdateDaemonSet{
 						MaxUnavailable: &intstr.IntOrString{
 							Type:   intstr.Int,
@@ -2486,6 +2641,105 @@ func TestDPAReconciler_buildNodeAgentDaemonset(t *testing.T) {
 	}
 }
 
+func TestDPAReconciler_buildNodeAgentDaemonsetHostPID(t *testing.T) {
+	tests := []struct {
+		name        string
+		nodeAgent   *oadpv1alpha1.NodeAgentConfig
+		restic      *oadpv1alpha1.ResticConfig
+		wantHostPID bool
+	}{
+		{
+			name:        "node agent hostPID enabled",
+			nodeAgent:   &oadpv1alpha1.NodeAgentConfig{NodeAgentCommonFields: oadpv1alpha1.NodeAgentCommonFields{HostPID: pointer.Bool(true)}},
+			wantHostPID: true,
+		},
+		{
+			name:      "node agent hostPID disabled",
+			nodeAgent: &oadpv1alpha1.NodeAgentConfig{NodeAgentCommonFields: oadpv1alpha1.NodeAgentCommonFields{HostPID: pointer.Bool(false)}},
+		},
+		{
+			name:      "node agent hostPID not set",
+			nodeAgent: &oadpv1alpha1.NodeAgentConfig{},
+		},
+		{
+			name:        "restic hostPID enabled",
+			restic:      &oadpv1alpha1.ResticConfig{NodeAgentCommonFields: oadpv1alpha1.NodeAgentCommonFields{HostPID: pointer.Bool(true)}},
+			wantHostPID: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &DPAReconciler{}
+			dpa := &oadpv1alpha1.DataProtectionApplication{
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero:    &oadpv1alpha1.VeleroConfig{},
+						NodeAgent: tt.nodeAgent,
+						Restic:    tt.restic,
+					},
+				},
+			}
+			// a previously enabled hostPID is cleared when unset
+			ds := &appsv1.DaemonSet{ObjectMeta: getNodeAgentObjectMeta(r)}
+			ds.Spec.Template.Spec.HostPID = true
+			got, err := r.buildNodeAgentDaemonset(dpa, ds)
+			if err != nil {
+				t.Errorf("buildNodeAgentDaemonset() unexpected error = %v", err)
+				return
+			}
+			if got.Spec.Template.Spec.HostPID != tt.wantHostPID {
+				t.Errorf("buildNodeAgentDaemonset() hostPID = %v, want %v", got.Spec.Template.Spec.HostPID, tt.wantHostPID)
+			}
+		})
+	}
+}
+
+func TestDPAReconciler_warnNodeAgentHostPID(t *testing.T) {
+	tests := []struct {
+		name       string
+		nodeAgent  *oadpv1alpha1.NodeAgentConfig
+		wantEvents int
+	}{
+		{
+			name: "hostPID enabled",
+			nodeAgent: &oadpv1alpha1.NodeAgentConfig{
+				NodeAgentCommonFields: oadpv1alpha1.NodeAgentCommonFields{Enable: pointer.Bool(true), HostPID: pointer.Bool(true)},
+			},
+			wantEvents: 1,
+		},
+		{
+			name: "hostPID enabled with the node agent disabled",
+			nodeAgent: &oadpv1alpha1.NodeAgentConfig{
+				NodeAgentCommonFields: oadpv1alpha1.NodeAgentCommonFields{Enable: pointer.Bool(false), HostPID: pointer.Bool(true)},
+			},
+		},
+		{
+			name: "hostPID not set",
+			nodeAgent: &oadpv1alpha1.NodeAgentConfig{
+				NodeAgentCommonFields: oadpv1alpha1.NodeAgentCommonFields{Enable: pointer.Bool(true)},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := record.NewFakeRecorder(10)
+			r := &DPAReconciler{EventRecorder: recorder}
+			dpa := &oadpv1alpha1.DataProtectionApplication{
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero:    &oadpv1alpha1.VeleroConfig{},
+						NodeAgent: tt.nodeAgent,
+					},
+				},
+			}
+			r.warnNodeAgentHostPID(logr.Discard(), dpa)
+			if len(recorder.Events) != tt.wantEvents {
+				t.Errorf("warnNodeAgentHostPID() emitted %d events, want %d", len(recorder.Events), tt.wantEvents)
+			}
+		})
+	}
+}
+
 func TestDPAReconciler_updateFsRestoreHelperCM(t *testing.T) {
 
 	tests := []struct {
@@ -2557,3 +2811,323 @@ func TestDPAReconciler_updateFsRestoreHelperCM(t *testing.T) {
 		})
 	}
 }
+
+func TestDPAReconciler_warnConflictingNodeAgents(t *testing.T) {
+	hostPathDaemonSet := func(namespace, name, hostPath string) *appsv1.DaemonSet {
+		return &appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: appsv1.DaemonSetSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Volumes: []corev1.Volume{
+							{
+								Name:         "host",
+								VolumeSource: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{Path: hostPath}},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+	tests := []struct {
+		name             string
+		nodeAgentEnabled bool
+		daemonSets       []client.Object
+		wantMessages     []string
+	}{
+		{
+			name:             "storage agent mounting the host pods path",
+			nodeAgentEnabled: true,
+			daemonSets: []client.Object{
+				hostPathDaemonSet("test-ns", common.NodeAgent, "/var/lib/kubelet/pods"),
+				hostPathDaemonSet("storage-system", "storage-agent", "/var/lib/kubelet/pods/"),
+			},
+			wantMessages: []string{
+				"DaemonSet storage-system/storage-agent also mounts the host pods path /var/lib/kubelet/pods used by the node agent, running another agent on the pod volumes of the same nodes can cause file system backups and restores to fail",
+			},
+		},
+		{
+			name:             "csi driver mounting the kubelet directory",
+			nodeAgentEnabled: true,
+			daemonSets: []client.Object{
+				hostPathDaemonSet("test-ns", common.NodeAgent, "/var/lib/kubelet/pods"),
+				hostPathDaemonSet("csi-driver", "csi-node", "/var/lib/kubelet"),
+			},
+			wantMessages: []string{},
+		},
+		{
+			name: "storage agent mounting the host pods path with node agent disabled",
+			daemonSets: []client.Object{
+				hostPathDaemonSet("storage-system", "storage-agent", "/var/lib/kubelet/pods"),
+			},
+			wantMessages: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dpa := &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-DPA-CR", Namespace: "test-ns"},
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						NodeAgent: &oadpv1alpha1.NodeAgentConfig{
+							NodeAgentCommonFields: oadpv1alpha1.NodeAgentCommonFields{Enable: pointer.Bool(tt.nodeAgentEnabled)},
+						},
+					},
+				},
+			}
+			fakeClient, err := getFakeClientFromObjects(append(tt.daemonSets, dpa)...)
+			if err != nil {
+				t.Errorf("error in creating fake client, likely programmer error")
+			}
+			recorder := record.NewFakeRecorder(10)
+			r := &DPAReconciler{
+				Client:        fakeClient,
+				APIReader:     fakeClient,
+				Context:       newContextForTest(tt.name),
+				EventRecorder: recorder,
+			}
+			r.warnConflictingNodeAgents(logr.Discard(), dpa)
+			if len(recorder.Events) != len(tt.wantMessages) {
+				t.Errorf("warnConflictingNodeAgents() emitted %d events, want %d", len(recorder.Events), len(tt.wantMessages))
+				return
+			}
+			for _, msg := range tt.wantMessages {
+				if event := <-recorder.Events; event != "Warning ConflictingNodeAgent "+msg {
+					t.Errorf("warnConflictingNodeAgents() event = %s, want %s", event, msg)
+				}
+			}
+			// the DaemonSets are only checked once per DPA generation
+			if err := fakeClient.Create(r.Context, hostPathDaemonSet("backup-system", "backup-agent", "/var/lib/kubelet/pods")); err != nil {
+				t.Errorf("unable to create DaemonSet: %v", err)
+				return
+			}
+			r.warnConflictingNodeAgents(logr.Discard(), dpa)
+			if len(recorder.Events) != 0 {
+				t.Errorf("warnConflictingNodeAgents() emitted %d events for an already checked generation, want 0", len(recorder.Events))
+			}
+		})
+	}
+}
+
+func TestDPAReconciler_validateFsBackupLocation(t *testing.T) {
+	tests := []struct {
+		name                     string
+		nodeAgentEnabled         bool
+		noDefaultBackupLocation  bool
+		defaultVolumesToFSBackup bool
+		objects                  []client.Object
+		wantErrMessage           string
+	}{
+		{
+			name:                     "node agent and file system backup without backup storage location",
+			nodeAgentEnabled:         true,
+			noDefaultBackupLocation:  true,
+			defaultVolumesToFSBackup: true,
+			wantErrMessage:           "defaultVolumesToFSBackup requires a backup storage location to store volume data, create a BackupStorageLocation in namespace test-ns or unset noDefaultBackupLocation",
+		},
+		{
+			name:                     "node agent and file system backup with user created backup storage location",
+			nodeAgentEnabled:         true,
+			noDefaultBackupLocation:  true,
+			defaultVolumesToFSBackup: true,
+			objects: []client.Object{
+				&velerov1.BackupStorageLocation{ObjectMeta: metav1.ObjectMeta{Name: "user-bsl", Namespace: "test-ns"}},
+			},
+		},
+		{
+			name:                    "node agent without file system backup by default",
+			nodeAgentEnabled:        true,
+			noDefaultBackupLocation: true,
+		},
+		{
+			name:                     "file system backup with default backup location",
+			nodeAgentEnabled:         true,
+			defaultVolumesToFSBackup: true,
+		},
+		{
+			name:                     "file system backup without node agent",
+			noDefaultBackupLocation:  true,
+			defaultVolumesToFSBackup: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dpa := &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-DPA-CR", Namespace: "test-ns"},
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{
+							NoDefaultBackupLocation:  tt.noDefaultBackupLocation,
+							DefaultVolumesToFSBackup: pointer.Bool(tt.defaultVolumesToFSBackup),
+						},
+						NodeAgent: &oadpv1alpha1.NodeAgentConfig{
+							NodeAgentCommonFields: oadpv1alpha1.NodeAgentCommonFields{Enable: pointer.Bool(tt.nodeAgentEnabled)},
+						},
+					},
+				},
+			}
+			fakeClient, err := getFakeClientFromObjects(append(tt.objects, dpa)...)
+			if err != nil {
+				t.Errorf("error in creating fake client, likely programmer error")
+			}
+			r := &DPAReconciler{
+				Client:  fakeClient,
+				Scheme:  fakeClient.Scheme(),
+				Log:     logr.Discard(),
+				Context: newContextForTest(tt.name),
+			}
+			err = r.validateFsBackupLocation(dpa)
+			if tt.wantErrMessage == "" {
+				if err != nil {
+					t.Errorf("validateFsBackupLocation() unexpected error = %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErrMessage {
+				t.Errorf("validateFsBackupLocation() error = %v, want %v", err, tt.wantErrMessage)
+			}
+		})
+	}
+}
+
+func TestDPAReconciler_validateDataMoverBackupLocation(t *testing.T) {
+	tests := []struct {
+		name                    string
+		noDefaultBackupLocation bool
+		defaultSnapshotMoveData *bool
+		objects                 []client.Object
+		wantErrMessage          string
+	}{
+		{
+			name:                    "snapshot data movement without backup storage location",
+			noDefaultBackupLocation: true,
+			defaultSnapshotMoveData: pointer.Bool(true),
+			wantErrMessage:          "noDefaultBackupLocation cannot be used when snapshotMoveData/data-mover is enabled because moved data requires a backup storage location",
+		},
+		{
+			name:                    "snapshot data movement with user created backup storage location",
+			noDefaultBackupLocation: true,
+			defaultSnapshotMoveData: pointer.Bool(true),
+			objects: []client.Object{
+				&velerov1.BackupStorageLocation{ObjectMeta: metav1.ObjectMeta{Name: "user-bsl", Namespace: "test-ns"}},
+			},
+		},
+		{
+			name:                    "snapshot data movement disabled",
+			noDefaultBackupLocation: true,
+			defaultSnapshotMoveData: pointer.Bool(false),
+		},
+		{
+			name:                    "snapshot data movement not set",
+			noDefaultBackupLocation: true,
+		},
+		{
+			name:                    "snapshot data movement with default backup location",
+			defaultSnapshotMoveData: pointer.Bool(true),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dpa := &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-DPA-CR", Namespace: "test-ns"},
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{
+							NoDefaultBackupLocation: tt.noDefaultBackupLocation,
+							DefaultSnapshotMoveData: tt.defaultSnapshotMoveData,
+						},
+						NodeAgent: &oadpv1alpha1.NodeAgentConfig{
+							NodeAgentCommonFields: oadpv1alpha1.NodeAgentCommonFields{Enable: pointer.Bool(true)},
+						},
+					},
+				},
+			}
+			fakeClient, err := getFakeClientFromObjects(append(tt.objects, dpa)...)
+			if err != nil {
+				t.Errorf("error in creating fake client, likely programmer error")
+			}
+			r := &DPAReconciler{
+				Client:  fakeClient,
+				Scheme:  fakeClient.Scheme(),
+				Log:     logr.Discard(),
+				Context: newContextForTest(tt.name),
+			}
+			err = r.validateDataMoverBackupLocation(dpa)
+			if tt.wantErrMessage == "" {
+				if err != nil {
+					t.Errorf("validateDataMoverBackupLocation() unexpected error = %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErrMessage {
+				t.Errorf("validateDataMoverBackupLocation() error = %v, want %v", err, tt.wantErrMessage)
+			}
+		})
+	}
+}
+
+func Test_validateNodeAgentTimeout(t *testing.T) {
+	tests := []struct {
+		name           string
+		restic         *oadpv1alpha1.ResticConfig
+		nodeAgent      *oadpv1alpha1.NodeAgentConfig
+		wantErrMessage string
+	}{
+		{
+			name:      "timeout not set",
+			nodeAgent: &oadpv1alpha1.NodeAgentConfig{},
+		},
+		{
+			name:      "node agent timeout 30s",
+			nodeAgent: &oadpv1alpha1.NodeAgentConfig{NodeAgentCommonFields: oadpv1alpha1.NodeAgentCommonFields{Timeout: "30s"}},
+		},
+		{
+			name:           "node agent timeout 0",
+			nodeAgent:      &oadpv1alpha1.NodeAgentConfig{NodeAgentCommonFields: oadpv1alpha1.NodeAgentCommonFields{Timeout: "0"}},
+			wantErrMessage: "nodeAgent.timeout \"0\" must be greater than zero",
+		},
+		{
+			name:           "node agent timeout -5m",
+			nodeAgent:      &oadpv1alpha1.NodeAgentConfig{NodeAgentCommonFields: oadpv1alpha1.NodeAgentCommonFields{Timeout: "-5m"}},
+			wantErrMessage: "nodeAgent.timeout \"-5m\" must be greater than zero",
+		},
+		{
+			name:           "node agent timeout abc",
+			nodeAgent:      &oadpv1alpha1.NodeAgentConfig{NodeAgentCommonFields: oadpv1alpha1.NodeAgentCommonFields{Timeout: "abc"}},
+			wantErrMessage: "nodeAgent.timeout \"abc\" is not a valid duration: time: invalid duration \"abc\"",
+		},
+		{
+			name:           "restic timeout without unit",
+			restic:         &oadpv1alpha1.ResticConfig{NodeAgentCommonFields: oadpv1alpha1.NodeAgentCommonFields{Timeout: "1"}},
+			wantErrMessage: "restic.timeout \"1\" is not a valid duration: time: missing unit in duration \"1\"",
+		},
+		{
+			name:   "restic timeout 30s",
+			restic: &oadpv1alpha1.ResticConfig{NodeAgentCommonFields: oadpv1alpha1.NodeAgentCommonFields{Timeout: "30s"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dpa := &oadpv1alpha1.DataProtectionApplication{
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero:    &oadpv1alpha1.VeleroConfig{},
+						Restic:    tt.restic,
+						NodeAgent: tt.nodeAgent,
+					},
+				},
+			}
+			err := validateNodeAgentTimeout(dpa)
+			if tt.wantErrMessage == "" {
+				if err != nil {
+					t.Errorf("validateNodeAgentTimeout() unexpected error = %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErrMessage {
+				t.Errorf("validateNodeAgentTimeout() error = %v, want %v", err, tt.wantErrMessage)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package controllers
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	k8serror "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	oadpv1alpha1 "github.com/openshift/oadp-operator/api/v1alpha1"
+	"github.com/openshift/oadp-operator/pkg/common"
+)
+
+// default minAvailable of the velero PodDisruptionBudget
+var defaultVeleroPDBMinAvailable = intstr.FromInt(1)
+
+func (r *DPAReconciler) ReconcileVeleroPodDisruptionBudget(log logr.Logger) (bool, error) {
+	dpa := oadpv1alpha1.DataProtectionApplication{}
+	if err := r.Get(r.Context, r.NamespacedName, &dpa); err != nil {
+		return false, err
+	}
+
+	pdb := &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      common.Velero,
+			Namespace: r.NamespacedName.Namespace,
+		},
+	}
+
+	// Delete (possible) previously created PodDisruptionBudget
+	if dpa.Spec.Configuration == nil || dpa.Spec.Configuration.Velero == nil || dpa.Spec.Configuration.Velero.PodDisruptionBudget == nil {
+		if err := r.Get(r.Context, types.NamespacedName{Name: pdb.Name, Namespace: pdb.Namespace}, pdb); err != nil {
+			if k8serror.IsNotFound(err) {
+				return true, nil
+			}
+			return false, err
+		}
+		if err := r.Delete(r.Context, pdb); err != nil {
+			return false, err
+		}
+		r.EventRecorder.Event(pdb,
+			corev1.EventTypeNormal,
+			"VeleroPodDisruptionBudgetDeleted",
+			fmt.Sprintf("velero pod disruption budget %s/%s deleted", pdb.Namespace, pdb.Name),
+		)
+		return true, nil
+	}
+
+	op, err := controllerutil.CreateOrUpdate(r.Context, r.Client, pdb, func() error {
+		r.buildVeleroPodDisruptionBudget(pdb, &dpa)
+
+		// Setting controller owner reference on the velero pod disruption budget
+		return controllerutil.SetControllerReference(&dpa, pdb, r.Scheme)
+	})
+	if err != nil {
+		return false, err
+	}
+
+	if op == controllerutil.OperationResultCreated || op == controllerutil.OperationResultUpdated {
+		// Trigger event to indicate velero pod disruption budget was created or updated
+		r.EventRecorder.Event(pdb,
+			corev1.EventTypeNormal,
+			"VeleroPodDisruptionBudgetReconciled",
+			fmt.Sprintf("performed %s on velero pod disruption budget %s/%s", op, pdb.Namespace, pdb.Name),
+		)
+	}
+	return true, nil
+}
+
+func (r *DPAReconciler) buildVeleroPodDisruptionBudget(pdb *policyv1.PodDisruptionBudget, dpa *oadpv1alpha1.DataProtectionApplication) {
+	minAvailable := defaultVeleroPDBMinAvailable
+	if dpa.Spec.Configuration.Velero.PodDisruptionBudget.MinAvailable != nil {
+		minAvailable = *dpa.Spec.Configuration.Velero.PodDisruptionBudget.MinAvailable
+	}
+	pdb.Labels = getDpaAppLabels(dpa)
+	pdb.Spec.MinAvailable = &minAvailable
+	// deploy label is only set on velero pods, so node agent pods are not selected
+	pdb.Spec.Selector = &metav1.LabelSelector{
+		MatchLabels: common.AppendTTMapAsCopy(getDpaAppLabels(dpa), map[string]string{"deploy": common.Velero}),
+	}
+}
+
+// validateVeleroPodDisruptionBudget checks minAvailable of the velero PodDisruptionBudget
+// can be satisfied by the velero replica count
+func validateVeleroPodDisruptionBudget(dpa *oadpv1alpha1.DataProtectionApplication) error {
+	if dpa.Spec.Configuration == nil || dpa.Spec.Configuration.Velero == nil ||
+		dpa.Spec.Configuration.Velero.PodDisruptionBudget == nil || dpa.Spec.Configuration.Velero.PodDisruptionBudget.MinAvailable == nil {
+		return nil
+	}
+	minAvailable := dpa.Spec.Configuration.Velero.PodDisruptionBudget.MinAvailable
+	if minAvailable.Type == intstr.String {
+		if !strings.HasSuffix(minAvailable.StrVal, "%") {
+			return fmt.Errorf("velero podDisruptionBudget minAvailable %q must be an integer or a percentage", minAvailable.StrVal)
+		}
+		percent, err := strconv.Atoi(strings.TrimSuffix(minAvailable.StrVal, "%"))
+		if err != nil || percent < 0 || percent > 100 {
+			return fmt.Errorf("velero podDisruptionBudget minAvailable %q must be a percentage between 0%% and 100%%", minAvailable.StrVal)
+		}
+		return nil
+	}
+	if replicas := getVeleroReplicas(); minAvailable.IntVal < 0 || minAvailable.IntVal > replicas {
+		return fmt.Errorf("velero podDisruptionBudget minAvailable %d must be between 0 and the velero replica count %d", minAvailable.IntVal, replicas)
+	}
+	return nil
+}
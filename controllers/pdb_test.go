@@ -0,0 +1,179 @@
+package controllers
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/go-logr/logr"
+	policyv1 "k8s.io/api/policy/v1"
+	k8serror "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	oadpv1alpha1 "github.com/openshift/oadp-operator/api/v1alpha1"
+	"github.com/openshift/oadp-operator/pkg/common"
+)
+
+func TestDPAReconciler_ReconcileVeleroPodDisruptionBudget(t *testing.T) {
+	minAvailablePercent := intstr.FromString("50%")
+	tests := []struct {
+		name             string
+		pdbConfig        *oadpv1alpha1.PodDisruptionBudgetConfig
+		objects          []client.Object
+		wantPDB          bool
+		wantMinAvailable intstr.IntOrString
+	}{
+		{
+			name:             "podDisruptionBudget set with default minAvailable",
+			pdbConfig:        &oadpv1alpha1.PodDisruptionBudgetConfig{},
+			wantPDB:          true,
+			wantMinAvailable: intstr.FromInt(1),
+		},
+		{
+			name:             "podDisruptionBudget set with percentage minAvailable",
+			pdbConfig:        &oadpv1alpha1.PodDisruptionBudgetConfig{MinAvailable: &minAvailablePercent},
+			wantPDB:          true,
+			wantMinAvailable: minAvailablePercent,
+		},
+		{
+			name: "podDisruptionBudget unset deletes existing pod disruption budget",
+			objects: []client.Object{
+				&policyv1.PodDisruptionBudget{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      common.Velero,
+						Namespace: "test-ns",
+					},
+				},
+			},
+			wantPDB: false,
+		},
+		{
+			name:    "podDisruptionBudget unset",
+			wantPDB: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dpa := &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-DPA-CR",
+					Namespace: "test-ns",
+				},
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{
+							PodDisruptionBudget: tt.pdbConfig,
+						},
+					},
+				},
+			}
+			fakeClient, err := getFakeClientFromObjects(append(tt.objects, dpa)...)
+			if err != nil {
+				t.Errorf("error in creating fake client, likely programmer error")
+			}
+			r := &DPAReconciler{
+				Client:  fakeClient,
+				Scheme:  fakeClient.Scheme(),
+				Log:     logr.Discard(),
+				Context: newContextForTest(tt.name),
+				NamespacedName: types.NamespacedName{
+					Namespace: dpa.Namespace,
+					Name:      dpa.Name,
+				},
+				EventRecorder: record.NewFakeRecorder(10),
+			}
+			if _, err := r.ReconcileVeleroPodDisruptionBudget(r.Log); err != nil {
+				t.Errorf("ReconcileVeleroPodDisruptionBudget() unexpected error = %v", err)
+				return
+			}
+			pdb := &policyv1.PodDisruptionBudget{}
+			err = r.Get(r.Context, types.NamespacedName{Name: common.Velero, Namespace: dpa.Namespace}, pdb)
+			if !tt.wantPDB {
+				if !k8serror.IsNotFound(err) {
+					t.Errorf("ReconcileVeleroPodDisruptionBudget() expected no pod disruption budget, got error = %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("ReconcileVeleroPodDisruptionBudget() expected pod disruption budget, got error = %v", err)
+				return
+			}
+			if !reflect.DeepEqual(*pdb.Spec.MinAvailable, tt.wantMinAvailable) {
+				t.Errorf("ReconcileVeleroPodDisruptionBudget() minAvailable = %v, want %v", pdb.Spec.MinAvailable, tt.wantMinAvailable)
+			}
+			wantSelector := common.AppendTTMapAsCopy(getDpaAppLabels(dpa), map[string]string{"deploy": common.Velero})
+			if !reflect.DeepEqual(pdb.Spec.Selector.MatchLabels, wantSelector) {
+				t.Errorf("ReconcileVeleroPodDisruptionBudget() selector = %v, want %v", pdb.Spec.Selector.MatchLabels, wantSelector)
+			}
+		})
+	}
+}
+
+func Test_validateVeleroPodDisruptionBudget(t *testing.T) {
+	tests := []struct {
+		name           string
+		minAvailable   intstr.IntOrString
+		replicas       string
+		wantErrMessage string
+	}{
+		{
+			name:         "minAvailable equal to replica count",
+			minAvailable: intstr.FromInt(1),
+		},
+		{
+			name:           "minAvailable above replica count",
+			minAvailable:   intstr.FromInt(2),
+			wantErrMessage: "velero podDisruptionBudget minAvailable 2 must be between 0 and the velero replica count 1",
+		},
+		{
+			name:         "minAvailable within overridden replica count",
+			minAvailable: intstr.FromInt(2),
+			replicas:     "3",
+		},
+		{
+			name:         "minAvailable percentage",
+			minAvailable: intstr.FromString("50%"),
+		},
+		{
+			name:           "minAvailable percentage above 100%",
+			minAvailable:   intstr.FromString("150%"),
+			wantErrMessage: "velero podDisruptionBudget minAvailable \"150%\" must be a percentage between 0% and 100%",
+		},
+		{
+			name:           "minAvailable invalid string",
+			minAvailable:   intstr.FromString("half"),
+			wantErrMessage: "velero podDisruptionBudget minAvailable \"half\" must be an integer or a percentage",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.replicas != "" {
+				t.Setenv(VeleroReplicaOverride, tt.replicas)
+			}
+			dpa := &oadpv1alpha1.DataProtectionApplication{
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{
+							PodDisruptionBudget: &oadpv1alpha1.PodDisruptionBudgetConfig{
+								MinAvailable: &tt.minAvailable,
+							},
+						},
+					},
+				},
+			}
+			err := validateVeleroPodDisruptionBudget(dpa)
+			if tt.wantErrMessage == "" {
+				if err != nil {
+					t.Errorf("validateVeleroPodDisruptionBudget() unexpected error = %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErrMessage {
+				t.Errorf("validateVeleroPodDisruptionBudget() error = %v, want %v", err, tt.wantErrMessage)
+			}
+		})
+	}
+}
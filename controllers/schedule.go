@@ -0,0 +1,124 @@
+package controllers
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	velerov1 "github.com/vmware-tanzu/velero/pkg/apis/velero/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	oadpv1alpha1 "github.com/openshift/oadp-operator/api/v1alpha1"
+)
+
+const (
+	// schedules annotated with this key were paused by the operator for the backup maintenance window
+	oadpMaintenanceWindowPausedAnnotation = "oadp.openshift.io/paused-by-maintenance-window"
+	maintenanceWindowStartLayout          = "15:04"
+	// schedules created during the window are paused at this interval
+	maintenanceWindowRequeueInterval = time.Minute
+)
+
+// validateBackupMaintenanceWindow checks the start and duration of the backup maintenance window
+func validateBackupMaintenanceWindow(dpa *oadpv1alpha1.DataProtectionApplication) error {
+	window := dpa.Spec.BackupMaintenanceWindow
+	if window == nil {
+		return nil
+	}
+	if _, err := time.Parse(maintenanceWindowStartLayout, window.Start); err != nil {
+		return fmt.Errorf("backupMaintenanceWindow start %q must be a time of day in HH:MM format", window.Start)
+	}
+	if window.Duration.Duration <= 0 || window.Duration.Duration >= 24*time.Hour {
+		return fmt.Errorf("backupMaintenanceWindow duration %s must be greater than 0 and less than 24h", window.Duration.Duration)
+	}
+	return nil
+}
+
+// maintenanceWindowState returns whether now is within the backup maintenance window, and the time until
+// the window starts or ends
+func maintenanceWindowState(window *oadpv1alpha1.BackupMaintenanceWindow, now time.Time) (bool, time.Duration) {
+	start, _ := time.Parse(maintenanceWindowStartLayout, window.Start)
+	now = now.UTC()
+	todayStart := time.Date(now.Year(), now.Month(), now.Day(), start.Hour(), start.Minute(), 0, 0, time.UTC)
+	// a window started yesterday may not have ended yet
+	for _, windowStart := range []time.Time{todayStart.AddDate(0, 0, -1), todayStart} {
+		windowEnd := windowStart.Add(window.Duration.Duration)
+		if !now.Before(windowStart) && now.Before(windowEnd) {
+			return true, windowEnd.Sub(now)
+		}
+	}
+	if now.Before(todayStart) {
+		return false, todayStart.Sub(now)
+	}
+	return false, todayStart.AddDate(0, 0, 1).Sub(now)
+}
+
+// maintenanceWindowRequeueAfter returns when the DPA needs to be reconciled again for its backup maintenance
+// window, or 0 if no window is configured
+func maintenanceWindowRequeueAfter(dpa *oadpv1alpha1.DataProtectionApplication, now time.Time) time.Duration {
+	if dpa.Spec.BackupMaintenanceWindow == nil || validateBackupMaintenanceWindow(dpa) != nil {
+		return 0
+	}
+	active, untilChange := maintenanceWindowState(dpa.Spec.BackupMaintenanceWindow, now)
+	if active && untilChange > maintenanceWindowRequeueInterval {
+		return maintenanceWindowRequeueInterval
+	}
+	return untilChange
+}
+
+func (r *DPAReconciler) ReconcileBackupMaintenanceWindow(log logr.Logger) (bool, error) {
+	dpa := oadpv1alpha1.DataProtectionApplication{}
+	if err := r.Get(r.Context, r.NamespacedName, &dpa); err != nil {
+		return false, err
+	}
+	return r.reconcileSchedulesForMaintenanceWindow(log, &dpa, time.Now())
+}
+
+// reconcileSchedulesForMaintenanceWindow pauses the unpaused schedules in the DPA namespace while the backup
+// maintenance window is active, and resumes the schedules it paused otherwise. Schedules are left as they are while
+// the window is invalid, which validation reports.
+func (r *DPAReconciler) reconcileSchedulesForMaintenanceWindow(log logr.Logger, dpa *oadpv1alpha1.DataProtectionApplication, now time.Time) (bool, error) {
+	if validateBackupMaintenanceWindow(dpa) != nil {
+		return true, nil
+	}
+	active := false
+	if dpa.Spec.BackupMaintenanceWindow != nil {
+		active, _ = maintenanceWindowState(dpa.Spec.BackupMaintenanceWindow, now)
+	}
+	schedules := velerov1.ScheduleList{}
+	if err := r.List(r.Context, &schedules, client.InNamespace(dpa.Namespace)); err != nil {
+		return false, err
+	}
+	for i := range schedules.Items {
+		schedule := &schedules.Items[i]
+		_, pausedByWindow := schedule.Annotations[oadpMaintenanceWindowPausedAnnotation]
+		switch {
+		case active && !schedule.Spec.Paused:
+			if schedule.Annotations == nil {
+				schedule.Annotations = map[string]string{}
+			}
+			schedule.Annotations[oadpMaintenanceWindowPausedAnnotation] = "true"
+			schedule.Spec.Paused = true
+		case !active && pausedByWindow:
+			delete(schedule.Annotations, oadpMaintenanceWindowPausedAnnotation)
+			schedule.Spec.Paused = false
+		default:
+			continue
+		}
+		if err := r.Update(r.Context, schedule); err != nil {
+			return false, err
+		}
+		action := "resumed"
+		if active {
+			action = "paused"
+		}
+		log.Info(fmt.Sprintf("schedule %s/%s %s for backup maintenance window", schedule.Namespace, schedule.Name, action))
+		r.EventRecorder.Event(dpa,
+			corev1.EventTypeNormal,
+			"BackupMaintenanceWindow",
+			fmt.Sprintf("schedule %s/%s %s for backup maintenance window", schedule.Namespace, schedule.Name, action),
+		)
+	}
+	return true, nil
+}
@@ -0,0 +1,209 @@
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	velerov1 "github.com/vmware-tanzu/velero/pkg/apis/velero/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+
+	oadpv1alpha1 "github.com/openshift/oadp-operator/api/v1alpha1"
+)
+
+func Test_validateBackupMaintenanceWindow(t *testing.T) {
+	tests := []struct {
+		name           string
+		window         *oadpv1alpha1.BackupMaintenanceWindow
+		wantErrMessage string
+	}{
+		{
+			name: "window not set",
+		},
+		{
+			name:   "valid window",
+			window: &oadpv1alpha1.BackupMaintenanceWindow{Start: "23:30", Duration: metav1.Duration{Duration: 2 * time.Hour}},
+		},
+		{
+			name:           "start out of range",
+			window:         &oadpv1alpha1.BackupMaintenanceWindow{Start: "25:00", Duration: metav1.Duration{Duration: time.Hour}},
+			wantErrMessage: "backupMaintenanceWindow start \"25:00\" must be a time of day in HH:MM format",
+		},
+		{
+			name:           "start with seconds",
+			window:         &oadpv1alpha1.BackupMaintenanceWindow{Start: "01:00:00", Duration: metav1.Duration{Duration: time.Hour}},
+			wantErrMessage: "backupMaintenanceWindow start \"01:00:00\" must be a time of day in HH:MM format",
+		},
+		{
+			name:           "zero duration",
+			window:         &oadpv1alpha1.BackupMaintenanceWindow{Start: "01:00"},
+			wantErrMessage: "backupMaintenanceWindow duration 0s must be greater than 0 and less than 24h",
+		},
+		{
+			name:           "whole day duration",
+			window:         &oadpv1alpha1.BackupMaintenanceWindow{Start: "01:00", Duration: metav1.Duration{Duration: 24 * time.Hour}},
+			wantErrMessage: "backupMaintenanceWindow duration 24h0m0s must be greater than 0 and less than 24h",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dpa := &oadpv1alpha1.DataProtectionApplication{
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{BackupMaintenanceWindow: tt.window},
+			}
+			err := validateBackupMaintenanceWindow(dpa)
+			if tt.wantErrMessage == "" {
+				if err != nil {
+					t.Errorf("validateBackupMaintenanceWindow() unexpected error = %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErrMessage {
+				t.Errorf("validateBackupMaintenanceWindow() error = %v, want %v", err, tt.wantErrMessage)
+			}
+		})
+	}
+}
+
+func Test_maintenanceWindowState(t *testing.T) {
+	nightly := &oadpv1alpha1.BackupMaintenanceWindow{Start: "23:00", Duration: metav1.Duration{Duration: 3 * time.Hour}}
+	tests := []struct {
+		name            string
+		now             time.Time
+		wantActive      bool
+		wantUntilChange time.Duration
+	}{
+		{
+			name:            "before the window",
+			now:             time.Date(2024, 1, 1, 22, 0, 0, 0, time.UTC),
+			wantUntilChange: time.Hour,
+		},
+		{
+			name:            "at the start of the window",
+			now:             time.Date(2024, 1, 1, 23, 0, 0, 0, time.UTC),
+			wantActive:      true,
+			wantUntilChange: 3 * time.Hour,
+		},
+		{
+			name:            "in the window after midnight",
+			now:             time.Date(2024, 1, 2, 1, 30, 0, 0, time.UTC),
+			wantActive:      true,
+			wantUntilChange: 30 * time.Minute,
+		},
+		{
+			name:            "at the end of the window",
+			now:             time.Date(2024, 1, 2, 2, 0, 0, 0, time.UTC),
+			wantUntilChange: 21 * time.Hour,
+		},
+		{
+			name:            "in the window in another time zone",
+			now:             time.Date(2024, 1, 2, 3, 0, 0, 0, time.FixedZone("UTC+2", 2*60*60)),
+			wantActive:      true,
+			wantUntilChange: time.Hour,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			active, untilChange := maintenanceWindowState(nightly, tt.now)
+			if active != tt.wantActive || untilChange != tt.wantUntilChange {
+				t.Errorf("maintenanceWindowState() = %v, %v, want %v, %v", active, untilChange, tt.wantActive, tt.wantUntilChange)
+			}
+		})
+	}
+}
+
+func TestDPAReconciler_reconcileSchedulesForMaintenanceWindow(t *testing.T) {
+	window := &oadpv1alpha1.BackupMaintenanceWindow{Start: "01:00", Duration: metav1.Duration{Duration: 2 * time.Hour}}
+	pausedByWindow := map[string]string{oadpMaintenanceWindowPausedAnnotation: "true"}
+	tests := []struct {
+		name       string
+		window     *oadpv1alpha1.BackupMaintenanceWindow
+		now        time.Time
+		schedule   *velerov1.Schedule
+		wantPaused bool
+		wantMarked bool
+	}{
+		{
+			name:       "active schedule is paused during the window",
+			window:     window,
+			now:        time.Date(2024, 1, 1, 2, 0, 0, 0, time.UTC),
+			schedule:   &velerov1.Schedule{},
+			wantPaused: true,
+			wantMarked: true,
+		},
+		{
+			name:       "schedule paused by the user is not marked during the window",
+			window:     window,
+			now:        time.Date(2024, 1, 1, 2, 0, 0, 0, time.UTC),
+			schedule:   &velerov1.Schedule{Spec: velerov1.ScheduleSpec{Paused: true}},
+			wantPaused: true,
+		},
+		{
+			name:     "active schedule is left active outside the window",
+			window:   window,
+			now:      time.Date(2024, 1, 1, 4, 0, 0, 0, time.UTC),
+			schedule: &velerov1.Schedule{},
+		},
+		{
+			name:     "schedule paused by the window is resumed after the window",
+			window:   window,
+			now:      time.Date(2024, 1, 1, 4, 0, 0, 0, time.UTC),
+			schedule: &velerov1.Schedule{ObjectMeta: metav1.ObjectMeta{Annotations: pausedByWindow}, Spec: velerov1.ScheduleSpec{Paused: true}},
+		},
+		{
+			name:       "schedule paused by the user is left paused after the window",
+			window:     window,
+			now:        time.Date(2024, 1, 1, 4, 0, 0, 0, time.UTC),
+			schedule:   &velerov1.Schedule{Spec: velerov1.ScheduleSpec{Paused: true}},
+			wantPaused: true,
+		},
+		{
+			name:       "schedule paused by the window is left paused while the window is invalid",
+			window:     &oadpv1alpha1.BackupMaintenanceWindow{Start: "25:00", Duration: metav1.Duration{Duration: 2 * time.Hour}},
+			now:        time.Date(2024, 1, 1, 4, 0, 0, 0, time.UTC),
+			schedule:   &velerov1.Schedule{ObjectMeta: metav1.ObjectMeta{Annotations: pausedByWindow}, Spec: velerov1.ScheduleSpec{Paused: true}},
+			wantPaused: true,
+			wantMarked: true,
+		},
+		{
+			name:     "schedule paused by the window is resumed when the window is removed",
+			now:      time.Date(2024, 1, 1, 2, 0, 0, 0, time.UTC),
+			schedule: &velerov1.Schedule{ObjectMeta: metav1.ObjectMeta{Annotations: pausedByWindow}, Spec: velerov1.ScheduleSpec{Paused: true}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dpa := &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-DPA-CR", Namespace: "test-ns"},
+				Spec:       oadpv1alpha1.DataProtectionApplicationSpec{BackupMaintenanceWindow: tt.window},
+			}
+			tt.schedule.Name = "test-schedule"
+			tt.schedule.Namespace = "test-ns"
+			fakeClient, err := getFakeClientFromObjects(dpa, tt.schedule)
+			if err != nil {
+				t.Errorf("error in creating fake client, likely programmer error")
+			}
+			r := &DPAReconciler{
+				Client:        fakeClient,
+				Scheme:        fakeClient.Scheme(),
+				Log:           logr.Discard(),
+				Context:       newContextForTest(tt.name),
+				EventRecorder: record.NewFakeRecorder(10),
+			}
+			if _, err := r.reconcileSchedulesForMaintenanceWindow(r.Log, dpa, tt.now); err != nil {
+				t.Errorf("reconcileSchedulesForMaintenanceWindow() unexpected error = %v", err)
+				return
+			}
+			got := &velerov1.Schedule{}
+			if err := r.Get(r.Context, types.NamespacedName{Name: "test-schedule", Namespace: "test-ns"}, got); err != nil {
+				t.Errorf("reconcileSchedulesForMaintenanceWindow() unable to get schedule: %v", err)
+				return
+			}
+			_, marked := got.Annotations[oadpMaintenanceWindowPausedAnnotation]
+			if got.Spec.Paused != tt.wantPaused || marked != tt.wantMarked {
+				t.Errorf("reconcileSchedulesForMaintenanceWindow() paused = %v, marked = %v, want paused = %v, marked = %v", got.Spec.Paused, marked, tt.wantPaused, tt.wantMarked)
+			}
+		})
+	}
+}
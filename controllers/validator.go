@@ -1,142 +1,1358 @@
 package controllers
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"os"
+	"sort"
+	"strconv"
 	"strings"
+	"unicode"
 
 	mapset "github.com/deckarep/golang-set/v2"
 	"github.com/go-logr/logr"
+	"github.com/vmware-tanzu/velero/pkg/util/boolptr"
+	corev1 "k8s.io/api/core/v1"
+	k8serror "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	oadpv1alpha1 "github.com/openshift/oadp-operator/api/v1alpha1"
+	"github.com/openshift/oadp-operator/pkg/common"
 	"github.com/openshift/oadp-operator/pkg/credentials"
 )
 
+const (
+	// above this many plugins an advisory is emitted
+	recommendedMaxPluginCount = 10
+	// plugins above this count are rejected unless the max-plugin-count override is set
+	defaultMaxPluginCount = 20
+	// keys with this prefix were only consumed by restic and are ignored by node agent
+	resticSecretKeyPrefix = "RESTIC_"
+)
+
 // ValidateDataProtectionCR function validates the DPA CR, returns true if valid, false otherwise
-// it calls other validation functions to validate the DPA CR
-// TODO: #1129 Clean up duplicate logic for validating backupstoragelocations and volumesnapshotlocations in dpa
+// and records the result as an event on the DPA
 func (r *DPAReconciler) ValidateDataProtectionCR(log logr.Logger) (bool, error) {
 	dpa := oadpv1alpha1.DataProtectionApplication{}
 	if err := r.Get(r.Context, r.NamespacedName, &dpa); err != nil {
 		return false, err
 	}
-	if dpa.Spec.Configuration == nil || dpa.Spec.Configuration.Velero == nil {
-		return false, errors.New("DPA CR Velero configuration cannot be nil")
+	err := r.validateDataProtectionCR(log, dpa)
+	r.recordValidationEvent(&dpa, err)
+	if err != nil {
+		return false, err
 	}
+	return true, nil
+}
 
-	if dpa.Spec.Configuration.Restic != nil && dpa.Spec.Configuration.NodeAgent != nil {
-		return false, errors.New("DPA CR cannot have restic (deprecated in OADP 1.3) as well as nodeAgent options at the same time")
+// validationOutcome is the validation result last recorded as an event for a DPA
+type validationOutcome struct {
+	generation int64
+	message    string
+}
+
+// recordValidationEvent records a ValidationFailed warning or a Validated event on the DPA, unless the same
+// result was already recorded for the DPA generation, so a reconcile loop does not repeat the event
+func (r *DPAReconciler) recordValidationEvent(dpa *oadpv1alpha1.DataProtectionApplication, err error) {
+	outcome := validationOutcome{generation: dpa.Generation}
+	if err != nil {
+		outcome.message = err.Error()
+	}
+	key := types.NamespacedName{Namespace: dpa.Namespace, Name: dpa.Name}
+	if last, found := r.validationOutcomes[key]; found && last == outcome {
+		return
+	}
+	if r.validationOutcomes == nil {
+		r.validationOutcomes = map[types.NamespacedName]validationOutcome{}
+	}
+	r.validationOutcomes[key] = outcome
+	if err != nil {
+		r.EventRecorder.Event(dpa, corev1.EventTypeWarning, "ValidationFailed", outcome.message)
+		return
 	}
+	r.EventRecorder.Event(dpa, corev1.EventTypeNormal, "Validated", oadpv1alpha1.ValidatedPassedMessage)
+}
 
-	if dpa.Spec.Configuration.Velero.NoDefaultBackupLocation {
-		if len(dpa.Spec.BackupLocations) != 0 {
-			return false, errors.New("DPA CR Velero configuration cannot have backup locations if noDefaultBackupLocation is set")
+// validateDataProtectionCR calls other validation functions to validate the DPA CR and returns the errors of all
+// of them joined, except for spec errors which the other validation functions rely on not being present
+// TODO: #1129 Clean up duplicate logic for validating backupstoragelocations and volumesnapshotlocations in dpa
+func (r *DPAReconciler) validateDataProtectionCR(log logr.Logger, dpa oadpv1alpha1.DataProtectionApplication) error {
+	if err := dpa.ValidateSpec(); err != nil {
+		return withValidationCode(oadpv1alpha1.ValidationErrorCodeInvalidSpec, err)
+	}
+
+	// the checks below do not depend on each other, so their errors are reported together
+	errs := []error{}
+	if err := validateBackupMaintenanceWindow(&dpa); err != nil {
+		errs = append(errs, withValidationCode(oadpv1alpha1.ValidationErrorCodeInvalidBackupMaintenanceWindow, err))
+	}
+
+	if err := validateBackupLocationNames(&dpa); err != nil {
+		errs = append(errs, withValidationCode(oadpv1alpha1.ValidationErrorCodeDuplicateBackupLocationName, err))
+	}
+
+	if _, err := r.ValidateBackupStorageLocations(dpa); err != nil {
+		errs = append(errs, withValidationCode(oadpv1alpha1.ValidationErrorCodeInvalidBackupStorageLocation, err))
+	}
+
+	if _, err := r.ValidateVolumeSnapshotLocations(dpa); err != nil {
+		errs = append(errs, withValidationCode(oadpv1alpha1.ValidationErrorCodeInvalidVolumeSnapshotLocation, err))
+	}
+
+	if _, err := r.ValidateVeleroPlugins(r.Log); err != nil {
+		errs = append(errs, withValidationCode(oadpv1alpha1.ValidationErrorCodeInvalidPluginCredential, err))
+	}
+
+	if err := r.validatePluginCount(log, &dpa); err != nil {
+		errs = append(errs, withValidationCode(oadpv1alpha1.ValidationErrorCodeTooManyPlugins, err))
+	}
+
+	if err := r.validatePluginRequiredAPIs(&dpa); err != nil {
+		errs = append(errs, withValidationCode(oadpv1alpha1.ValidationErrorCodePluginRequiredAPIUnavailable, err))
+	}
+
+	if err := validateCustomPluginOverlap(&dpa); err != nil {
+		errs = append(errs, withValidationCode(oadpv1alpha1.ValidationErrorCodeCustomPluginOverlap, err))
+	}
+
+	if err := validateVeleroImageOverride(&dpa); err != nil {
+		errs = append(errs, withValidationCode(oadpv1alpha1.ValidationErrorCodeInvalidImageOverride, err))
+	}
+
+	if err := validatePluginImages(&dpa); err != nil {
+		errs = append(errs, withValidationCode(oadpv1alpha1.ValidationErrorCodeInvalidPluginImage, err))
+	}
+
+	r.warnStaleResticSecretKeys(log, &dpa)
+
+	r.warnDualPurposeCredentialSecrets(log, &dpa)
+
+	r.warnCredentialTrailingWhitespace(log, &dpa)
+
+	r.warnMissingResourceRequests(log, &dpa)
+
+	r.warnRequestsExceedNodeAllocatable(log, &dpa)
+
+	r.warnExtendedResourceRequests(log, &dpa)
+
+	r.warnNodeAgentHostPID(log, &dpa)
+
+	r.warnConflictingNodeAgents(log, &dpa)
+
+	r.warnIgnoredFieldsForOperatorMode(log, &dpa)
+
+	r.warnSnapshotMoveRegionMismatch(log, &dpa)
+
+	r.warnSharedSnapshotLocationCredentials(log, &dpa)
+
+	r.warnMissingSnapshotLocation(log, &dpa)
+
+	r.warnPrefixTemplateTokens(log, &dpa)
+
+	r.warnSharedBackupImagePrefixes(log, &dpa)
+
+	r.warnImageBackupRequestLimits(log, &dpa)
+
+	r.warnDeprecatedProviderAliases(log, &dpa)
+
+	r.warnDivergentPluginImageTags(log, &dpa)
+
+	r.warnServiceMonitorsForLocalhostMetrics(log, &dpa)
+
+	if err := validateServiceAccountTokenAudience(&dpa); err != nil {
+		errs = append(errs, withValidationCode(oadpv1alpha1.ValidationErrorCodeInvalidServiceAccountToken, err))
+	}
+
+	if err := validateServiceAccountTokenExpiration(&dpa); err != nil {
+		errs = append(errs, withValidationCode(oadpv1alpha1.ValidationErrorCodeInvalidServiceAccountToken, err))
+	}
+
+	if err := validateClientPageSize(&dpa); err != nil {
+		errs = append(errs, withValidationCode(oadpv1alpha1.ValidationErrorCodeInvalidClientPageSize, err))
+	}
+
+	if err := validateVeleroSysctls(&dpa); err != nil {
+		errs = append(errs, withValidationCode(oadpv1alpha1.ValidationErrorCodeUnsafeSysctl, err))
+	}
+
+	if err := validateShareProcessNamespace(&dpa); err != nil {
+		errs = append(errs, withValidationCode(oadpv1alpha1.ValidationErrorCodeInvalidShareProcessNamespace, err))
+	}
+
+	if err := r.validatePluginConfigFiles(&dpa); err != nil {
+		errs = append(errs, withValidationCode(oadpv1alpha1.ValidationErrorCodeInvalidPluginConfigFile, err))
+	}
+
+	if err := validateRemovedFeatureFlags(&dpa); err != nil {
+		errs = append(errs, withValidationCode(oadpv1alpha1.ValidationErrorCodeRemovedFeatureFlag, err))
+	}
+
+	if err := validateNoSecretFeatureFlag(&dpa); err != nil {
+		errs = append(errs, withValidationCode(oadpv1alpha1.ValidationErrorCodeConflictingFeatureFlag, err))
+	}
+
+	if err := validateDefaultItemOperationTimeout(&dpa); err != nil {
+		errs = append(errs, withValidationCode(oadpv1alpha1.ValidationErrorCodeInvalidItemOperationTimeout, err))
+	}
+
+	if err := validateResourceTimeout(&dpa); err != nil {
+		errs = append(errs, withValidationCode(oadpv1alpha1.ValidationErrorCodeInvalidResourceTimeout, err))
+	}
+
+	if err := validateVeleroCommand(&dpa); err != nil {
+		errs = append(errs, withValidationCode(oadpv1alpha1.ValidationErrorCodeInvalidVeleroCommand, err))
+	}
+
+	if err := validateProfilerAddress(&dpa); err != nil {
+		errs = append(errs, withValidationCode(oadpv1alpha1.ValidationErrorCodeInvalidProfilerAddress, err))
+	}
+
+	if err := validateVeleroListenPorts(&dpa); err != nil {
+		errs = append(errs, withValidationCode(oadpv1alpha1.ValidationErrorCodeConflictingListenPorts, err))
+	}
+
+	if err := r.validateRestoreOnlyMode(&dpa); err != nil {
+		errs = append(errs, withValidationCode(oadpv1alpha1.ValidationErrorCodeInvalidRestoreOnlyMode, err))
+	}
+
+	if err := r.validateDisableGarbageCollection(&dpa); err != nil {
+		errs = append(errs, withValidationCode(oadpv1alpha1.ValidationErrorCodeInvalidGarbageCollection, err))
+	}
+
+	if err := validateNodeAgentMaxUnavailable(&dpa); err != nil {
+		errs = append(errs, withValidationCode(oadpv1alpha1.ValidationErrorCodeInvalidNodeAgentMaxUnavailable, err))
+	}
+
+	if err := validateDataMoverNodeAgent(&dpa); err != nil {
+		errs = append(errs, withValidationCode(oadpv1alpha1.ValidationErrorCodeDataMoverRequiresNodeAgent, err))
+	}
+
+	if err := r.validateFsBackupLocation(&dpa); err != nil {
+		errs = append(errs, withValidationCode(oadpv1alpha1.ValidationErrorCodeMissingBackupStorageLocation, err))
+	}
+
+	if err := r.validateDataMoverBackupLocation(&dpa); err != nil {
+		errs = append(errs, withValidationCode(oadpv1alpha1.ValidationErrorCodeMissingBackupStorageLocation, err))
+	}
+
+	if err := validateVeleroPodDisruptionBudget(&dpa); err != nil {
+		errs = append(errs, withValidationCode(oadpv1alpha1.ValidationErrorCodeInvalidPodDisruptionBudget, err))
+	}
+
+	if err := validateEgressNetworkPolicy(&dpa); err != nil {
+		errs = append(errs, withValidationCode(oadpv1alpha1.ValidationErrorCodeInvalidEgressNetworkPolicy, err))
+	}
+
+	if err := validatePodLabels(&dpa); err != nil {
+		errs = append(errs, withValidationCode(oadpv1alpha1.ValidationErrorCodeReservedPodLabel, err))
+	}
+
+	if err := validatePodAnnotations(&dpa); err != nil {
+		errs = append(errs, withValidationCode(oadpv1alpha1.ValidationErrorCodeInvalidPodAnnotation, err))
+	}
+
+	if err := validatePodScheduling(&dpa); err != nil {
+		errs = append(errs, withValidationCode(oadpv1alpha1.ValidationErrorCodeInvalidPodScheduling, err))
+	}
+
+	if _, err := r.getRestoreResourcePriorities(&dpa); err != nil {
+		errs = append(errs, withValidationCode(oadpv1alpha1.ValidationErrorCodeInvalidRestoreResourcePriorities, err))
+	}
+
+	if _, err := r.getVeleroResourceReqs(&dpa); err != nil {
+		errs = append(errs, withValidationCode(oadpv1alpha1.ValidationErrorCodeInvalidResourceAllocations, err))
+	}
+
+	if _, err := getResticResourceReqs(&dpa); err != nil {
+		errs = append(errs, withValidationCode(oadpv1alpha1.ValidationErrorCodeInvalidResourceAllocations, err))
+	}
+
+	if _, err := getNodeAgentResourceReqs(&dpa); err != nil {
+		errs = append(errs, withValidationCode(oadpv1alpha1.ValidationErrorCodeInvalidResourceAllocations, err))
+	}
+
+	if err := validateNodeAgentTimeout(&dpa); err != nil {
+		errs = append(errs, withValidationCode(oadpv1alpha1.ValidationErrorCodeInvalidNodeAgentTimeout, err))
+	}
+
+	if _, err := getPluginsVolumeSource(&dpa); err != nil {
+		errs = append(errs, withValidationCode(oadpv1alpha1.ValidationErrorCodeInvalidPluginsVolume, err))
+	}
+
+	if err := validateLogFile(&dpa); err != nil {
+		errs = append(errs, withValidationCode(oadpv1alpha1.ValidationErrorCodeInvalidLogFile, err))
+	}
+	return joinUniqueErrors(errs)
+}
+
+// codedValidationError is a validation failure of the check identified by code
+type codedValidationError struct {
+	code oadpv1alpha1.ValidationErrorCode
+	err  error
+}
+
+func (e *codedValidationError) Error() string {
+	return e.err.Error()
+}
+
+func (e *codedValidationError) Unwrap() error {
+	return e.err
+}
+
+func withValidationCode(code oadpv1alpha1.ValidationErrorCode, err error) error {
+	return &codedValidationError{code: code, err: err}
+}
+
+// retriableValidationError is a validation failure on objects that are not watched, such as schedules or CRDs,
+// which may resolve without a DPA change
+type retriableValidationError struct {
+	err error
+}
+
+func (e *retriableValidationError) Error() string {
+	return e.err.Error()
+}
+
+func (e *retriableValidationError) Unwrap() error {
+	return e.err
+}
+
+func retriable(err error) error {
+	return &retriableValidationError{err: err}
+}
+
+// isRetriableValidationFailure returns whether err holds a validation failure on a missing object or on an
+// object that is not watched
+func isRetriableValidationFailure(err error) bool {
+	var retriableErr *retriableValidationError
+	return k8serror.IsNotFound(err) || errors.As(err, &retriableErr)
+}
+
+// validationErrors returns the validation failures in err, which may be a joined error, for the DPA status.
+// Errors not returned by a validation check, such as a failed DPA get, are not listed
+func validationErrors(err error) []oadpv1alpha1.ValidationError {
+	if err == nil {
+		return nil
+	}
+	if coded, ok := err.(*codedValidationError); ok {
+		return []oadpv1alpha1.ValidationError{{Code: coded.code, Message: coded.Error()}}
+	}
+	joined, ok := err.(interface{ Unwrap() []error })
+	if !ok {
+		return nil
+	}
+	var failures []oadpv1alpha1.ValidationError
+	for _, err := range joined.Unwrap() {
+		failures = append(failures, validationErrors(err)...)
+	}
+	return failures
+}
+
+// joinUniqueErrors joins the errors, dropping errors with the same message as an earlier one, as checks sharing
+// a helper, such as the resource requirement parsing, report the same problem
+func joinUniqueErrors(errs []error) error {
+	unique := []error{}
+	seen := map[string]empty{}
+	for _, err := range errs {
+		if _, found := seen[err.Error()]; found {
+			continue
+		}
+		seen[err.Error()] = empty{}
+		unique = append(unique, err)
+	}
+	return errors.Join(unique...)
+}
+
+// validatePluginCount warns when the number of plugin init containers in the Velero pod is above
+// recommendedMaxPluginCount and returns an error when it is above the hard cap, which defaults to
+// defaultMaxPluginCount and can be changed with the max-plugin-count unsupported override
+func (r *DPAReconciler) validatePluginCount(log logr.Logger, dpa *oadpv1alpha1.DataProtectionApplication) error {
+	maxPluginCount := defaultMaxPluginCount
+	if val, found := dpa.Spec.UnsupportedOverrides[oadpv1alpha1.MaxPluginCountKey]; found {
+		parsed, err := strconv.Atoi(val)
+		if err != nil || parsed < 1 {
+			return fmt.Errorf("%s override must be a positive integer, got %q", oadpv1alpha1.MaxPluginCountKey, val)
+		}
+		maxPluginCount = parsed
+	}
+
+	pluginCount := len(dpa.Spec.Configuration.Velero.DefaultPlugins) + len(dpa.Spec.Configuration.Velero.CustomPlugins)
+	if pluginCount > maxPluginCount {
+		return fmt.Errorf("DPA CR configures %d plugins, which exceeds the maximum of %d", pluginCount, maxPluginCount)
+	}
+	if pluginCount > recommendedMaxPluginCount {
+		msg := fmt.Sprintf("DPA CR configures %d plugins, more than %d plugins slows down Velero pod startup", pluginCount, recommendedMaxPluginCount)
+		r.warnEvent(log, dpa, "ExcessivePluginCount", msg)
+	}
+	return nil
+}
+
+// a cluster API a default plugin requires, along with the cluster feature providing it
+type pluginRequiredAPI struct {
+	gvk     schema.GroupVersionKind
+	feature string
+}
+
+// default plugins which fail to start or have nothing to back up without a cluster API
+var pluginRequiredAPIs = map[oadpv1alpha1.DefaultPlugin]pluginRequiredAPI{
+	oadpv1alpha1.DefaultPluginCSI: {
+		gvk:     schema.GroupVersionKind{Group: "snapshot.storage.k8s.io", Version: "v1", Kind: "VolumeSnapshot"},
+		feature: "the CSI snapshot controller",
+	},
+	oadpv1alpha1.DefaultPluginKubeVirt: {
+		gvk:     schema.GroupVersionKind{Group: "kubevirt.io", Version: "v1", Kind: "VirtualMachine"},
+		feature: "OpenShift Virtualization",
+	},
+}
+
+// validatePluginRequiredAPIs rejects default plugins requiring a cluster API which is not served by the cluster
+func (r *DPAReconciler) validatePluginRequiredAPIs(dpa *oadpv1alpha1.DataProtectionApplication) error {
+	for _, plugin := range dpa.Spec.Configuration.Velero.DefaultPlugins {
+		requiredAPI, found := pluginRequiredAPIs[plugin]
+		if !found {
+			continue
 		}
-		if dpa.BackupImages() {
-			return false, errors.New("backupImages needs to be set to false when noDefaultBackupLocation is set")
+		if _, err := r.RESTMapper().RESTMapping(requiredAPI.gvk.GroupKind(), requiredAPI.gvk.Version); err != nil {
+			if apimeta.IsNoMatchError(err) {
+				return retriable(fmt.Errorf("%s plugin requires the %s %s API, which is not available in the cluster; enable %s or remove the plugin from defaultPlugins",
+					plugin, requiredAPI.gvk.GroupVersion(), requiredAPI.gvk.Kind, requiredAPI.feature))
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// validateCustomPluginOverlap rejects custom plugins that duplicate a default plugin, either by name
+// or by image repository, as both would be added as init containers of the Velero pod
+func validateCustomPluginOverlap(dpa *oadpv1alpha1.DataProtectionApplication) error {
+	for _, defaultPlugin := range dpa.Spec.Configuration.Velero.DefaultPlugins {
+		pluginSpecificMap, ok := credentials.PluginSpecificFields[defaultPlugin]
+		if !ok {
+			continue
+		}
+		for _, customPlugin := range dpa.Spec.Configuration.Velero.CustomPlugins {
+			if customPlugin.Name == string(defaultPlugin) ||
+				customPlugin.Name == pluginSpecificMap.PluginName ||
+				imageRepositoryName(customPlugin.Image) == pluginSpecificMap.PluginName {
+				return fmt.Errorf("custom plugin %s duplicates default plugin %s, remove it from either defaultPlugins or customPlugins", customPlugin.Name, defaultPlugin)
+			}
+		}
+	}
+	return nil
+}
+
+// validatePluginImages rejects default plugins without an image in the image set the operator was installed
+// with, as in disconnected installs only the bundled plugin images are mirrored. Plugins with an image override
+// are accepted, and the check is skipped when the operator was not installed with a plugin image set.
+func validatePluginImages(dpa *oadpv1alpha1.DataProtectionApplication) error {
+	hasImageSet := false
+	for _, fields := range credentials.PluginSpecificFields {
+		if fields.RelatedImageEnvVar != "" && os.Getenv(fields.RelatedImageEnvVar) != "" {
+			hasImageSet = true
+			break
+		}
+	}
+	if !hasImageSet {
+		return nil
+	}
+	unmirrored := []string{}
+	for _, plugin := range dpa.Spec.Configuration.Velero.DefaultPlugins {
+		fields, ok := credentials.PluginSpecificFields[plugin]
+		if !ok || fields.RelatedImageEnvVar == "" || os.Getenv(fields.RelatedImageEnvVar) != "" ||
+			dpa.Spec.UnsupportedOverrides[fields.ImageOverrideKey] != "" {
+			continue
+		}
+		unmirrored = append(unmirrored, fmt.Sprintf("%s (override with unsupportedOverrides %s)", plugin, fields.ImageOverrideKey))
+	}
+	if len(unmirrored) > 0 {
+		return fmt.Errorf("default plugins are not in the image set of the operator: %s", strings.Join(unmirrored, ", "))
+	}
+	return nil
+}
+
+// imageRepositoryName returns the last path component of an image reference, without tag or digest
+func imageRepositoryName(image string) string {
+	image, _, _ = strings.Cut(image, "@")
+	name := image[strings.LastIndex(image, "/")+1:]
+	name, _, _ = strings.Cut(name, ":")
+	return name
+}
+
+// warnStaleResticSecretKeys emits a warning for every backup location secret that still holds
+// restic-only keys once the DPA has been migrated to nodeAgent, as node agent ignores them
+func (r *DPAReconciler) warnStaleResticSecretKeys(log logr.Logger, dpa *oadpv1alpha1.DataProtectionApplication) {
+	staleKeys, err := r.staleResticSecretKeys(dpa)
+	if err != nil {
+		log.Error(err, "unable to check backup location secrets for restic-only keys")
+		return
+	}
+	for _, secretName := range sortedKeys(staleKeys) {
+		msg := fmt.Sprintf("secret %s/%s has restic-only keys %s that are ignored by nodeAgent and can be removed", dpa.Namespace, secretName, strings.Join(staleKeys[secretName], ", "))
+		r.warnEvent(log, dpa, "StaleResticSecretKeys", msg)
+	}
+}
+
+// staleResticSecretKeys returns the restic-only keys found in backup location secrets, by secret name,
+// when nodeAgent is configured
+func (r *DPAReconciler) staleResticSecretKeys(dpa *oadpv1alpha1.DataProtectionApplication) (map[string][]string, error) {
+	staleKeys := map[string][]string{}
+	if dpa.Spec.Configuration.NodeAgent == nil {
+		return staleKeys, nil
+	}
+	secrets, err := r.getBackupLocationSecrets(dpa)
+	if err != nil {
+		return nil, err
+	}
+	for _, secret := range secrets {
+		for key := range secret.Data {
+			if strings.HasPrefix(key, resticSecretKeyPrefix) {
+				staleKeys[secret.Name] = append(staleKeys[secret.Name], key)
+			}
+		}
+		sort.Strings(staleKeys[secret.Name])
+	}
+	return staleKeys, nil
+}
+
+// warnCredentialTrailingWhitespace emits a warning for every backup location secret of a known provider
+// with credential values ending in whitespace, which providers reject as invalid keys
+func (r *DPAReconciler) warnCredentialTrailingWhitespace(log logr.Logger, dpa *oadpv1alpha1.DataProtectionApplication) {
+	suspiciousValues, err := r.credentialValuesWithTrailingWhitespace(dpa)
+	if err != nil {
+		log.Error(err, "unable to check backup location secrets for trailing whitespace")
+		return
+	}
+	for _, secretName := range sortedKeys(suspiciousValues) {
+		msg := fmt.Sprintf("secret %s/%s has credential values with trailing whitespace for %s, which may break authentication", dpa.Namespace, secretName, strings.Join(suspiciousValues[secretName], ", "))
+		r.warnEvent(log, dpa, "CredentialTrailingWhitespace", msg)
+	}
+}
+
+// credentialValuesWithTrailingWhitespace returns, by secret name, the credential file entries of aws and
+// azure backup locations whose value ends in whitespace, such as a carriage return left by a CRLF file
+func (r *DPAReconciler) credentialValuesWithTrailingWhitespace(dpa *oadpv1alpha1.DataProtectionApplication) (map[string][]string, error) {
+	suspiciousValues := map[string][]string{}
+	checked := mapset.NewSet[string]()
+	for _, bslSpec := range dpa.Spec.BackupLocations {
+		if bslSpec.Velero == nil {
+			continue
+		}
+		// gcp credentials are JSON, where whitespace around values is not significant
+		provider := strings.TrimPrefix(bslSpec.Velero.Provider, veleroIOPrefix)
+		if provider != AWSProvider && provider != AzureProvider {
+			continue
+		}
+		secretName, secretKey := r.getSecretNameAndKeyforBackupLocation(bslSpec)
+		if secretName == "" || checked.Contains(secretName+"/"+secretKey) {
+			continue
+		}
+		checked.Add(secretName + "/" + secretKey)
+		secret := corev1.Secret{}
+		if err := r.Get(r.Context, types.NamespacedName{Namespace: dpa.Namespace, Name: secretName}, &secret); err != nil {
+			if k8serror.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		for _, line := range strings.Split(string(secret.Data[secretKey]), "\n") {
+			key, value, found := strings.Cut(line, "=")
+			if !found || strings.HasPrefix(strings.TrimSpace(key), "#") {
+				continue
+			}
+			if value != strings.TrimRightFunc(value, unicode.IsSpace) {
+				suspiciousValues[secretName] = append(suspiciousValues[secretName], strings.TrimSpace(key))
+			}
+		}
+	}
+	return suspiciousValues, nil
+}
+
+// warnDualPurposeCredentialSecrets emits a warning for every backup location secret that is also used for
+// something other than storage credentials, as editing it for one purpose may break the other
+func (r *DPAReconciler) warnDualPurposeCredentialSecrets(log logr.Logger, dpa *oadpv1alpha1.DataProtectionApplication) {
+	otherUsages, err := r.dualPurposeCredentialSecrets(dpa)
+	if err != nil {
+		log.Error(err, "unable to check backup location secrets for other usages")
+		return
+	}
+	for _, secretName := range sortedKeys(otherUsages) {
+		msg := fmt.Sprintf("backup location secret %s/%s is also %s, use a dedicated secret for backup location credentials", dpa.Namespace, secretName, strings.Join(otherUsages[secretName], " and "))
+		r.warnEvent(log, dpa, "DualPurposeCredentialSecret", msg)
+	}
+}
+
+// dualPurposeCredentialSecrets returns the non credential usages of backup location secrets, by secret name
+func (r *DPAReconciler) dualPurposeCredentialSecrets(dpa *oadpv1alpha1.DataProtectionApplication) (map[string][]string, error) {
+	otherUsages := map[string][]string{}
+	secrets, err := r.getBackupLocationSecrets(dpa)
+	if err != nil {
+		return nil, err
+	}
+	if len(secrets) == 0 {
+		return otherUsages, nil
+	}
+	serviceAccounts := corev1.ServiceAccountList{}
+	if err := r.List(r.Context, &serviceAccounts, client.InNamespace(dpa.Namespace)); err != nil {
+		return nil, err
+	}
+	for _, secret := range secrets {
+		if secret.Type != "" && secret.Type != corev1.SecretTypeOpaque {
+			otherUsages[secret.Name] = append(otherUsages[secret.Name], fmt.Sprintf("a %s secret", secret.Type))
+		}
+		for _, sa := range serviceAccounts.Items {
+			for _, pullSecret := range sa.ImagePullSecrets {
+				if pullSecret.Name == secret.Name {
+					otherUsages[secret.Name] = append(otherUsages[secret.Name], fmt.Sprintf("an image pull secret of service account %s", sa.Name))
+				}
+			}
 		}
+	}
+	return otherUsages, nil
+}
+
+// getBackupLocationSecrets returns the existing credential secrets referenced by the DPA backup locations,
+// missing secrets are reported by backup location validation and are skipped
+func (r *DPAReconciler) getBackupLocationSecrets(dpa *oadpv1alpha1.DataProtectionApplication) ([]corev1.Secret, error) {
+	secrets := []corev1.Secret{}
+	checked := mapset.NewSet[string]()
+	for _, bslSpec := range dpa.Spec.BackupLocations {
+		secretName, _ := r.getSecretNameAndKeyforBackupLocation(bslSpec)
+		if secretName == "" || checked.Contains(secretName) {
+			continue
+		}
+		checked.Add(secretName)
+		secret := corev1.Secret{}
+		if err := r.Get(r.Context, types.NamespacedName{Namespace: dpa.Namespace, Name: secretName}, &secret); err != nil {
+			if k8serror.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		secrets = append(secrets, secret)
+	}
+	return secrets, nil
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// warnMissingResourceRequests emits a warning for Velero and node agent when no resource requests are
+// configured in the DPA and a LimitRange exists in the namespace, as the LimitRange may reject the operator defaults
+func (r *DPAReconciler) warnMissingResourceRequests(log logr.Logger, dpa *oadpv1alpha1.DataProtectionApplication) {
+	limitRanges := corev1.LimitRangeList{}
+	if err := r.List(r.Context, &limitRanges, client.InNamespace(dpa.Namespace)); err != nil {
+		log.Error(err, "unable to list LimitRanges to check for missing resource requests")
+		return
+	}
+	if len(limitRanges.Items) == 0 {
+		return
+	}
+	for _, component := range componentsWithoutResourceRequests(dpa) {
+		msg := fmt.Sprintf("%s has no resource requests configured and LimitRange %s exists in namespace %s, pods may be rejected; set podConfig.resourceAllocations.requests", component, limitRanges.Items[0].Name, dpa.Namespace)
+		r.warnEvent(log, dpa, "MissingResourceRequests", msg)
+	}
+}
+
+// componentsWithoutResourceRequests returns the components deployed by the DPA that have no resource requests configured
+func componentsWithoutResourceRequests(dpa *oadpv1alpha1.DataProtectionApplication) []string {
+	components := []string{}
+	if podConfig := dpa.Spec.Configuration.Velero.PodConfig; podConfig == nil || len(podConfig.ResourceAllocations.Requests) == 0 {
+		components = append(components, common.Velero)
+	}
+	var nodeAgentFields *oadpv1alpha1.NodeAgentCommonFields
+	if dpa.Spec.Configuration.NodeAgent != nil {
+		nodeAgentFields = &dpa.Spec.Configuration.NodeAgent.NodeAgentCommonFields
+	} else if dpa.Spec.Configuration.Restic != nil {
+		nodeAgentFields = &dpa.Spec.Configuration.Restic.NodeAgentCommonFields
+	}
+	if nodeAgentFields != nil && boolptr.IsSetToTrue(nodeAgentFields.Enable) &&
+		(nodeAgentFields.PodConfig == nil || len(nodeAgentFields.PodConfig.ResourceAllocations.Requests) == 0) {
+		components = append(components, common.NodeAgent)
+	}
+	return components
+}
+
+// warnRequestsExceedNodeAllocatable emits a warning for Velero and node agent resource requests above the
+// allocatable resources of the smallest schedulable node their pods can be placed on, and for Velero requests
+// no such node can satisfy
+func (r *DPAReconciler) warnRequestsExceedNodeAllocatable(log logr.Logger, dpa *oadpv1alpha1.DataProtectionApplication) {
+	nodes := corev1.NodeList{}
+	if err := r.List(r.Context, &nodes); err != nil {
+		log.Error(err, "unable to list nodes to compare resource requests with node allocatable")
+		return
+	}
+	messages, err := r.requestsExceedingNodeAllocatable(dpa, nodes.Items)
+	if err != nil {
+		// unparseable resource allocations are reported by the resource allocations validation
+		return
+	}
+	for _, msg := range messages {
+		r.warnEvent(log, dpa, "RequestsExceedNodeAllocatable", msg)
+	}
+}
+
+// requestsExceedingNodeAllocatable returns a message for each Velero and node agent cpu or memory request
+// above the allocatable of the smallest schedulable node matching the component's node selector, and one if
+// the Velero requests set in the DPA fit no such node
+func (r *DPAReconciler) requestsExceedingNodeAllocatable(dpa *oadpv1alpha1.DataProtectionApplication, nodes []corev1.Node) ([]string, error) {
+	messages := []string{}
+	veleroResourceReqs, err := r.getVeleroResourceReqs(dpa)
+	if err != nil {
+		return nil, err
+	}
+	var veleroNodeSelector map[string]string
+	if dpa.Spec.Configuration.Velero.PodConfig != nil {
+		veleroNodeSelector = dpa.Spec.Configuration.Velero.PodConfig.NodeSelector
+	}
+	messages = append(messages, requestsAboveSmallestNode(common.Velero, veleroResourceReqs.Requests, veleroNodeSelector, nodes)...)
+	if podConfig := dpa.Spec.Configuration.Velero.PodConfig; podConfig != nil && len(podConfig.ResourceAllocations.Requests) > 0 {
+		if msg := requestsFitNoNode(common.Velero, veleroResourceReqs.Requests, veleroNodeSelector, nodes); msg != "" {
+			messages = append(messages, msg)
+		}
+	}
+
+	var nodeAgentResourceReqs corev1.ResourceRequirements
+	if dpa.Spec.Configuration.Restic != nil && boolptr.IsSetToTrue(dpa.Spec.Configuration.Restic.Enable) {
+		nodeAgentResourceReqs, err = getResticResourceReqs(dpa)
+	} else if dpa.Spec.Configuration.NodeAgent != nil && boolptr.IsSetToTrue(dpa.Spec.Configuration.NodeAgent.Enable) {
+		nodeAgentResourceReqs, err = getNodeAgentResourceReqs(dpa)
 	} else {
-		if len(dpa.Spec.BackupLocations) == 0 {
-			return false, errors.New("no backupstoragelocations configured, ensure a backupstoragelocation has been configured or use the noDefaultBackupLocation flag")
+		return messages, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var nodeAgentNodeSelector map[string]string
+	if podConfig := getNodeAgentPodConfig(dpa); podConfig != nil {
+		nodeAgentNodeSelector = podConfig.NodeSelector
+	}
+	messages = append(messages, requestsAboveSmallestNode(common.NodeAgent, nodeAgentResourceReqs.Requests, nodeAgentNodeSelector, nodes)...)
+	return messages, nil
+}
+
+// requestsAboveSmallestNode compares cpu and memory requests to the smallest allocatable among schedulable
+// nodes matching nodeSelector
+func requestsAboveSmallestNode(component string, requests corev1.ResourceList, nodeSelector map[string]string, nodes []corev1.Node) []string {
+	messages := []string{}
+	for _, resourceName := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+		request, found := requests[resourceName]
+		if !found {
+			continue
+		}
+		var smallestNode *corev1.Node
+		var smallestAllocatable resource.Quantity
+		for i, node := range nodes {
+			if node.Spec.Unschedulable || !labels.SelectorFromSet(nodeSelector).Matches(labels.Set(node.Labels)) {
+				continue
+			}
+			allocatable, found := node.Status.Allocatable[resourceName]
+			if !found {
+				continue
+			}
+			if smallestNode == nil || allocatable.Cmp(smallestAllocatable) < 0 {
+				smallestNode = &nodes[i]
+				smallestAllocatable = allocatable
+			}
+		}
+		if smallestNode != nil && request.Cmp(smallestAllocatable) > 0 {
+			messages = append(messages, fmt.Sprintf("%s %s request %s exceeds allocatable %s of the smallest schedulable node %s, pods may not schedule there", component, resourceName, request.String(), smallestAllocatable.String(), smallestNode.Name))
 		}
 	}
+	return messages
+}
 
-	if validBsl, err := r.ValidateBackupStorageLocations(dpa); !validBsl || err != nil {
-		return validBsl, err
+// requestsFitNoNode returns a message if no schedulable node matching nodeSelector has the allocatable cpu and
+// memory for all of the requests, or an empty string if one does or no nodes are known
+func requestsFitNoNode(component string, requests corev1.ResourceList, nodeSelector map[string]string, nodes []corev1.Node) string {
+	if len(nodes) == 0 {
+		return ""
+	}
+	resourceNames := []corev1.ResourceName{}
+	for _, resourceName := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+		if _, found := requests[resourceName]; found {
+			resourceNames = append(resourceNames, resourceName)
+		}
+	}
+	if len(resourceNames) == 0 {
+		return ""
+	}
+	selector := labels.SelectorFromSet(nodeSelector)
+	for _, node := range nodes {
+		if node.Spec.Unschedulable || !selector.Matches(labels.Set(node.Labels)) {
+			continue
+		}
+		fits := true
+		for _, resourceName := range resourceNames {
+			request := requests[resourceName]
+			allocatable, found := node.Status.Allocatable[resourceName]
+			if found && request.Cmp(allocatable) > 0 {
+				fits = false
+			}
+		}
+		if fits {
+			return ""
+		}
 	}
-	if validVsl, err := r.ValidateVolumeSnapshotLocations(dpa); !validVsl || err != nil {
-		return validVsl, err
+	requested := []string{}
+	for _, resourceName := range resourceNames {
+		request := requests[resourceName]
+		requested = append(requested, fmt.Sprintf("%s %s", resourceName, request.String()))
 	}
+	if len(nodeSelector) == 0 {
+		return fmt.Sprintf("%s requests %s fit no schedulable node, the %s pod will not schedule", component, strings.Join(requested, ", "), component)
+	}
+	return fmt.Sprintf("%s requests %s fit no schedulable node matching node selector %s, the %s pod will not schedule", component, strings.Join(requested, ", "), selector.String(), component)
+}
 
-	if val, found := dpa.Spec.UnsupportedOverrides[oadpv1alpha1.OperatorTypeKey]; found && val != oadpv1alpha1.OperatorTypeMTC {
-		return false, errors.New("only mtc operator type override is supported")
+// warnExtendedResourceRequests emits a warning for every extended resource, such as a GPU, requested for Velero
+// or node agent, as neither uses them and their pods only schedule on nodes advertising the resource
+func (r *DPAReconciler) warnExtendedResourceRequests(log logr.Logger, dpa *oadpv1alpha1.DataProtectionApplication) {
+	for _, msg := range extendedResourceRequestsMessages(dpa) {
+		r.warnEvent(log, dpa, "ExtendedResourceRequests", msg)
 	}
+}
 
-	if _, err := r.ValidateVeleroPlugins(r.Log); err != nil {
-		return false, err
+// extendedResourceRequestsMessages returns a message for each resource other than cpu, memory, ephemeral storage
+// and hugepages in the Velero and node agent resource allocations
+func extendedResourceRequestsMessages(dpa *oadpv1alpha1.DataProtectionApplication) []string {
+	messages := []string{}
+	if podConfig := dpa.Spec.Configuration.Velero.PodConfig; podConfig != nil {
+		messages = append(messages, extendedResources(common.Velero, podConfig.ResourceAllocations)...)
+	}
+	var nodeAgentFields *oadpv1alpha1.NodeAgentCommonFields
+	if dpa.Spec.Configuration.NodeAgent != nil {
+		nodeAgentFields = &dpa.Spec.Configuration.NodeAgent.NodeAgentCommonFields
+	} else if dpa.Spec.Configuration.Restic != nil {
+		nodeAgentFields = &dpa.Spec.Configuration.Restic.NodeAgentCommonFields
+	}
+	if nodeAgentFields != nil && boolptr.IsSetToTrue(nodeAgentFields.Enable) && nodeAgentFields.PodConfig != nil {
+		messages = append(messages, extendedResources(common.NodeAgent, nodeAgentFields.PodConfig.ResourceAllocations)...)
 	}
+	return messages
+}
 
-	if _, err := r.getVeleroResourceReqs(&dpa); err != nil {
-		return false, err
+func extendedResources(component string, resourceAllocations corev1.ResourceRequirements) []string {
+	resourceNames := map[string]bool{}
+	for resourceName := range resourceAllocations.Requests {
+		resourceNames[string(resourceName)] = true
 	}
+	for resourceName := range resourceAllocations.Limits {
+		resourceNames[string(resourceName)] = true
+	}
+	messages := []string{}
+	for _, resourceName := range sortedKeys(resourceNames) {
+		switch corev1.ResourceName(resourceName) {
+		case corev1.ResourceCPU, corev1.ResourceMemory, corev1.ResourceEphemeralStorage:
+			continue
+		}
+		if strings.HasPrefix(resourceName, corev1.ResourceHugePagesPrefix) {
+			continue
+		}
+		messages = append(messages, fmt.Sprintf("%s resource allocations include extended resource %s which %s does not use, pods only schedule on nodes providing it; remove it from podConfig.resourceAllocations", component, resourceName, component))
+	}
+	return messages
+}
 
-	if _, err := getResticResourceReqs(&dpa); err != nil {
-		return false, err
+// warnIgnoredFieldsForOperatorMode emits a warning for every DPA field that is set but has no effect
+// in the operator mode selected with the operator-type unsupported override
+func (r *DPAReconciler) warnIgnoredFieldsForOperatorMode(log logr.Logger, dpa *oadpv1alpha1.DataProtectionApplication) {
+	for _, ignored := range ignoredFieldsForOperatorMode(dpa) {
+		msg := fmt.Sprintf("%s is ignored in %s operator mode: %s", ignored.field, dpa.Spec.UnsupportedOverrides[oadpv1alpha1.OperatorTypeKey], ignored.reason)
+		r.warnEvent(log, dpa, "IgnoredFieldForOperatorMode", msg)
+	}
+}
+
+type ignoredField struct {
+	field  string
+	reason string
+}
+
+// ignoredFieldsForOperatorMode returns the DPA fields that are set but do not take effect in the active operator mode
+func ignoredFieldsForOperatorMode(dpa *oadpv1alpha1.DataProtectionApplication) []ignoredField {
+	ignored := []ignoredField{}
+	if !dpa.IsOperatorTypeMTC() {
+		return ignored
+	}
+	if dpa.Spec.Configuration.Velero.NoDefaultBackupLocation && dpa.Spec.Configuration.Velero.HasFeatureFlag("no-secret") {
+		ignored = append(ignored, ignoredField{
+			field:  "spec.configuration.velero.featureFlags no-secret",
+			reason: "default cloud provider credentials are always mounted for MTC",
+		})
+	}
+	if dpa.Spec.Configuration.Velero.NoDefaultBackupLocation {
+		for _, plugin := range dpa.Spec.Configuration.Velero.DefaultPlugins {
+			if fields, ok := credentials.PluginSpecificFields[plugin]; ok && fields.IsCloudProvider {
+				ignored = append(ignored, ignoredField{
+					field:  "spec.configuration.velero.noDefaultBackupLocation",
+					reason: fmt.Sprintf("default credentials secret %s of the %s plugin is still mounted for MTC", fields.SecretName, plugin),
+				})
+			}
+		}
+	}
+	return ignored
+}
+
+// locationCredential is the credentials secret and key used by a backup or snapshot location of a default plugin
+type locationCredential struct {
+	// location is the spec path of the location, such as backupLocations[0]
+	location   string
+	secretName string
+	secretKey  string
+}
+
+// pluginCredentialResolution decides whether credentials for a default plugin
+// must be validated and returns the decision along with the credentials of
+// each backup and snapshot location of the plugin to validate.
+func pluginCredentialResolution(dpa *oadpv1alpha1.DataProtectionApplication, plugin oadpv1alpha1.DefaultPlugin, providerNeedsDefaultCreds map[string]bool, hasCloudStorage bool) (oadpv1alpha1.CredentialDecision, []locationCredential) {
+	pluginSpecificMap, ok := credentials.PluginSpecificFields[plugin]
+	pluginNeedsCheck, foundInBSLorVSL := providerNeedsDefaultCreds[string(plugin)]
+
+	usedBySnapshotLocation := false
+	for _, location := range dpa.Spec.SnapshotLocations {
+		if location.Velero != nil && strings.TrimPrefix(location.Velero.Provider, veleroIOPrefix) == string(plugin) {
+			pluginNeedsCheck = true
+			usedBySnapshotLocation = true
+		}
+	}
+	if !foundInBSLorVSL && !hasCloudStorage {
+		pluginNeedsCheck = true
+	}
+	// without a default backup location, credentials are still needed by the snapshot locations of the plugin
+	skipNoDefaultBackupLocation := dpa.Spec.Configuration.Velero.NoDefaultBackupLocation && !usedBySnapshotLocation
+	if !ok || !pluginSpecificMap.IsCloudProvider || !pluginNeedsCheck || skipNoDefaultBackupLocation || dpa.Spec.Configuration.Velero.HasFeatureFlag("no-secret") {
+		return oadpv1alpha1.CredentialDecisionSkipped, nil
+	}
+
+	// check specified credentials in backup and snapshot locations exists in the cluster, falling back to the
+	// default secret of the plugin for each location without credentials
+	locationCredentials := []locationCredential{}
+	defaultUsed := false
+	addLocationCredential := func(location, provider string, credential *corev1.SecretKeySelector) {
+		if strings.TrimPrefix(provider, veleroIOPrefix) != string(plugin) {
+			return
+		}
+		name, key := pluginSpecificMap.SecretName, pluginSpecificMap.PluginSecretKey
+		if credential != nil {
+			name = credential.Name
+			if credential.Key != "" {
+				key = credential.Key
+			}
+		} else {
+			defaultUsed = true
+		}
+		locationCredentials = append(locationCredentials, locationCredential{location: location, secretName: name, secretKey: key})
+	}
+	for i, location := range dpa.Spec.BackupLocations {
+		if location.Velero != nil && !usesGCPWorkloadIdentity(location.Velero) {
+			addLocationCredential(fmt.Sprintf("backupLocations[%d]", i), location.Velero.Provider, location.Velero.Credential)
+		}
+	}
+	for i, location := range dpa.Spec.SnapshotLocations {
+		if location.Velero != nil {
+			addLocationCredential(fmt.Sprintf("snapshotLocations[%d]", i), location.Velero.Provider, location.Velero.Credential)
+		}
+	}
+	if defaultUsed {
+		return oadpv1alpha1.CredentialDecisionDefaultUsed, locationCredentials
+	}
+	return oadpv1alpha1.CredentialDecisionNeedsCheck, locationCredentials
+}
+
+// getCredentialResolutions returns the credential decision for each default
+// plugin, as made by ValidateVeleroPlugins.
+func (r *DPAReconciler) getCredentialResolutions(dpa *oadpv1alpha1.DataProtectionApplication) ([]oadpv1alpha1.ProviderCredentialResolution, error) {
+	if dpa.Spec.Configuration == nil || dpa.Spec.Configuration.Velero == nil {
+		return nil, nil
+	}
+	providerNeedsDefaultCreds, hasCloudStorage, err := r.noDefaultCredentials(*dpa)
+	if err != nil {
+		return nil, err
+	}
+	var resolutions []oadpv1alpha1.ProviderCredentialResolution
+	for _, plugin := range dpa.Spec.Configuration.Velero.DefaultPlugins {
+		decision, _ := pluginCredentialResolution(dpa, plugin, providerNeedsDefaultCreds, hasCloudStorage)
+		resolutions = append(resolutions, oadpv1alpha1.ProviderCredentialResolution{
+			Provider: plugin,
+			Decision: decision,
+		})
+	}
+	return resolutions, nil
+}
+
+// legacy provider names still accepted for backup and snapshot locations, by current provider name
+var deprecatedProviderAliases = map[string]string{
+	veleroIOPrefix + AWSProvider:   AWSProvider,
+	veleroIOPrefix + AzureProvider: AzureProvider,
+	veleroIOPrefix + GCPProvider:   GCPProvider,
+}
+
+// warnDeprecatedProviderAliases emits a warning for every backup and snapshot location using a deprecated
+// provider alias
+func (r *DPAReconciler) warnDeprecatedProviderAliases(log logr.Logger, dpa *oadpv1alpha1.DataProtectionApplication) {
+	for _, msg := range deprecatedProviderAliasUsages(dpa) {
+		r.warnEvent(log, dpa, "DeprecatedProviderAlias", msg)
 	}
-	return true, nil
+}
+
+// deprecatedProviderAliasUsages returns a message naming the current provider for each location using a
+// deprecated provider alias
+func deprecatedProviderAliasUsages(dpa *oadpv1alpha1.DataProtectionApplication) []string {
+	messages := []string{}
+	for i, bslSpec := range dpa.Spec.BackupLocations {
+		if bslSpec.Velero == nil {
+			continue
+		}
+		if current, found := deprecatedProviderAliases[bslSpec.Velero.Provider]; found {
+			messages = append(messages, fmt.Sprintf("backupLocations[%d] provider %s is a deprecated alias, use %s instead", i, bslSpec.Velero.Provider, current))
+		}
+	}
+	for i, vslSpec := range dpa.Spec.SnapshotLocations {
+		if vslSpec.Velero == nil {
+			continue
+		}
+		if current, found := deprecatedProviderAliases[vslSpec.Velero.Provider]; found {
+			messages = append(messages, fmt.Sprintf("snapshotLocations[%d] provider %s is a deprecated alias, use %s instead", i, vslSpec.Velero.Provider, current))
+		}
+	}
+	return messages
 }
 
 // empty struct to use as map value
 type empty struct{}
 
-// For later: Move this code into validator.go when more need for validation arises
-// TODO: if multiple default plugins exist, ensure we validate all of them.
-// Right now its sequential validation
+// ValidateVeleroPlugins checks the credentials secret of every backup and snapshot location of the default
+// plugins exists, reporting every missing or invalid secret along with the locations using it
 func (r *DPAReconciler) ValidateVeleroPlugins(log logr.Logger) (bool, error) {
 	dpa := oadpv1alpha1.DataProtectionApplication{}
 	if err := r.Get(r.Context, r.NamespacedName, &dpa); err != nil {
 		return false, err
 	}
 
-	providerNeedsDefaultCreds, hasCloudStorage, err := r.noDefaultCredentials(dpa)
+	pluginErrs, err := r.pluginCredentialErrors(&dpa)
 	if err != nil {
 		return false, err
 	}
+	errs := []error{}
+	for _, plugin := range dpa.Spec.Configuration.Velero.DefaultPlugins {
+		errs = append(errs, pluginErrs[plugin]...)
+	}
+	if len(errs) > 0 {
+		return false, errors.Join(errs...)
+	}
+	return true, nil
+}
 
-	snapshotLocationsProviders := make(map[string]bool)
-	for _, location := range dpa.Spec.SnapshotLocations {
-		if location.Velero != nil {
-			provider := strings.TrimPrefix(location.Velero.Provider, veleroIOPrefix)
-			snapshotLocationsProviders[provider] = true
-		}
+// pluginCredentialErrors returns the errors found validating the credentials secrets of each default plugin,
+// plugins whose credentials are valid or not checked have no entry
+func (r *DPAReconciler) pluginCredentialErrors(dpa *oadpv1alpha1.DataProtectionApplication) (map[oadpv1alpha1.DefaultPlugin][]error, error) {
+	providerNeedsDefaultCreds, hasCloudStorage, err := r.noDefaultCredentials(*dpa)
+	if err != nil {
+		return nil, err
 	}
 
+	pluginErrs := map[oadpv1alpha1.DefaultPlugin][]error{}
 	for _, plugin := range dpa.Spec.Configuration.Velero.DefaultPlugins {
-		pluginSpecificMap, ok := credentials.PluginSpecificFields[plugin]
-		pluginNeedsCheck, foundInBSLorVSL := providerNeedsDefaultCreds[string(plugin)]
-
-		if foundInVSL := snapshotLocationsProviders[string(plugin)]; foundInVSL {
-			pluginNeedsCheck = true
-		}
-		if !foundInBSLorVSL && !hasCloudStorage {
-			pluginNeedsCheck = true
+		_, locationCredentials := pluginCredentialResolution(dpa, plugin, providerNeedsDefaultCreds, hasCloudStorage)
+		// each distinct secret is read once, and its errors name every location using it
+		secretNames := []string{}
+		secretLocations := map[string][]string{}
+		secretKeys := map[string]map[string]empty{}
+		for _, credential := range locationCredentials {
+			if _, found := secretLocations[credential.secretName]; !found {
+				secretNames = append(secretNames, credential.secretName)
+				secretKeys[credential.secretName] = map[string]empty{}
+			}
+			secretLocations[credential.secretName] = append(secretLocations[credential.secretName], credential.location)
+			secretKeys[credential.secretName][credential.secretKey] = empty{}
 		}
-		if ok && pluginSpecificMap.IsCloudProvider && pluginNeedsCheck && !dpa.Spec.Configuration.Velero.NoDefaultBackupLocation && !dpa.Spec.Configuration.Velero.HasFeatureFlag("no-secret") {
-			secretNamesToValidate := mapset.NewSet[string]()
-			// check specified credentials in backup locations exists in the cluster
-			for _, location := range dpa.Spec.BackupLocations {
-				if location.Velero != nil {
-					provider := strings.TrimPrefix(location.Velero.Provider, veleroIOPrefix)
-					if provider == string(plugin) && location.Velero != nil {
-						if location.Velero.Credential != nil {
-							secretNamesToValidate.Add(location.Velero.Credential.Name)
-						} else {
-							secretNamesToValidate.Add(pluginSpecificMap.SecretName)
-						}
-					}
-				}
+		for _, secretName := range secretNames {
+			locations := strings.Join(secretLocations[secretName], ", ")
+			secret, err := r.getProviderSecret(secretName)
+			if err != nil {
+				r.Log.Info(fmt.Sprintf("error validating %s provider secret:  %s/%s used by %s", string(plugin), r.NamespacedName.Namespace, secretName, locations))
+				pluginErrs[plugin] = append(pluginErrs[plugin], err)
+				continue
 			}
-			// check specified credentials in snapshot locations exists in the cluster
-			for _, location := range dpa.Spec.SnapshotLocations {
-				if location.Velero != nil {
-					provider := strings.TrimPrefix(location.Velero.Provider, veleroIOPrefix)
-					if provider == string(plugin) && location.Velero != nil {
-						if location.Velero.Credential != nil {
-							secretNamesToValidate.Add(location.Velero.Credential.Name)
-						} else {
-							secretNamesToValidate.Add(pluginSpecificMap.SecretName)
-						}
+			if plugin == oadpv1alpha1.DefaultPluginMicrosoftAzure {
+				for _, secretKey := range sortedKeys(secretKeys[secretName]) {
+					if err := validateAzureSecretContent(secret.Data, secretKey); err != nil {
+						pluginErrs[plugin] = append(pluginErrs[plugin], fmt.Errorf("error validating azure provider secret %s/%s used by %s: %w", r.NamespacedName.Namespace, secretName, locations, err))
 					}
 				}
 			}
-			for _, secretName := range secretNamesToValidate.ToSlice() {
-				_, err := r.getProviderSecret(secretName)
-				if err != nil {
-					r.Log.Info(fmt.Sprintf("error validating %s provider secret:  %s/%s", string(plugin), r.NamespacedName.Namespace, secretName))
-					return false, err
-				}
-			}
 		}
 	}
-	return true, nil
+	return pluginErrs, nil
+}
+
+// pluginsFailingCredentialValidation returns the default plugins whose credentials secrets failed validation,
+// in the order of the DPA
+func (r *DPAReconciler) pluginsFailingCredentialValidation(dpa *oadpv1alpha1.DataProtectionApplication) ([]string, error) {
+	if dpa.Spec.Configuration == nil || dpa.Spec.Configuration.Velero == nil {
+		return nil, nil
+	}
+	pluginErrs, err := r.pluginCredentialErrors(dpa)
+	if err != nil {
+		return nil, err
+	}
+	plugins := []string{}
+	for _, plugin := range dpa.Spec.Configuration.Velero.DefaultPlugins {
+		if len(pluginErrs[plugin]) > 0 {
+			plugins = append(plugins, string(plugin))
+		}
+	}
+	return plugins, nil
+}
+
+// required keys of the azure credentials file by authentication mode
+var (
+	azureServicePrincipalKeys  = []string{"AZURE_SUBSCRIPTION_ID", "AZURE_TENANT_ID", "AZURE_CLIENT_ID", "AZURE_CLIENT_SECRET"}
+	azureWorkloadIdentityKeys  = []string{"AZURE_SUBSCRIPTION_ID", "AZURE_TENANT_ID", "AZURE_CLIENT_ID", "AZURE_FEDERATED_TOKEN_FILE"}
+	azureClientCertificateKeys = []string{"AZURE_SUBSCRIPTION_ID", "AZURE_TENANT_ID", "AZURE_CLIENT_ID", "AZURE_CLIENT_CERTIFICATE_PATH"}
+	azureManagedIdentityKeys   = []string{"AZURE_SUBSCRIPTION_ID"}
+)
+
+// validateAzureSecretContent checks the azure credentials file in secretKey of the secret data has the keys required
+// by its authentication mode, picked from the credential present: a client secret for service principal, a federated
+// token file for workload identity, a certificate path for client certificate and otherwise managed identity, where a
+// client id optionally selects a user assigned identity. Storage account access keys do not need other keys.
+func validateAzureSecretContent(secretData map[string][]byte, secretKey string) error {
+	data, found := secretData[secretKey]
+	if !found {
+		return fmt.Errorf("azure credentials key %s not found in secret", secretKey)
+	}
+	credentials := parseCredentialsFile(data)
+	if credentials["AZURE_STORAGE_ACCOUNT_ACCESS_KEY"] != "" {
+		return nil
+	}
+	var requiredKeys []string
+	var authMode string
+	switch {
+	case credentials["AZURE_CLIENT_SECRET"] != "":
+		requiredKeys, authMode = azureServicePrincipalKeys, "service principal"
+	case credentials["AZURE_FEDERATED_TOKEN_FILE"] != "":
+		requiredKeys, authMode = azureWorkloadIdentityKeys, "workload identity"
+	case credentials["AZURE_CLIENT_CERTIFICATE_PATH"] != "":
+		requiredKeys, authMode = azureClientCertificateKeys, "client certificate"
+	default:
+		requiredKeys, authMode = azureManagedIdentityKeys, "managed identity"
+	}
+	missingKeys := []string{}
+	for _, key := range requiredKeys {
+		if credentials[key] == "" {
+			missingKeys = append(missingKeys, key)
+		}
+	}
+	if len(missingKeys) > 0 {
+		return fmt.Errorf("azure credentials key %s is missing %s required for %s authentication", secretKey, strings.Join(missingKeys, ", "), authMode)
+	}
+	return nil
+}
+
+// parseCredentialsFile returns the KEY=VALUE pairs of an azure or aws credentials file, ignoring comments,
+// section headers such as [default] and quotes around values
+func parseCredentialsFile(data []byte) map[string]string {
+	credentials := map[string]string{}
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "[") {
+			continue
+		}
+		key, value, found := strings.Cut(strings.TrimPrefix(line, "export "), "=")
+		if !found {
+			continue
+		}
+		credentials[strings.TrimSpace(key)] = strings.Trim(strings.TrimSpace(value), `"'`)
+	}
+	return credentials
+}
+
+// validateCloudStorageSecretContent checks the credentials in secretKey of a CloudStorage creation secret have the
+// format expected by its provider
+func validateCloudStorageSecretContent(provider oadpv1alpha1.CloudStorageProvider, secretData map[string][]byte, secretKey string) error {
+	switch provider {
+	case oadpv1alpha1.AWSBucketProvider:
+		return validateAWSSecretContent(secretData, secretKey)
+	case oadpv1alpha1.AzureBucketProvider:
+		return validateAzureSecretContent(secretData, secretKey)
+	case oadpv1alpha1.GCPBucketProvider:
+		return validateGCPSecretContent(secretData, secretKey)
+	}
+	return nil
+}
+
+// validateAWSSecretContent checks the aws credentials file in secretKey of the secret data has an access key, or
+// a role to assume with a web identity token for short lived credentials
+func validateAWSSecretContent(secretData map[string][]byte, secretKey string) error {
+	data, found := secretData[secretKey]
+	if !found {
+		return fmt.Errorf("aws credentials key %s not found in secret", secretKey)
+	}
+	credentials := parseCredentialsFile(data)
+	if credentials["role_arn"] != "" && credentials["web_identity_token_file"] != "" {
+		return nil
+	}
+	missingKeys := []string{}
+	for _, key := range []string{"aws_access_key_id", "aws_secret_access_key"} {
+		if credentials[key] == "" {
+			missingKeys = append(missingKeys, key)
+		}
+	}
+	if len(missingKeys) > 0 {
+		return fmt.Errorf("aws credentials key %s is missing %s", secretKey, strings.Join(missingKeys, ", "))
+	}
+	return nil
+}
+
+// validateGCPSecretContent checks secretKey of the secret data is a gcp service account key or a workload identity
+// federation credential configuration
+func validateGCPSecretContent(secretData map[string][]byte, secretKey string) error {
+	data, found := secretData[secretKey]
+	if !found {
+		return fmt.Errorf("gcp credentials key %s not found in secret", secretKey)
+	}
+	credentials := struct {
+		Type string `json:"type"`
+	}{}
+	if err := json.Unmarshal(data, &credentials); err != nil {
+		return fmt.Errorf("gcp credentials key %s is not a JSON credentials file: %v", secretKey, err)
+	}
+	if credentials.Type != "service_account" && credentials.Type != "external_account" {
+		return fmt.Errorf("gcp credentials key %s has type %q, must be service_account or external_account", secretKey, credentials.Type)
+	}
+	return nil
+}
+
+// validatePodLabels returns an error if the velero or node agent podConfig labels set a label OADP sets on the
+// pods, as the deployment and daemonset selectors and the app labels rely on them
+func validatePodLabels(dpa *oadpv1alpha1.DataProtectionApplication) error {
+	if podConfig := dpa.Spec.Configuration.Velero.PodConfig; podConfig != nil {
+		if err := validateReservedPodLabels(common.Velero, podConfig.Labels, veleroLabelSelector.MatchLabels); err != nil {
+			return err
+		}
+	}
+	if podConfig := getNodeAgentPodConfig(dpa); podConfig != nil {
+		if err := validateReservedPodLabels(common.NodeAgent, podConfig.Labels, nodeAgentMatchLabels); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateReservedPodLabels(component string, labels map[string]string, selectorLabels map[string]string) error {
+	for _, key := range sortedKeys(labels) {
+		_, selectorLabel := selectorLabels[key]
+		if selectorLabel || strings.HasPrefix(key, "app.kubernetes.io/") || key == oadpv1alpha1.OadpOperatorLabel {
+			return fmt.Errorf("%s podConfig label %q is reserved by OADP and cannot be set", component, key)
+		}
+	}
+	return nil
+}
+
+// validatePodAnnotations returns an error if a velero or node agent podConfig annotation key is invalid, or the
+// annotation changes the value of one of the podAnnotations
+func validatePodAnnotations(dpa *oadpv1alpha1.DataProtectionApplication) error {
+	if podConfig := dpa.Spec.Configuration.Velero.PodConfig; podConfig != nil {
+		if err := validatePodConfigAnnotations(common.Velero, podConfig.Annotations, dpa.Spec.PodAnnotations); err != nil {
+			return err
+		}
+	}
+	if podConfig := getNodeAgentPodConfig(dpa); podConfig != nil {
+		if err := validatePodConfigAnnotations(common.NodeAgent, podConfig.Annotations, dpa.Spec.PodAnnotations); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validatePodConfigAnnotations(component string, annotations map[string]string, podAnnotations map[string]string) error {
+	for _, key := range sortedKeys(annotations) {
+		// annotation keys are validated case insensitively by the API server
+		if errs := validation.IsQualifiedName(strings.ToLower(key)); len(errs) > 0 {
+			return fmt.Errorf("%s podConfig annotation key %q is not a valid annotation key: %s", component, key, strings.Join(errs, "; "))
+		}
+		if value, found := podAnnotations[key]; found && value != annotations[key] {
+			return fmt.Errorf("%s podConfig annotation %q value %q conflicts with podAnnotations value %q", component, key, annotations[key], value)
+		}
+	}
+	return nil
+}
+
+// validatePodScheduling returns an error naming the first invalid velero or node agent podConfig nodeSelector
+// entry or toleration, as the API server or the scheduler would otherwise reject or never place the pods
+func validatePodScheduling(dpa *oadpv1alpha1.DataProtectionApplication) error {
+	if podConfig := dpa.Spec.Configuration.Velero.PodConfig; podConfig != nil {
+		if err := validatePodConfigScheduling(common.Velero, podConfig); err != nil {
+			return err
+		}
+	}
+	if podConfig := getNodeAgentPodConfig(dpa); podConfig != nil {
+		if err := validatePodConfigScheduling(common.NodeAgent, podConfig); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validatePodConfigScheduling(component string, podConfig *oadpv1alpha1.PodConfig) error {
+	for _, key := range sortedKeys(podConfig.NodeSelector) {
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return fmt.Errorf("%s podConfig nodeSelector key %q is not a valid label key: %s", component, key, strings.Join(errs, "; "))
+		}
+		if errs := validation.IsValidLabelValue(podConfig.NodeSelector[key]); len(errs) > 0 {
+			return fmt.Errorf("%s podConfig nodeSelector %s value %q is not a valid label value: %s", component, key, podConfig.NodeSelector[key], strings.Join(errs, "; "))
+		}
+	}
+	for i, toleration := range podConfig.Tolerations {
+		if err := validateToleration(toleration); err != nil {
+			return fmt.Errorf("%s podConfig tolerations[%d] %s", component, i, err)
+		}
+	}
+	return nil
+}
+
+// validateToleration follows the toleration validation of the API server
+func validateToleration(toleration corev1.Toleration) error {
+	if toleration.Key != "" {
+		if errs := validation.IsQualifiedName(toleration.Key); len(errs) > 0 {
+			return fmt.Errorf("key %q is not a valid label key: %s", toleration.Key, strings.Join(errs, "; "))
+		}
+	} else if toleration.Operator != corev1.TolerationOpExists {
+		return fmt.Errorf("operator must be %s when key is empty", corev1.TolerationOpExists)
+	}
+	switch toleration.Operator {
+	case "", corev1.TolerationOpEqual:
+		if errs := validation.IsValidLabelValue(toleration.Value); len(errs) > 0 {
+			return fmt.Errorf("value %q is not a valid label value: %s", toleration.Value, strings.Join(errs, "; "))
+		}
+	case corev1.TolerationOpExists:
+		if toleration.Value != "" {
+			return fmt.Errorf("value must be empty when operator is %s", corev1.TolerationOpExists)
+		}
+	default:
+		return fmt.Errorf("operator %q must be one of %s, %s", toleration.Operator, corev1.TolerationOpEqual, corev1.TolerationOpExists)
+	}
+	switch toleration.Effect {
+	case "", corev1.TaintEffectNoSchedule, corev1.TaintEffectPreferNoSchedule, corev1.TaintEffectNoExecute:
+	default:
+		return fmt.Errorf("effect %q must be one of %s, %s, %s", toleration.Effect, corev1.TaintEffectNoSchedule, corev1.TaintEffectPreferNoSchedule, corev1.TaintEffectNoExecute)
+	}
+	if toleration.TolerationSeconds != nil && toleration.Effect != corev1.TaintEffectNoExecute {
+		return fmt.Errorf("tolerationSeconds may only be set when effect is %s", corev1.TaintEffectNoExecute)
+	}
+	return nil
 }
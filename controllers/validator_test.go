@@ -1,19 +1,33 @@
 package controllers
 
 import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/vmware-tanzu/velero/pkg/apis/velero/v1"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	k8serror "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	oadpv1alpha1 "github.com/openshift/oadp-operator/api/v1alpha1"
+	"github.com/openshift/oadp-operator/pkg/common"
+	"github.com/openshift/oadp-operator/pkg/credentials"
+	"github.com/openshift/oadp-operator/pkg/velero/server"
 )
 
 func TestDPAReconciler_ValidateDataProtectionCR(t *testing.T) {
@@ -95,7 +109,32 @@ func TestDPAReconciler_ValidateDataProtectionCR(t *testing.T) {
 			},
 			objects:    []client.Object{},
 			wantErr:    true,
-			messageErr: "only mtc operator type override is supported",
+			messageErr: "unsupported operator type override \"notmtc\", only mtc operator type override is supported",
+		},
+		{
+			name: "given valid DPA CR, uppercase mtc operator type override, no error case",
+			dpa: &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-DPA-CR",
+					Namespace: "test-ns",
+				},
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{
+							DefaultPlugins: []oadpv1alpha1.DefaultPlugin{
+								oadpv1alpha1.DefaultPluginAWS,
+							},
+							NoDefaultBackupLocation: true,
+						},
+					},
+					BackupImages: pointer.Bool(false),
+					UnsupportedOverrides: map[oadpv1alpha1.UnsupportedImageKey]string{
+						oadpv1alpha1.OperatorTypeKey: "MTC",
+					},
+				},
+			},
+			objects: []client.Object{},
+			wantErr: false,
 		},
 		{
 			name: "given valid DPA CR, no default backup location, backup images cannot be nil, error case",
@@ -400,7 +439,7 @@ func TestDPAReconciler_ValidateDataProtectionCR(t *testing.T) {
 			},
 			objects:    []client.Object{},
 			wantErr:    true,
-			messageErr: "secrets \"testing\" not found",
+			messageErr: "secrets \"testing\" not found\nsecrets \"cloud-credentials\" not found",
 		},
 		{
 			name: "given valid DPA CR bucket BSL configured with creds and VSL and AWS Default Plugin with no secret, with no-secrets feature enabled",
@@ -499,7 +538,7 @@ func TestDPAReconciler_ValidateDataProtectionCR(t *testing.T) {
 				},
 			},
 			wantErr:    true,
-			messageErr: "secrets \"\" not found",
+			messageErr: "secrets \"\" not found\nsecrets \"cloud-credentials\" not found",
 		},
 		{
 			name: "given valid DPA CR bucket BSL configured and AWS Default Plugin with secret",
@@ -649,6 +688,77 @@ func TestDPAReconciler_ValidateDataProtectionCR(t *testing.T) {
 			objects: []client.Object{},
 			wantErr: false,
 		},
+		{
+			name: "given valid DPA CR BSL configured and GCP Default Plugin without secret using workload identity",
+			dpa: &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-DPA-CR",
+					Namespace: "test-ns",
+				},
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					BackupLocations: []oadpv1alpha1.BackupLocation{
+						{
+							Velero: &v1.BackupStorageLocationSpec{
+								StorageType: v1.StorageType{
+									ObjectStorage: &v1.ObjectStorageLocation{
+										Bucket: "test-bucket",
+										Prefix: "test-prefix",
+									},
+								},
+								Provider: "gcp",
+								Config:   map[string]string{"useWorkloadIdentity": "true", "serviceAccount": "velero@project.iam.gserviceaccount.com"},
+								Default:  true,
+							},
+						},
+					},
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{
+							DefaultPlugins: []oadpv1alpha1.DefaultPlugin{
+								oadpv1alpha1.DefaultPluginGCP,
+							},
+						},
+					},
+				},
+			},
+			objects: []client.Object{},
+			wantErr: false,
+		},
+		{
+			name: "should error: given valid DPA CR BSL configured and GCP Default Plugin using workload identity without service account",
+			dpa: &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-DPA-CR",
+					Namespace: "test-ns",
+				},
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					BackupLocations: []oadpv1alpha1.BackupLocation{
+						{
+							Velero: &v1.BackupStorageLocationSpec{
+								StorageType: v1.StorageType{
+									ObjectStorage: &v1.ObjectStorageLocation{
+										Bucket: "test-bucket",
+										Prefix: "test-prefix",
+									},
+								},
+								Provider: "gcp",
+								Config:   map[string]string{"useWorkloadIdentity": "true"},
+								Default:  true,
+							},
+						},
+					},
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{
+							DefaultPlugins: []oadpv1alpha1.DefaultPlugin{
+								oadpv1alpha1.DefaultPluginGCP,
+							},
+						},
+					},
+				},
+			},
+			objects:    []client.Object{},
+			wantErr:    true,
+			messageErr: "BackupLocation test-DPA-CR-1: useWorkloadIdentity requires serviceAccount to be set to the GCP service account of the workload identity",
+		},
 		{
 			name: "should error: given valid DPA CR BSL configured and GCP Default Plugin without secret without no-secret feature flag",
 			dpa: &oadpv1alpha1.DataProtectionApplication{
@@ -681,7 +791,7 @@ func TestDPAReconciler_ValidateDataProtectionCR(t *testing.T) {
 			},
 			objects:    []client.Object{},
 			wantErr:    true,
-			messageErr: "secrets \"\" not found",
+			messageErr: "secrets \"\" not found\nsecrets \"cloud-credentials-gcp\" not found",
 		},
 		{
 			name: "given valid DPA CR VSL configured and GCP Default Plugin without secret",
@@ -823,7 +933,7 @@ func TestDPAReconciler_ValidateDataProtectionCR(t *testing.T) {
 				},
 			},
 			wantErr:    true,
-			messageErr: "Secret name Test is missing data for key Creds",
+			messageErr: "Secret name Test is missing data for key Creds\nsecrets \"cloud-credentials\" not found",
 		},
 		{
 			name: "given valid DPA CR AWS Default Plugin with credentials and a VSL, and default secret specified, passes",
@@ -998,7 +1108,7 @@ func TestDPAReconciler_ValidateDataProtectionCR(t *testing.T) {
 				},
 			},
 			wantErr:    true,
-			messageErr: "Secret name cloud-credentials is missing data for key cloud",
+			messageErr: "Secret name cloud-credentials is missing data for key cloud\nsecrets \"bad-credentials\" not found",
 		},
 		{
 			name: "given valid DPA CR AWS with BSL and VSL credentials referencing a custom secret",
@@ -1100,7 +1210,7 @@ func TestDPAReconciler_ValidateDataProtectionCR(t *testing.T) {
 			},
 			objects:    []client.Object{},
 			wantErr:    true,
-			messageErr: "BackupLocation must have cloud storage prefix when backupImages is not set to false",
+			messageErr: "BackupLocation must have cloud storage prefix when backupImages is not set to false\ncloudstorages.oadp.openshift.io \"testing\" not found",
 		},
 		{
 			name: "If DPA CR has CloudStorageLocation with Prefix defined with backupImages enabled, no error case",
@@ -1141,6 +1251,9 @@ func TestDPAReconciler_ValidateDataProtectionCR(t *testing.T) {
 						Name:      "testing",
 						Namespace: "test-ns",
 					},
+					Spec: oadpv1alpha1.CloudStorageSpec{
+						Provider: oadpv1alpha1.AWSBucketProvider,
+					},
 				},
 				&corev1.Secret{
 					ObjectMeta: metav1.ObjectMeta{
@@ -1152,6 +1265,60 @@ func TestDPAReconciler_ValidateDataProtectionCR(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "given invalid DPA CR, CloudStorageLocation with unsupported CloudStorage provider, error case",
+			dpa: &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-DPA-CR",
+					Namespace: "test-ns",
+				},
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					BackupLocations: []oadpv1alpha1.BackupLocation{
+						{
+							CloudStorage: &oadpv1alpha1.CloudStorageLocation{
+								CloudStorageRef: corev1.LocalObjectReference{
+									Name: "testing",
+								},
+								Prefix: "some-prefix",
+								Credential: &corev1.SecretKeySelector{
+									LocalObjectReference: corev1.LocalObjectReference{
+										Name: "cloud-credentials",
+									},
+									Key: "cloud",
+								},
+								Default: true,
+							},
+						},
+					},
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{
+							DefaultPlugins: []oadpv1alpha1.DefaultPlugin{},
+						},
+					},
+					BackupImages: pointer.Bool(true),
+				},
+			},
+			objects: []client.Object{
+				&oadpv1alpha1.CloudStorage{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "testing",
+						Namespace: "test-ns",
+					},
+					Spec: oadpv1alpha1.CloudStorageSpec{
+						Provider: oadpv1alpha1.AzureBucketProvider,
+					},
+				},
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "cloud-credentials",
+						Namespace: "test-ns",
+					},
+					Data: map[string][]byte{"cloud": []byte("dummy_data")},
+				},
+			},
+			wantErr:    true,
+			messageErr: "CloudStorage test-ns/testing provider \"azure\" is not supported by the CloudStorage controller",
+		},
 		{
 			name: "given invalid DPA CR, BSL secret key name not match the secret key name, error case",
 			dpa: &oadpv1alpha1.DataProtectionApplication{
@@ -1360,36 +1527,2823 @@ func TestDPAReconciler_ValidateDataProtectionCR(t *testing.T) {
 			wantErr:    true,
 			messageErr: "Secret name specified in BackupLocation  cannot be empty",
 		},
-	}
-	for _, tt := range tests {
-		tt.objects = append(tt.objects, tt.dpa)
-		fakeClient, err := getFakeClientFromObjects(tt.objects...)
-		if err != nil {
-			t.Errorf("error in creating fake client, likely programmer error")
-		}
-		r := &DPAReconciler{
-			Client:  fakeClient,
-			Scheme:  fakeClient.Scheme(),
-			Log:     logr.Discard(),
-			Context: newContextForTest(tt.name),
-			NamespacedName: types.NamespacedName{
-				Namespace: tt.dpa.Namespace,
-				Name:      tt.dpa.Name,
+		{
+			name: "given valid DPA CR, serviceAccountTokenAudience is set, no error case",
+			dpa: &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-DPA-CR",
+					Namespace: "test-ns",
+				},
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{
+							NoDefaultBackupLocation:     true,
+							ServiceAccountTokenAudience: "sts.amazonaws.com",
+						},
+					},
+					BackupImages: pointer.Bool(false),
+				},
 			},
-			EventRecorder: record.NewFakeRecorder(10),
-		}
-		t.Run(tt.name, func(t *testing.T) {
-			got, err := r.ValidateDataProtectionCR(r.Log)
-			if err != nil && !tt.wantErr {
-				t.Errorf("ValidateDataProtectionCR() error = %v, wantErr %v", err, tt.wantErr)
-				return
-			}
-			if tt.wantErr && err.Error() != tt.messageErr {
-				t.Errorf("Error messages are not the same: got %v, expected %v", err.Error(), tt.messageErr)
-				return
-			}
-			if got != !tt.wantErr {
-				t.Errorf("ValidateDataProtectionCR() got = %v, want %v", got, !tt.wantErr)
+			objects: []client.Object{},
+			wantErr: false,
+		},
+		{
+			name: "given invalid DPA CR, serviceAccountTokenAudience contains whitespace, error case",
+			dpa: &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-DPA-CR",
+					Namespace: "test-ns",
+				},
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{
+							NoDefaultBackupLocation:     true,
+							ServiceAccountTokenAudience: "sts amazonaws com",
+						},
+					},
+					BackupImages: pointer.Bool(false),
+				},
+			},
+			objects:    []client.Object{},
+			wantErr:    true,
+			messageErr: "serviceAccountTokenAudience \"sts amazonaws com\" must not contain whitespace",
+		},
+		{
+			name: "given invalid DPA CR, plugin config file configmap does not exist, error case",
+			dpa: &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-DPA-CR",
+					Namespace: "test-ns",
+				},
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{
+							NoDefaultBackupLocation: true,
+							PluginConfigFiles: []oadpv1alpha1.PluginConfigFile{
+								{ConfigMap: "custom-plugin-config", MountPath: "/opt/custom-plugin/config"},
+							},
+						},
+					},
+					BackupImages: pointer.Bool(false),
+				},
+			},
+			objects:    []client.Object{},
+			wantErr:    true,
+			messageErr: "pluginConfigFiles[0] configMap test-ns/custom-plugin-config: configmaps \"custom-plugin-config\" not found",
+		},
+		{
+			name: "given invalid DPA CR, plugin config file mounted over plugins volume, error case",
+			dpa: &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-DPA-CR",
+					Namespace: "test-ns",
+				},
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{
+							NoDefaultBackupLocation: true,
+							PluginConfigFiles: []oadpv1alpha1.PluginConfigFile{
+								{ConfigMap: "custom-plugin-config", MountPath: "/plugins/config"},
+							},
+						},
+					},
+					BackupImages: pointer.Bool(false),
+				},
+			},
+			objects: []client.Object{
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "custom-plugin-config",
+						Namespace: "test-ns",
+					},
+				},
+			},
+			wantErr:    true,
+			messageErr: "pluginConfigFiles[0] mountPath /plugins/config collides with plugins volume mounted at /plugins",
+		},
+		{
+			name: "given valid DPA CR, plugin config file configmap exists, no error case",
+			dpa: &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-DPA-CR",
+					Namespace: "test-ns",
+				},
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{
+							NoDefaultBackupLocation: true,
+							PluginConfigFiles: []oadpv1alpha1.PluginConfigFile{
+								{ConfigMap: "custom-plugin-config", MountPath: "/opt/custom-plugin/config"},
+							},
+						},
+					},
+					BackupImages: pointer.Bool(false),
+				},
+			},
+			objects: []client.Object{
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "custom-plugin-config",
+						Namespace: "test-ns",
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "given invalid DPA CR, unsafe sysctl on velero pod, error case",
+			dpa: &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-DPA-CR",
+					Namespace: "test-ns",
+				},
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{
+							NoDefaultBackupLocation: true,
+							Sysctls: []corev1.Sysctl{
+								{Name: "net.core.somaxconn", Value: "4096"},
+							},
+						},
+					},
+					BackupImages: pointer.Bool(false),
+				},
+			},
+			objects:    []client.Object{},
+			wantErr:    true,
+			messageErr: "sysctl net.core.somaxconn is not allowed on the Velero pod, use one of: kernel.shm_rmid_forced, net.ipv4.ip_local_port_range, net.ipv4.ip_local_reserved_ports, net.ipv4.ip_unprivileged_port_start, net.ipv4.ping_group_range, net.ipv4.tcp_syncookies",
+		},
+		{
+			name: "given invalid DPA CR, serviceAccountTokenExpirationSeconds below minimum, error case",
+			dpa: &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-DPA-CR",
+					Namespace: "test-ns",
+				},
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{
+							NoDefaultBackupLocation:              true,
+							ServiceAccountTokenExpirationSeconds: pointer.Int64(60),
+						},
+					},
+					BackupImages: pointer.Bool(false),
+				},
+			},
+			objects:    []client.Object{},
+			wantErr:    true,
+			messageErr: "serviceAccountTokenExpirationSeconds 60 must be between 600 and 4294967296",
+		},
+		{
+			name: "given valid DPA CR, plugin count above advisory threshold, no error case",
+			dpa: &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-DPA-CR",
+					Namespace: "test-ns",
+				},
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{
+							NoDefaultBackupLocation: true,
+							CustomPlugins:           testCustomPlugins(11),
+						},
+					},
+					BackupImages: pointer.Bool(false),
+				},
+			},
+			objects: []client.Object{},
+			wantErr: false,
+		},
+		{
+			name: "given invalid DPA CR, excessive plugin list, error case",
+			dpa: &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-DPA-CR",
+					Namespace: "test-ns",
+				},
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{
+							NoDefaultBackupLocation: true,
+							CustomPlugins:           testCustomPlugins(21),
+						},
+					},
+					BackupImages: pointer.Bool(false),
+				},
+			},
+			objects:    []client.Object{},
+			wantErr:    true,
+			messageErr: "DPA CR configures 21 plugins, which exceeds the maximum of 20",
+		},
+		{
+			name: "given valid DPA CR, excessive plugin list with max-plugin-count override, no error case",
+			dpa: &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-DPA-CR",
+					Namespace: "test-ns",
+				},
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{
+							NoDefaultBackupLocation: true,
+							CustomPlugins:           testCustomPlugins(21),
+						},
+					},
+					BackupImages: pointer.Bool(false),
+					UnsupportedOverrides: map[oadpv1alpha1.UnsupportedImageKey]string{
+						oadpv1alpha1.MaxPluginCountKey: "25",
+					},
+				},
+			},
+			objects: []client.Object{},
+			wantErr: false,
+		},
+		{
+			name: "given invalid DPA CR, invalid max-plugin-count override, error case",
+			dpa: &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-DPA-CR",
+					Namespace: "test-ns",
+				},
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{
+							NoDefaultBackupLocation: true,
+							CustomPlugins:           testCustomPlugins(1),
+						},
+					},
+					BackupImages: pointer.Bool(false),
+					UnsupportedOverrides: map[oadpv1alpha1.UnsupportedImageKey]string{
+						oadpv1alpha1.MaxPluginCountKey: "many",
+					},
+				},
+			},
+			objects:    []client.Object{},
+			wantErr:    true,
+			messageErr: "max-plugin-count override must be a positive integer, got \"many\"",
+		},
+		{
+			name: "given valid DPA CR, custom plugin distinct from default plugins, no error case",
+			dpa: &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-DPA-CR",
+					Namespace: "test-ns",
+				},
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{
+							NoDefaultBackupLocation: true,
+							DefaultPlugins: []oadpv1alpha1.DefaultPlugin{
+								oadpv1alpha1.DefaultPluginOpenShift,
+								oadpv1alpha1.DefaultPluginAWS,
+							},
+							CustomPlugins: []oadpv1alpha1.CustomPlugin{
+								{
+									Name:  "my-plugin",
+									Image: "quay.io/example/my-plugin:latest",
+								},
+							},
+						},
+					},
+					BackupImages: pointer.Bool(false),
+				},
+			},
+			objects: []client.Object{},
+			wantErr: false,
+		},
+		{
+			name: "given invalid DPA CR, custom plugin with default plugin name, error case",
+			dpa: &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-DPA-CR",
+					Namespace: "test-ns",
+				},
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{
+							NoDefaultBackupLocation: true,
+							DefaultPlugins: []oadpv1alpha1.DefaultPlugin{
+								oadpv1alpha1.DefaultPluginOpenShift,
+								oadpv1alpha1.DefaultPluginAWS,
+							},
+							CustomPlugins: []oadpv1alpha1.CustomPlugin{
+								{
+									Name:  "aws",
+									Image: "quay.io/example/my-aws-plugin:latest",
+								},
+							},
+						},
+					},
+					BackupImages: pointer.Bool(false),
+				},
+			},
+			objects:    []client.Object{},
+			wantErr:    true,
+			messageErr: "custom plugin aws duplicates default plugin aws, remove it from either defaultPlugins or customPlugins",
+		},
+		{
+			name: "given invalid DPA CR, custom plugin with default plugin image, error case",
+			dpa: &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-DPA-CR",
+					Namespace: "test-ns",
+				},
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{
+							NoDefaultBackupLocation: true,
+							DefaultPlugins: []oadpv1alpha1.DefaultPlugin{
+								oadpv1alpha1.DefaultPluginOpenShift,
+								oadpv1alpha1.DefaultPluginAWS,
+							},
+							CustomPlugins: []oadpv1alpha1.CustomPlugin{
+								{
+									Name:  "my-aws",
+									Image: "quay.io/example/velero-plugin-for-aws@sha256:0123456789abcdef",
+								},
+							},
+						},
+					},
+					BackupImages: pointer.Bool(false),
+				},
+			},
+			objects:    []client.Object{},
+			wantErr:    true,
+			messageErr: "custom plugin my-aws duplicates default plugin aws, remove it from either defaultPlugins or customPlugins",
+		},
+		{
+			name: "given invalid DPA CR, negative velero client-page-size, error case",
+			dpa: &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-DPA-CR",
+					Namespace: "test-ns",
+				},
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{
+							NoDefaultBackupLocation: true,
+							Args: &server.Args{
+								ServerConfig: server.ServerConfig{
+									ClientPageSize: pointer.Int(-1),
+								},
+							},
+						},
+					},
+					BackupImages: pointer.Bool(false),
+				},
+			},
+			objects:    []client.Object{},
+			wantErr:    true,
+			messageErr: "velero args client-page-size -1 must not be negative",
+		},
+		{
+			name: "given invalid DPA CR, non-positive defaultItemOperationTimeout, error case",
+			dpa: &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-DPA-CR",
+					Namespace: "test-ns",
+				},
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{
+							NoDefaultBackupLocation:     true,
+							DefaultItemOperationTimeout: "0s",
+						},
+					},
+					BackupImages: pointer.Bool(false),
+				},
+			},
+			objects:    []client.Object{},
+			wantErr:    true,
+			messageErr: "defaultItemOperationTimeout \"0s\" must be greater than zero",
+		},
+		{
+			name: "given invalid DPA CR, unparsable defaultItemOperationTimeout, error case",
+			dpa: &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-DPA-CR",
+					Namespace: "test-ns",
+				},
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{
+							NoDefaultBackupLocation:     true,
+							DefaultItemOperationTimeout: "forever",
+						},
+					},
+					BackupImages: pointer.Bool(false),
+				},
+			},
+			objects:    []client.Object{},
+			wantErr:    true,
+			messageErr: "defaultItemOperationTimeout \"forever\" is not a valid duration: time: invalid duration \"forever\"",
+		},
+		{
+			name: "given invalid DPA CR, velero command not invoking velero, error case",
+			dpa: &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-DPA-CR",
+					Namespace: "test-ns",
+				},
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{
+							NoDefaultBackupLocation: true,
+							Command:                 []string{"/bin/sh", "-c", "sleep infinity"},
+						},
+					},
+					BackupImages: pointer.Bool(false),
+				},
+			},
+			objects:    []client.Object{},
+			wantErr:    true,
+			messageErr: "velero command \"/bin/sh -c sleep infinity\" must invoke the velero binary, for example [\"/wrapper\", \"/velero\"]",
+		},
+		{
+			name: "given invalid DPA CR, defaultSnapshotMoveData without node agent, error case",
+			dpa: &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-DPA-CR",
+					Namespace: "test-ns",
+				},
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{
+							NoDefaultBackupLocation: true,
+							DefaultSnapshotMoveData: pointer.Bool(true),
+						},
+						NodeAgent: &oadpv1alpha1.NodeAgentConfig{
+							NodeAgentCommonFields: oadpv1alpha1.NodeAgentCommonFields{
+								Enable: pointer.Bool(false),
+							},
+							UploaderType: "kopia",
+						},
+					},
+					BackupImages: pointer.Bool(false),
+				},
+			},
+			objects: []client.Object{
+				&v1.BackupStorageLocation{ObjectMeta: metav1.ObjectMeta{Name: "user-bsl", Namespace: "test-ns"}},
+			},
+			wantErr:    true,
+			messageErr: "defaultSnapshotMoveData requires the node agent, which runs the data mover, set nodeAgent.enable to true",
+		},
+		{
+			name: "given valid DPA CR, defaultSnapshotMoveData with node agent, no error case",
+			dpa: &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-DPA-CR",
+					Namespace: "test-ns",
+				},
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{
+							NoDefaultBackupLocation: true,
+							DefaultSnapshotMoveData: pointer.Bool(true),
+						},
+						NodeAgent: &oadpv1alpha1.NodeAgentConfig{
+							NodeAgentCommonFields: oadpv1alpha1.NodeAgentCommonFields{
+								Enable: pointer.Bool(true),
+							},
+							UploaderType: "kopia",
+						},
+					},
+					BackupImages: pointer.Bool(false),
+				},
+			},
+			objects: []client.Object{
+				&v1.BackupStorageLocation{ObjectMeta: metav1.ObjectMeta{Name: "user-bsl", Namespace: "test-ns"}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "given invalid DPA CR, defaultSnapshotMoveData with noDefaultBackupLocation and no backup storage location, error case",
+			dpa: &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-DPA-CR",
+					Namespace: "test-ns",
+				},
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{
+							NoDefaultBackupLocation: true,
+							DefaultSnapshotMoveData: pointer.Bool(true),
+						},
+						NodeAgent: &oadpv1alpha1.NodeAgentConfig{
+							NodeAgentCommonFields: oadpv1alpha1.NodeAgentCommonFields{
+								Enable: pointer.Bool(true),
+							},
+							UploaderType: "kopia",
+						},
+					},
+					BackupImages: pointer.Bool(false),
+				},
+			},
+			objects:    []client.Object{},
+			wantErr:    true,
+			messageErr: "noDefaultBackupLocation cannot be used when snapshotMoveData/data-mover is enabled because moved data requires a backup storage location",
+		},
+		{
+			name: "given valid DPA CR, snapshot location only with noDefaultBackupLocation and missing snapshot location secret, error case",
+			dpa: &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-DPA-CR",
+					Namespace: "test-ns",
+				},
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{
+							DefaultPlugins: []oadpv1alpha1.DefaultPlugin{
+								oadpv1alpha1.DefaultPluginAWS,
+							},
+							NoDefaultBackupLocation: true,
+						},
+					},
+					SnapshotLocations: []oadpv1alpha1.SnapshotLocation{
+						{
+							Velero: &v1.VolumeSnapshotLocationSpec{
+								Provider: "aws",
+								Config: map[string]string{
+									AWSRegion: "us-east-1",
+								},
+								Credential: &corev1.SecretKeySelector{
+									LocalObjectReference: corev1.LocalObjectReference{Name: "custom-vsl-credentials"},
+									Key:                  "cloud",
+								},
+							},
+						},
+					},
+					BackupImages: pointer.Bool(false),
+				},
+			},
+			objects: []client.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "cloud-credentials",
+						Namespace: "test-ns",
+					},
+				},
+			},
+			wantErr:    true,
+			messageErr: "secrets \"custom-vsl-credentials\" not found",
+		},
+		{
+			name: "given valid DPA CR, snapshot location only with noDefaultBackupLocation and snapshot location secret, no error case",
+			dpa: &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-DPA-CR",
+					Namespace: "test-ns",
+				},
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{
+							DefaultPlugins: []oadpv1alpha1.DefaultPlugin{
+								oadpv1alpha1.DefaultPluginAWS,
+							},
+							NoDefaultBackupLocation: true,
+						},
+					},
+					SnapshotLocations: []oadpv1alpha1.SnapshotLocation{
+						{
+							Velero: &v1.VolumeSnapshotLocationSpec{
+								Provider: "aws",
+								Config: map[string]string{
+									AWSRegion: "us-east-1",
+								},
+							},
+						},
+					},
+					BackupImages: pointer.Bool(false),
+				},
+			},
+			objects: []client.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "cloud-credentials",
+						Namespace: "test-ns",
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "given invalid DPA CR, velero ephemeral-storage request above limit, error case",
+			dpa: &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-DPA-CR",
+					Namespace: "test-ns",
+				},
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{
+							NoDefaultBackupLocation: true,
+							PodConfig: &oadpv1alpha1.PodConfig{
+								ResourceAllocations: corev1.ResourceRequirements{
+									Limits: corev1.ResourceList{
+										corev1.ResourceEphemeralStorage: resource.MustParse("1Gi"),
+									},
+									Requests: corev1.ResourceList{
+										corev1.ResourceEphemeralStorage: resource.MustParse("2Gi"),
+									},
+								},
+							},
+						},
+					},
+					BackupImages: pointer.Bool(false),
+				},
+			},
+			objects:    []client.Object{},
+			wantErr:    true,
+			messageErr: "velero ephemeral-storage request 2Gi must be less than or equal to limit 1Gi",
+		},
+		{
+			name: "given invalid DPA CR, velero memory limit below request, error case",
+			dpa: &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-DPA-CR",
+					Namespace: "test-ns",
+				},
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{
+							NoDefaultBackupLocation: true,
+							PodConfig: &oadpv1alpha1.PodConfig{
+								ResourceAllocations: corev1.ResourceRequirements{
+									Limits: corev1.ResourceList{
+										corev1.ResourceMemory: resource.MustParse("256Mi"),
+									},
+									Requests: corev1.ResourceList{
+										corev1.ResourceMemory: resource.MustParse("512Mi"),
+									},
+								},
+							},
+						},
+					},
+					BackupImages: pointer.Bool(false),
+				},
+			},
+			objects:    []client.Object{},
+			wantErr:    true,
+			messageErr: "velero container memory limit (256Mi) is less than request (512Mi)",
+		},
+		{
+			name: "given invalid DPA CR, nodeAgent cpu limit below default request, error case",
+			dpa: &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-DPA-CR",
+					Namespace: "test-ns",
+				},
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{
+							NoDefaultBackupLocation: true,
+						},
+						NodeAgent: &oadpv1alpha1.NodeAgentConfig{
+							NodeAgentCommonFields: oadpv1alpha1.NodeAgentCommonFields{
+								PodConfig: &oadpv1alpha1.PodConfig{
+									ResourceAllocations: corev1.ResourceRequirements{
+										Limits: corev1.ResourceList{
+											corev1.ResourceCPU: resource.MustParse("250m"),
+										},
+									},
+								},
+							},
+							UploaderType: "kopia",
+						},
+					},
+					BackupImages: pointer.Bool(false),
+				},
+			},
+			objects:    []client.Object{},
+			wantErr:    true,
+			messageErr: "nodeAgent container cpu limit (250m) is less than request (500m)",
+		},
+		{
+			name: "given valid DPA CR, restoreOnlyMode with paused schedule, no error case",
+			dpa: &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-DPA-CR",
+					Namespace: "test-ns",
+				},
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{
+							NoDefaultBackupLocation: true,
+							RestoreOnlyMode:         pointer.Bool(true),
+						},
+					},
+					BackupImages: pointer.Bool(false),
+				},
+			},
+			objects: []client.Object{
+				&v1.Schedule{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "daily",
+						Namespace: "test-ns",
+					},
+					Spec: v1.ScheduleSpec{
+						Schedule: "@daily",
+						Paused:   true,
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "given invalid DPA CR, restoreOnlyMode with active schedule, error case",
+			dpa: &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-DPA-CR",
+					Namespace: "test-ns",
+				},
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{
+							NoDefaultBackupLocation: true,
+							RestoreOnlyMode:         pointer.Bool(true),
+						},
+					},
+					BackupImages: pointer.Bool(false),
+				},
+			},
+			objects: []client.Object{
+				&v1.Schedule{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "daily",
+						Namespace: "test-ns",
+					},
+					Spec: v1.ScheduleSpec{
+						Schedule: "@daily",
+					},
+				},
+			},
+			wantErr:    true,
+			messageErr: "restoreOnlyMode cannot be enabled while schedules are active, pause or delete schedules: daily",
+		},
+		{
+			name: "given valid DPA CR, disableGarbageCollection with schedule without ttl, no error case",
+			dpa: &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-DPA-CR",
+					Namespace: "test-ns",
+				},
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{
+							NoDefaultBackupLocation:  true,
+							DisableGarbageCollection: pointer.Bool(true),
+						},
+					},
+					BackupImages: pointer.Bool(false),
+				},
+			},
+			objects: []client.Object{
+				&v1.Schedule{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "daily",
+						Namespace: "test-ns",
+					},
+					Spec: v1.ScheduleSpec{
+						Schedule: "@daily",
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "given invalid DPA CR, disableGarbageCollection with schedule with ttl, error case",
+			dpa: &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-DPA-CR",
+					Namespace: "test-ns",
+				},
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{
+							NoDefaultBackupLocation:  true,
+							DisableGarbageCollection: pointer.Bool(true),
+						},
+					},
+					BackupImages: pointer.Bool(false),
+				},
+			},
+			objects: []client.Object{
+				&v1.Schedule{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "daily",
+						Namespace: "test-ns",
+					},
+					Spec: v1.ScheduleSpec{
+						Schedule: "@daily",
+						Template: v1.BackupSpec{
+							TTL: metav1.Duration{Duration: 24 * time.Hour},
+						},
+					},
+				},
+			},
+			wantErr:    true,
+			messageErr: "disableGarbageCollection cannot be enabled while schedules rely on backup ttl expiry, remove the ttl from or pause schedules: daily",
+		},
+		{
+			name: "given valid DPA CR, nodeAgent maxUnavailable percentage, no error case",
+			dpa: &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-DPA-CR",
+					Namespace: "test-ns",
+				},
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{
+							NoDefaultBackupLocation: true,
+						},
+						NodeAgent: &oadpv1alpha1.NodeAgentConfig{
+							NodeAgentCommonFields: oadpv1alpha1.NodeAgentCommonFields{
+								PodConfig: &oadpv1alpha1.PodConfig{
+									MaxUnavailable: &intstr.IntOrString{Type: intstr.String, StrVal: "10%"},
+								},
+							},
+						},
+					},
+					BackupImages: pointer.Bool(false),
+				},
+			},
+			objects: []client.Object{},
+			wantErr: false,
+		},
+		{
+			name: "given invalid DPA CR, nodeAgent maxUnavailable is zero, error case",
+			dpa: &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-DPA-CR",
+					Namespace: "test-ns",
+				},
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{
+							NoDefaultBackupLocation: true,
+						},
+						NodeAgent: &oadpv1alpha1.NodeAgentConfig{
+							NodeAgentCommonFields: oadpv1alpha1.NodeAgentCommonFields{
+								PodConfig: &oadpv1alpha1.PodConfig{
+									MaxUnavailable: &intstr.IntOrString{Type: intstr.Int, IntVal: 0},
+								},
+							},
+						},
+					},
+					BackupImages: pointer.Bool(false),
+				},
+			},
+			objects:    []client.Object{},
+			wantErr:    true,
+			messageErr: "nodeAgent maxUnavailable 0 must be greater than 0",
+		},
+		{
+			name: "given invalid DPA CR, nodeAgent maxUnavailable percentage above 100, error case",
+			dpa: &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-DPA-CR",
+					Namespace: "test-ns",
+				},
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{
+							NoDefaultBackupLocation: true,
+						},
+						NodeAgent: &oadpv1alpha1.NodeAgentConfig{
+							NodeAgentCommonFields: oadpv1alpha1.NodeAgentCommonFields{
+								PodConfig: &oadpv1alpha1.PodConfig{
+									MaxUnavailable: &intstr.IntOrString{Type: intstr.String, StrVal: "150%"},
+								},
+							},
+						},
+					},
+					BackupImages: pointer.Bool(false),
+				},
+			},
+			objects:    []client.Object{},
+			wantErr:    true,
+			messageErr: "nodeAgent maxUnavailable \"150%\" must be a percentage between 1% and 100%",
+		},
+		{
+			name: "given invalid DPA CR with several independent errors, all errors are returned",
+			dpa: &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-DPA-CR",
+					Namespace: "test-ns",
+				},
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{
+							NoDefaultBackupLocation: true,
+							Sysctls:                 []corev1.Sysctl{{Name: "kernel.shm_rmid_forced", Value: "1"}, {Name: "vm.swappiness", Value: "10"}},
+						},
+						NodeAgent: &oadpv1alpha1.NodeAgentConfig{
+							NodeAgentCommonFields: oadpv1alpha1.NodeAgentCommonFields{
+								PodConfig: &oadpv1alpha1.PodConfig{
+									MaxUnavailable: &intstr.IntOrString{Type: intstr.Int, IntVal: 0},
+								},
+							},
+						},
+					},
+					BackupMaintenanceWindow: &oadpv1alpha1.BackupMaintenanceWindow{Start: "25:00", Duration: metav1.Duration{Duration: time.Hour}},
+					BackupImages:            pointer.Bool(false),
+				},
+			},
+			objects:    []client.Object{},
+			wantErr:    true,
+			messageErr: "backupMaintenanceWindow start \"25:00\" must be a time of day in HH:MM format\nsysctl vm.swappiness is not allowed on the Velero pod, use one of: " + strings.Join(sortedKeys(safeSysctls), ", ") + "\nnodeAgent maxUnavailable 0 must be greater than 0",
+		},
+		{
+			name: "given invalid DPA CR, restoreResourcePrioritiesConfigMap is missing the priorities key, error case",
+			dpa: &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-DPA-CR",
+					Namespace: "test-ns",
+				},
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{
+							NoDefaultBackupLocation:            true,
+							RestoreResourcePrioritiesConfigMap: "restore-priorities",
+						},
+					},
+					BackupImages: pointer.Bool(false),
+				},
+			},
+			objects: []client.Object{
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "restore-priorities",
+						Namespace: "test-ns",
+					},
+					Data: map[string]string{"priorities": "namespaces"},
+				},
+			},
+			wantErr:    true,
+			messageErr: "restoreResourcePrioritiesConfigMap test-ns/restore-priorities is missing data for key restoreResourcePriorities",
+		},
+	}
+	for _, tt := range tests {
+		tt.objects = append(tt.objects, tt.dpa)
+		fakeClient, err := getFakeClientFromObjects(tt.objects...)
+		if err != nil {
+			t.Errorf("error in creating fake client, likely programmer error")
+		}
+		r := &DPAReconciler{
+			Client:  fakeClient,
+			Scheme:  fakeClient.Scheme(),
+			Log:     logr.Discard(),
+			Context: newContextForTest(tt.name),
+			NamespacedName: types.NamespacedName{
+				Namespace: tt.dpa.Namespace,
+				Name:      tt.dpa.Name,
+			},
+			EventRecorder: record.NewFakeRecorder(10),
+		}
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := r.ValidateDataProtectionCR(r.Log)
+			if err != nil && !tt.wantErr {
+				t.Errorf("ValidateDataProtectionCR() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr && err.Error() != tt.messageErr {
+				t.Errorf("Error messages are not the same: got %v, expected %v", err.Error(), tt.messageErr)
+				return
+			}
+			if got != !tt.wantErr {
+				t.Errorf("ValidateDataProtectionCR() got = %v, want %v", got, !tt.wantErr)
+			}
+		})
+	}
+}
+
+// forbiddenListClient fails every List of the given list types as if the operator had no RBAC to read them
+type forbiddenListClient struct {
+	client.Client
+	forbidden []client.ObjectList
+}
+
+func (c *forbiddenListClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	for _, forbidden := range c.forbidden {
+		if reflect.TypeOf(list) == reflect.TypeOf(forbidden) {
+			return k8serror.NewForbidden(schema.GroupResource{}, "", fmt.Errorf("forbidden"))
+		}
+	}
+	return c.Client.List(ctx, list, opts...)
+}
+
+func TestDPAReconciler_ValidateDataProtectionCR_advisoryLookupFailure(t *testing.T) {
+	dpa := &oadpv1alpha1.DataProtectionApplication{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-DPA-CR",
+			Namespace: "test-ns",
+		},
+		Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+			Configuration: &oadpv1alpha1.ApplicationConfig{
+				Velero: &oadpv1alpha1.VeleroConfig{
+					NoDefaultBackupLocation: true,
+					PodConfig: &oadpv1alpha1.PodConfig{
+						ResourceAllocations: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m")},
+						},
+					},
+				},
+				NodeAgent: &oadpv1alpha1.NodeAgentConfig{
+					NodeAgentCommonFields: oadpv1alpha1.NodeAgentCommonFields{Enable: pointer.Bool(true)},
+					UploaderType:          "kopia",
+				},
+			},
+			BackupImages: pointer.Bool(false),
+		},
+	}
+	fakeClient, err := getFakeClientFromObjects(dpa)
+	if err != nil {
+		t.Errorf("error in creating fake client, likely programmer error")
+	}
+	r := &DPAReconciler{
+		Client:  &forbiddenListClient{Client: fakeClient, forbidden: []client.ObjectList{&corev1.NodeList{}, &corev1.LimitRangeList{}, &appsv1.DaemonSetList{}}},
+		Scheme:  fakeClient.Scheme(),
+		Log:     logr.Discard(),
+		Context: newContextForTest("advisory lookup failure"),
+		NamespacedName: types.NamespacedName{
+			Namespace: dpa.Namespace,
+			Name:      dpa.Name,
+		},
+		EventRecorder: record.NewFakeRecorder(10),
+	}
+	// advisories only warn, failing to look up what they check must not fail validation
+	if got, err := r.ValidateDataProtectionCR(r.Log); !got || err != nil {
+		t.Errorf("ValidateDataProtectionCR() got = %v, error = %v, want true and no error", got, err)
+	}
+}
+
+func testCustomPlugins(count int) []oadpv1alpha1.CustomPlugin {
+	plugins := []oadpv1alpha1.CustomPlugin{}
+	for i := 0; i < count; i++ {
+		plugins = append(plugins, oadpv1alpha1.CustomPlugin{
+			Name:  fmt.Sprintf("custom-plugin-%d", i),
+			Image: fmt.Sprintf("quay.io/example/custom-plugin-%d:latest", i),
+		})
+	}
+	return plugins
+}
+
+func TestDPAReconciler_warnStaleResticSecretKeys(t *testing.T) {
+	tests := []struct {
+		name          string
+		dpa           *oadpv1alpha1.DataProtectionApplication
+		secret        *corev1.Secret
+		wantStaleKeys map[string][]string
+		wantEvents    int
+	}{
+		{
+			name: "migrated config to nodeAgent with stale restic keys",
+			dpa: &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-DPA-CR",
+					Namespace: "test-ns",
+				},
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{},
+						NodeAgent: &oadpv1alpha1.NodeAgentConfig{
+							UploaderType: "kopia",
+						},
+					},
+					BackupLocations: []oadpv1alpha1.BackupLocation{
+						{
+							Velero: &v1.BackupStorageLocationSpec{
+								Provider: "aws",
+							},
+						},
+					},
+				},
+			},
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "cloud-credentials",
+					Namespace: "test-ns",
+				},
+				Data: map[string][]byte{
+					"cloud":             []byte("dummy_data"),
+					"RESTIC_REPOSITORY": []byte("s3:s3.amazonaws.com/bucket"),
+					"RESTIC_PASSWORD":   []byte("dummy_password"),
+				},
+			},
+			wantStaleKeys: map[string][]string{
+				"cloud-credentials": {"RESTIC_PASSWORD", "RESTIC_REPOSITORY"},
+			},
+			wantEvents: 1,
+		},
+		{
+			name: "nodeAgent config without stale restic keys",
+			dpa: &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-DPA-CR",
+					Namespace: "test-ns",
+				},
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero:    &oadpv1alpha1.VeleroConfig{},
+						NodeAgent: &oadpv1alpha1.NodeAgentConfig{},
+					},
+					BackupLocations: []oadpv1alpha1.BackupLocation{
+						{
+							Velero: &v1.BackupStorageLocationSpec{
+								Provider: "aws",
+							},
+						},
+					},
+				},
+			},
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "cloud-credentials",
+					Namespace: "test-ns",
+				},
+				Data: map[string][]byte{"cloud": []byte("dummy_data")},
+			},
+			wantStaleKeys: map[string][]string{},
+			wantEvents:    0,
+		},
+		{
+			name: "restic config keeps restic keys",
+			dpa: &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-DPA-CR",
+					Namespace: "test-ns",
+				},
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{},
+						Restic: &oadpv1alpha1.ResticConfig{},
+					},
+					BackupLocations: []oadpv1alpha1.BackupLocation{
+						{
+							Velero: &v1.BackupStorageLocationSpec{
+								Provider: "aws",
+							},
+						},
+					},
+				},
+			},
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "cloud-credentials",
+					Namespace: "test-ns",
+				},
+				Data: map[string][]byte{
+					"cloud":           []byte("dummy_data"),
+					"RESTIC_PASSWORD": []byte("dummy_password"),
+				},
+			},
+			wantStaleKeys: map[string][]string{},
+			wantEvents:    0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient, err := getFakeClientFromObjects(tt.dpa, tt.secret)
+			if err != nil {
+				t.Errorf("error in creating fake client, likely programmer error")
+			}
+			recorder := record.NewFakeRecorder(10)
+			r := &DPAReconciler{
+				Client:  fakeClient,
+				Scheme:  fakeClient.Scheme(),
+				Log:     logr.Discard(),
+				Context: newContextForTest(tt.name),
+				NamespacedName: types.NamespacedName{
+					Namespace: tt.dpa.Namespace,
+					Name:      tt.dpa.Name,
+				},
+				EventRecorder: recorder,
+			}
+			got, err := r.staleResticSecretKeys(tt.dpa)
+			if err != nil {
+				t.Errorf("staleResticSecretKeys() unexpected error = %v", err)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.wantStaleKeys) {
+				t.Errorf("staleResticSecretKeys() got = %v, want %v", got, tt.wantStaleKeys)
+			}
+			r.warnStaleResticSecretKeys(r.Log, tt.dpa)
+			if len(recorder.Events) != tt.wantEvents {
+				t.Errorf("warnStaleResticSecretKeys() emitted %d events, want %d", len(recorder.Events), tt.wantEvents)
+			}
+		})
+	}
+}
+
+func TestDPAReconciler_warnMissingResourceRequests(t *testing.T) {
+	limitRange := &corev1.LimitRange{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "resource-limits",
+			Namespace: "test-ns",
+		},
+		Spec: corev1.LimitRangeSpec{
+			Limits: []corev1.LimitRangeItem{
+				{
+					Type: corev1.LimitTypeContainer,
+					Min: corev1.ResourceList{
+						corev1.ResourceCPU: resource.MustParse("1"),
+					},
+				},
+			},
+		},
+	}
+	tests := []struct {
+		name       string
+		dpa        *oadpv1alpha1.DataProtectionApplication
+		objects    []client.Object
+		wantEvents int
+	}{
+		{
+			name: "LimitRange present, Velero and NodeAgent without requests",
+			dpa: &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-DPA-CR",
+					Namespace: "test-ns",
+				},
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{},
+						NodeAgent: &oadpv1alpha1.NodeAgentConfig{
+							NodeAgentCommonFields: oadpv1alpha1.NodeAgentCommonFields{
+								Enable: pointer.Bool(true),
+							},
+						},
+					},
+				},
+			},
+			objects:    []client.Object{limitRange},
+			wantEvents: 2,
+		},
+		{
+			name: "LimitRange present, Velero with requests and NodeAgent disabled",
+			dpa: &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-DPA-CR",
+					Namespace: "test-ns",
+				},
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{
+							PodConfig: &oadpv1alpha1.PodConfig{
+								ResourceAllocations: corev1.ResourceRequirements{
+									Requests: corev1.ResourceList{
+										corev1.ResourceCPU: resource.MustParse("1"),
+									},
+								},
+							},
+						},
+						NodeAgent: &oadpv1alpha1.NodeAgentConfig{},
+					},
+				},
+			},
+			objects:    []client.Object{limitRange},
+			wantEvents: 0,
+		},
+		{
+			name: "no LimitRange, Velero without requests",
+			dpa: &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-DPA-CR",
+					Namespace: "test-ns",
+				},
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{},
+					},
+				},
+			},
+			objects:    []client.Object{},
+			wantEvents: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient, err := getFakeClientFromObjects(append(tt.objects, tt.dpa)...)
+			if err != nil {
+				t.Errorf("error in creating fake client, likely programmer error")
+			}
+			recorder := record.NewFakeRecorder(10)
+			r := &DPAReconciler{
+				Client:  fakeClient,
+				Scheme:  fakeClient.Scheme(),
+				Log:     logr.Discard(),
+				Context: newContextForTest(tt.name),
+				NamespacedName: types.NamespacedName{
+					Namespace: tt.dpa.Namespace,
+					Name:      tt.dpa.Name,
+				},
+				EventRecorder: recorder,
+			}
+			r.warnMissingResourceRequests(r.Log, tt.dpa)
+			if len(recorder.Events) != tt.wantEvents {
+				t.Errorf("warnMissingResourceRequests() emitted %d events, want %d", len(recorder.Events), tt.wantEvents)
+			}
+		})
+	}
+}
+
+func TestDPAReconciler_dualPurposeCredentialSecrets(t *testing.T) {
+	dpa := &oadpv1alpha1.DataProtectionApplication{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-DPA-CR",
+			Namespace: "test-ns",
+		},
+		Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+			Configuration: &oadpv1alpha1.ApplicationConfig{
+				Velero: &oadpv1alpha1.VeleroConfig{},
+			},
+			BackupLocations: []oadpv1alpha1.BackupLocation{
+				{
+					Velero: &v1.BackupStorageLocationSpec{
+						Provider: "aws",
+					},
+				},
+			},
+		},
+	}
+	tests := []struct {
+		name            string
+		objects         []client.Object
+		wantOtherUsages map[string][]string
+		wantEvents      int
+	}{
+		{
+			name: "credential secret also used as image pull secret",
+			objects: []client.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "cloud-credentials",
+						Namespace: "test-ns",
+					},
+					Type: corev1.SecretTypeDockerConfigJson,
+					Data: map[string][]byte{
+						"cloud":                    []byte("dummy_data"),
+						corev1.DockerConfigJsonKey: []byte("{}"),
+					},
+				},
+				&corev1.ServiceAccount{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "builder",
+						Namespace: "test-ns",
+					},
+					ImagePullSecrets: []corev1.LocalObjectReference{{Name: "cloud-credentials"}},
+				},
+			},
+			wantOtherUsages: map[string][]string{
+				"cloud-credentials": {
+					"a kubernetes.io/dockerconfigjson secret",
+					"an image pull secret of service account builder",
+				},
+			},
+			wantEvents: 1,
+		},
+		{
+			name: "dedicated credential secret",
+			objects: []client.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "cloud-credentials",
+						Namespace: "test-ns",
+					},
+					Type: corev1.SecretTypeOpaque,
+					Data: map[string][]byte{"cloud": []byte("dummy_data")},
+				},
+				&corev1.ServiceAccount{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "builder",
+						Namespace: "test-ns",
+					},
+					ImagePullSecrets: []corev1.LocalObjectReference{{Name: "builder-dockercfg"}},
+				},
+			},
+			wantOtherUsages: map[string][]string{},
+			wantEvents:      0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient, err := getFakeClientFromObjects(append(tt.objects, dpa)...)
+			if err != nil {
+				t.Errorf("error in creating fake client, likely programmer error")
+			}
+			recorder := record.NewFakeRecorder(10)
+			r := &DPAReconciler{
+				Client:  fakeClient,
+				Scheme:  fakeClient.Scheme(),
+				Log:     logr.Discard(),
+				Context: newContextForTest(tt.name),
+				NamespacedName: types.NamespacedName{
+					Namespace: dpa.Namespace,
+					Name:      dpa.Name,
+				},
+				EventRecorder: recorder,
+			}
+			got, err := r.dualPurposeCredentialSecrets(dpa)
+			if err != nil {
+				t.Errorf("dualPurposeCredentialSecrets() unexpected error = %v", err)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.wantOtherUsages) {
+				t.Errorf("dualPurposeCredentialSecrets() got = %v, want %v", got, tt.wantOtherUsages)
+			}
+			r.warnDualPurposeCredentialSecrets(r.Log, dpa)
+			if len(recorder.Events) != tt.wantEvents {
+				t.Errorf("warnDualPurposeCredentialSecrets() emitted %d events, want %d", len(recorder.Events), tt.wantEvents)
+			}
+		})
+	}
+}
+
+func Test_ignoredFieldsForOperatorMode(t *testing.T) {
+	tests := []struct {
+		name        string
+		dpa         *oadpv1alpha1.DataProtectionApplication
+		wantIgnored []ignoredField
+	}{
+		{
+			name: "MTC mode with no-secret feature flag and cloud provider plugin",
+			dpa: &oadpv1alpha1.DataProtectionApplication{
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{
+							DefaultPlugins: []oadpv1alpha1.DefaultPlugin{
+								oadpv1alpha1.DefaultPluginAWS,
+								oadpv1alpha1.DefaultPluginOpenShift,
+							},
+							FeatureFlags:            []string{"no-secret"},
+							NoDefaultBackupLocation: true,
+						},
+					},
+					UnsupportedOverrides: map[oadpv1alpha1.UnsupportedImageKey]string{
+						oadpv1alpha1.OperatorTypeKey: oadpv1alpha1.OperatorTypeMTC,
+					},
+				},
+			},
+			wantIgnored: []ignoredField{
+				{
+					field:  "spec.configuration.velero.featureFlags no-secret",
+					reason: "default cloud provider credentials are always mounted for MTC",
+				},
+				{
+					field:  "spec.configuration.velero.noDefaultBackupLocation",
+					reason: "default credentials secret cloud-credentials of the aws plugin is still mounted for MTC",
+				},
+			},
+		},
+		{
+			name: "MTC mode with backup locations",
+			dpa: &oadpv1alpha1.DataProtectionApplication{
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{
+							DefaultPlugins: []oadpv1alpha1.DefaultPlugin{
+								oadpv1alpha1.DefaultPluginAWS,
+							},
+						},
+					},
+					UnsupportedOverrides: map[oadpv1alpha1.UnsupportedImageKey]string{
+						oadpv1alpha1.OperatorTypeKey: oadpv1alpha1.OperatorTypeMTC,
+					},
+				},
+			},
+			wantIgnored: []ignoredField{},
+		},
+		{
+			name: "default mode with no-secret feature flag",
+			dpa: &oadpv1alpha1.DataProtectionApplication{
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{
+							DefaultPlugins: []oadpv1alpha1.DefaultPlugin{
+								oadpv1alpha1.DefaultPluginAWS,
+							},
+							FeatureFlags:            []string{"no-secret"},
+							NoDefaultBackupLocation: true,
+						},
+					},
+				},
+			},
+			wantIgnored: []ignoredField{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ignoredFieldsForOperatorMode(tt.dpa); !reflect.DeepEqual(got, tt.wantIgnored) {
+				t.Errorf("ignoredFieldsForOperatorMode() = %v, want %v", got, tt.wantIgnored)
+			}
+		})
+	}
+}
+
+func TestDPAReconciler_getCredentialResolutions(t *testing.T) {
+	tests := []struct {
+		name string
+		dpa  *oadpv1alpha1.DataProtectionApplication
+		want []oadpv1alpha1.ProviderCredentialResolution
+	}{
+		{
+			name: "backup location without credential uses default secret",
+			dpa: &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-DPA-CR",
+					Namespace: "test-ns",
+				},
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{
+							DefaultPlugins: []oadpv1alpha1.DefaultPlugin{
+								oadpv1alpha1.DefaultPluginAWS,
+								oadpv1alpha1.DefaultPluginCSI,
+							},
+						},
+					},
+					BackupLocations: []oadpv1alpha1.BackupLocation{
+						{
+							Velero: &v1.BackupStorageLocationSpec{
+								Provider: "aws",
+							},
+						},
+					},
+				},
+			},
+			want: []oadpv1alpha1.ProviderCredentialResolution{
+				{Provider: oadpv1alpha1.DefaultPluginAWS, Decision: oadpv1alpha1.CredentialDecisionDefaultUsed},
+				{Provider: oadpv1alpha1.DefaultPluginCSI, Decision: oadpv1alpha1.CredentialDecisionSkipped},
+			},
+		},
+		{
+			name: "snapshot location with custom credential needs check",
+			dpa: &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-DPA-CR",
+					Namespace: "test-ns",
+				},
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{
+							DefaultPlugins: []oadpv1alpha1.DefaultPlugin{
+								oadpv1alpha1.DefaultPluginAWS,
+							},
+						},
+					},
+					BackupLocations: []oadpv1alpha1.BackupLocation{
+						{
+							Velero: &v1.BackupStorageLocationSpec{
+								Provider: "aws",
+								Credential: &corev1.SecretKeySelector{
+									LocalObjectReference: corev1.LocalObjectReference{Name: "custom-bsl-credentials"},
+									Key:                  "cloud",
+								},
+							},
+						},
+					},
+					SnapshotLocations: []oadpv1alpha1.SnapshotLocation{
+						{
+							Velero: &v1.VolumeSnapshotLocationSpec{
+								Provider: "aws",
+								Credential: &corev1.SecretKeySelector{
+									LocalObjectReference: corev1.LocalObjectReference{Name: "custom-vsl-credentials"},
+									Key:                  "cloud",
+								},
+							},
+						},
+					},
+				},
+			},
+			want: []oadpv1alpha1.ProviderCredentialResolution{
+				{Provider: oadpv1alpha1.DefaultPluginAWS, Decision: oadpv1alpha1.CredentialDecisionNeedsCheck},
+			},
+		},
+		{
+			name: "backup location with custom credential only skips check",
+			dpa: &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-DPA-CR",
+					Namespace: "test-ns",
+				},
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{
+							DefaultPlugins: []oadpv1alpha1.DefaultPlugin{
+								oadpv1alpha1.DefaultPluginAWS,
+							},
+						},
+					},
+					BackupLocations: []oadpv1alpha1.BackupLocation{
+						{
+							Velero: &v1.BackupStorageLocationSpec{
+								Provider: "aws",
+								Credential: &corev1.SecretKeySelector{
+									LocalObjectReference: corev1.LocalObjectReference{Name: "custom-bsl-credentials"},
+									Key:                  "cloud",
+								},
+							},
+						},
+					},
+				},
+			},
+			want: []oadpv1alpha1.ProviderCredentialResolution{
+				{Provider: oadpv1alpha1.DefaultPluginAWS, Decision: oadpv1alpha1.CredentialDecisionSkipped},
+			},
+		},
+		{
+			name: "no default backup location skips check",
+			dpa: &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-DPA-CR",
+					Namespace: "test-ns",
+				},
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{
+							NoDefaultBackupLocation: true,
+							DefaultPlugins: []oadpv1alpha1.DefaultPlugin{
+								oadpv1alpha1.DefaultPluginGCP,
+							},
+						},
+					},
+				},
+			},
+			want: []oadpv1alpha1.ProviderCredentialResolution{
+				{Provider: oadpv1alpha1.DefaultPluginGCP, Decision: oadpv1alpha1.CredentialDecisionSkipped},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient, err := getFakeClientFromObjects(tt.dpa)
+			if err != nil {
+				t.Errorf("error in creating fake client, likely programmer error")
+			}
+			r := &DPAReconciler{
+				Client:  fakeClient,
+				Scheme:  fakeClient.Scheme(),
+				Log:     logr.Discard(),
+				Context: newContextForTest(tt.name),
+				NamespacedName: types.NamespacedName{
+					Namespace: tt.dpa.Namespace,
+					Name:      tt.dpa.Name,
+				},
+			}
+			got, err := r.getCredentialResolutions(tt.dpa)
+			if err != nil {
+				t.Errorf("getCredentialResolutions() unexpected error = %v", err)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getCredentialResolutions() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDPAReconciler_credentialValuesWithTrailingWhitespace(t *testing.T) {
+	dpa := &oadpv1alpha1.DataProtectionApplication{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-DPA-CR",
+			Namespace: "test-ns",
+		},
+		Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+			Configuration: &oadpv1alpha1.ApplicationConfig{
+				Velero: &oadpv1alpha1.VeleroConfig{},
+			},
+			BackupLocations: []oadpv1alpha1.BackupLocation{
+				{
+					Velero: &v1.BackupStorageLocationSpec{
+						Provider: "aws",
+					},
+				},
+			},
+		},
+	}
+	tests := []struct {
+		name                 string
+		credentials          string
+		wantSuspiciousValues map[string][]string
+		wantEvents           int
+	}{
+		{
+			name:                 "clean credentials",
+			credentials:          "[default]\naws_access_key_id=AKIAEXAMPLE\naws_secret_access_key=secret\n",
+			wantSuspiciousValues: map[string][]string{},
+			wantEvents:           0,
+		},
+		{
+			name:        "secret access key with trailing newline",
+			credentials: "[default]\naws_access_key_id=AKIAEXAMPLE\naws_secret_access_key=secret\r\n",
+			wantSuspiciousValues: map[string][]string{
+				"cloud-credentials": {"aws_secret_access_key"},
+			},
+			wantEvents: 1,
+		},
+		{
+			name:        "access key id with trailing space",
+			credentials: "[default]\naws_access_key_id = AKIAEXAMPLE \naws_secret_access_key = secret\n",
+			wantSuspiciousValues: map[string][]string{
+				"cloud-credentials": {"aws_access_key_id"},
+			},
+			wantEvents: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "cloud-credentials",
+					Namespace: "test-ns",
+				},
+				Data: map[string][]byte{"cloud": []byte(tt.credentials)},
+			}
+			fakeClient, err := getFakeClientFromObjects(dpa, secret)
+			if err != nil {
+				t.Errorf("error in creating fake client, likely programmer error")
+			}
+			recorder := record.NewFakeRecorder(10)
+			r := &DPAReconciler{
+				Client:  fakeClient,
+				Scheme:  fakeClient.Scheme(),
+				Log:     logr.Discard(),
+				Context: newContextForTest(tt.name),
+				NamespacedName: types.NamespacedName{
+					Namespace: dpa.Namespace,
+					Name:      dpa.Name,
+				},
+				EventRecorder: recorder,
+			}
+			got, err := r.credentialValuesWithTrailingWhitespace(dpa)
+			if err != nil {
+				t.Errorf("credentialValuesWithTrailingWhitespace() unexpected error = %v", err)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.wantSuspiciousValues) {
+				t.Errorf("credentialValuesWithTrailingWhitespace() got = %v, want %v", got, tt.wantSuspiciousValues)
+			}
+			r.warnCredentialTrailingWhitespace(r.Log, dpa)
+			if len(recorder.Events) != tt.wantEvents {
+				t.Errorf("warnCredentialTrailingWhitespace() emitted %d events, want %d", len(recorder.Events), tt.wantEvents)
+			}
+		})
+	}
+}
+
+func Test_deprecatedProviderAliasUsages(t *testing.T) {
+	tests := []struct {
+		name         string
+		bslProvider  string
+		vslProvider  string
+		wantMessages []string
+	}{
+		{
+			name:         "current provider names",
+			bslProvider:  "aws",
+			vslProvider:  "aws",
+			wantMessages: []string{},
+		},
+		{
+			name:        "deprecated provider aliases",
+			bslProvider: "velero.io/aws",
+			vslProvider: "velero.io/gcp",
+			wantMessages: []string{
+				"backupLocations[0] provider velero.io/aws is a deprecated alias, use aws instead",
+				"snapshotLocations[0] provider velero.io/gcp is a deprecated alias, use gcp instead",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dpa := &oadpv1alpha1.DataProtectionApplication{
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					BackupLocations: []oadpv1alpha1.BackupLocation{
+						{
+							Velero: &v1.BackupStorageLocationSpec{
+								Provider: tt.bslProvider,
+							},
+						},
+					},
+					SnapshotLocations: []oadpv1alpha1.SnapshotLocation{
+						{
+							Velero: &v1.VolumeSnapshotLocationSpec{
+								Provider: tt.vslProvider,
+							},
+						},
+					},
+				},
+			}
+			got := deprecatedProviderAliasUsages(dpa)
+			if !reflect.DeepEqual(got, tt.wantMessages) {
+				t.Errorf("deprecatedProviderAliasUsages() got = %v, want %v", got, tt.wantMessages)
+			}
+			recorder := record.NewFakeRecorder(10)
+			r := &DPAReconciler{EventRecorder: recorder}
+			r.warnDeprecatedProviderAliases(logr.Discard(), dpa)
+			if len(recorder.Events) != len(tt.wantMessages) {
+				t.Errorf("warnDeprecatedProviderAliases() emitted %d events, want %d", len(recorder.Events), len(tt.wantMessages))
+			}
+		})
+	}
+}
+
+func TestDPAReconciler_requestsExceedingNodeAllocatable(t *testing.T) {
+	newNode := func(name string, cpu string, memory string, unschedulable bool) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+			},
+			Spec: corev1.NodeSpec{
+				Unschedulable: unschedulable,
+			},
+			Status: corev1.NodeStatus{
+				Allocatable: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse(cpu),
+					corev1.ResourceMemory: resource.MustParse(memory),
+				},
+			},
+		}
+	}
+	dpa := &oadpv1alpha1.DataProtectionApplication{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-DPA-CR",
+			Namespace: "test-ns",
+		},
+		Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+			Configuration: &oadpv1alpha1.ApplicationConfig{
+				Velero: &oadpv1alpha1.VeleroConfig{
+					PodConfig: &oadpv1alpha1.PodConfig{
+						ResourceAllocations: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{
+								corev1.ResourceCPU:    resource.MustParse("1"),
+								corev1.ResourceMemory: resource.MustParse("1Gi"),
+							},
+						},
+					},
+				},
+				NodeAgent: &oadpv1alpha1.NodeAgentConfig{
+					NodeAgentCommonFields: oadpv1alpha1.NodeAgentCommonFields{
+						Enable: pointer.Bool(true),
+					},
+				},
+			},
+		},
+	}
+	tests := []struct {
+		name         string
+		nodes        []client.Object
+		wantMessages []string
+		wantEvents   int
+	}{
+		{
+			name: "requests fit on every node",
+			nodes: []client.Object{
+				newNode("large-node", "8", "32Gi", false),
+			},
+			wantMessages: []string{},
+			wantEvents:   0,
+		},
+		{
+			name: "velero requests exceed tiny node",
+			nodes: []client.Object{
+				newNode("large-node", "8", "32Gi", false),
+				newNode("tiny-node", "500m", "512Mi", false),
+			},
+			wantMessages: []string{
+				"velero cpu request 1 exceeds allocatable 500m of the smallest schedulable node tiny-node, pods may not schedule there",
+				"velero memory request 1Gi exceeds allocatable 512Mi of the smallest schedulable node tiny-node, pods may not schedule there",
+			},
+			wantEvents: 2,
+		},
+		{
+			name: "velero requests fit no node",
+			nodes: []client.Object{
+				newNode("tiny-node", "500m", "512Mi", false),
+				newNode("large-node", "8", "32Gi", true),
+			},
+			wantMessages: []string{
+				"velero cpu request 1 exceeds allocatable 500m of the smallest schedulable node tiny-node, pods may not schedule there",
+				"velero memory request 1Gi exceeds allocatable 512Mi of the smallest schedulable node tiny-node, pods may not schedule there",
+				"velero requests cpu 1, memory 1Gi fit no schedulable node, the velero pod will not schedule",
+			},
+			wantEvents: 3,
+		},
+		{
+			name: "unschedulable tiny node is ignored",
+			nodes: []client.Object{
+				newNode("large-node", "8", "32Gi", false),
+				newNode("tiny-node", "500m", "512Mi", true),
+			},
+			wantMessages: []string{},
+			wantEvents:   0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient, err := getFakeClientFromObjects(append(tt.nodes, dpa)...)
+			if err != nil {
+				t.Errorf("error in creating fake client, likely programmer error")
+			}
+			recorder := record.NewFakeRecorder(10)
+			r := &DPAReconciler{
+				Client:  fakeClient,
+				Scheme:  fakeClient.Scheme(),
+				Log:     logr.Discard(),
+				Context: newContextForTest(tt.name),
+				NamespacedName: types.NamespacedName{
+					Namespace: dpa.Namespace,
+					Name:      dpa.Name,
+				},
+				EventRecorder: recorder,
+			}
+			nodes := []corev1.Node{}
+			for _, node := range tt.nodes {
+				nodes = append(nodes, *node.(*corev1.Node))
+			}
+			got, err := r.requestsExceedingNodeAllocatable(dpa, nodes)
+			if err != nil {
+				t.Errorf("requestsExceedingNodeAllocatable() unexpected error = %v", err)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.wantMessages) {
+				t.Errorf("requestsExceedingNodeAllocatable() got = %v, want %v", got, tt.wantMessages)
+			}
+			r.warnRequestsExceedNodeAllocatable(r.Log, dpa)
+			if len(recorder.Events) != tt.wantEvents {
+				t.Errorf("warnRequestsExceedNodeAllocatable() emitted %d events, want %d", len(recorder.Events), tt.wantEvents)
+			}
+		})
+	}
+}
+
+func Test_requestsFitNoNode(t *testing.T) {
+	newNode := func(name string, cpu string, memory string, nodeLabels map[string]string) corev1.Node {
+		return corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: nodeLabels},
+			Status: corev1.NodeStatus{
+				Allocatable: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse(cpu),
+					corev1.ResourceMemory: resource.MustParse(memory),
+				},
+			},
+		}
+	}
+	infraLabels := map[string]string{"node-role.kubernetes.io/infra": ""}
+	requests := corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("2"),
+		corev1.ResourceMemory: resource.MustParse("4Gi"),
+	}
+	tests := []struct {
+		name         string
+		nodeSelector map[string]string
+		nodes        []corev1.Node
+		wantMessage  string
+	}{
+		{
+			name:         "a matching node fits the requests",
+			nodeSelector: infraLabels,
+			nodes:        []corev1.Node{newNode("infra-node", "4", "8Gi", infraLabels)},
+		},
+		{
+			name:         "matching nodes are too small",
+			nodeSelector: infraLabels,
+			nodes: []corev1.Node{
+				newNode("infra-cpu-node", "4", "2Gi", infraLabels),
+				newNode("infra-memory-node", "1", "8Gi", infraLabels),
+				newNode("worker-node", "8", "32Gi", nil),
+			},
+			wantMessage: "velero requests cpu 2, memory 4Gi fit no schedulable node matching node selector node-role.kubernetes.io/infra=, the velero pod will not schedule",
+		},
+		{
+			name:         "no node matches the node selector",
+			nodeSelector: infraLabels,
+			nodes:        []corev1.Node{newNode("worker-node", "8", "32Gi", nil)},
+			wantMessage:  "velero requests cpu 2, memory 4Gi fit no schedulable node matching node selector node-role.kubernetes.io/infra=, the velero pod will not schedule",
+		},
+		{
+			name: "no nodes known",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := requestsFitNoNode(common.Velero, requests, tt.nodeSelector, tt.nodes); got != tt.wantMessage {
+				t.Errorf("requestsFitNoNode() = %q, want %q", got, tt.wantMessage)
+			}
+		})
+	}
+}
+
+func Test_validatePluginImages(t *testing.T) {
+	tests := []struct {
+		name                 string
+		relatedImages        map[string]string
+		unsupportedOverrides map[oadpv1alpha1.UnsupportedImageKey]string
+		wantErr              bool
+		messageErr           string
+	}{
+		{
+			name:    "operator installed without plugin image set",
+			wantErr: false,
+		},
+		{
+			name: "all default plugins in image set",
+			relatedImages: map[string]string{
+				"RELATED_IMAGE_VELERO_PLUGIN_FOR_AWS":   "registry.example.com/velero-plugin-for-aws:mirrored",
+				"RELATED_IMAGE_OPENSHIFT_VELERO_PLUGIN": "registry.example.com/openshift-velero-plugin:mirrored",
+				"RELATED_IMAGE_VELERO_PLUGIN_FOR_CSI":   "registry.example.com/velero-plugin-for-csi:mirrored",
+			},
+			wantErr: false,
+		},
+		{
+			name: "unmirrored default plugin",
+			relatedImages: map[string]string{
+				"RELATED_IMAGE_VELERO_PLUGIN_FOR_AWS":   "registry.example.com/velero-plugin-for-aws:mirrored",
+				"RELATED_IMAGE_OPENSHIFT_VELERO_PLUGIN": "registry.example.com/openshift-velero-plugin:mirrored",
+			},
+			wantErr:    true,
+			messageErr: "default plugins are not in the image set of the operator: csi (override with unsupportedOverrides csiPluginImageFqin)",
+		},
+		{
+			name: "unmirrored default plugin with image override",
+			relatedImages: map[string]string{
+				"RELATED_IMAGE_VELERO_PLUGIN_FOR_AWS":   "registry.example.com/velero-plugin-for-aws:mirrored",
+				"RELATED_IMAGE_OPENSHIFT_VELERO_PLUGIN": "registry.example.com/openshift-velero-plugin:mirrored",
+			},
+			unsupportedOverrides: map[oadpv1alpha1.UnsupportedImageKey]string{
+				oadpv1alpha1.CSIPluginImageKey: "registry.example.com/velero-plugin-for-csi:custom",
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, fields := range credentials.PluginSpecificFields {
+				t.Setenv(fields.RelatedImageEnvVar, tt.relatedImages[fields.RelatedImageEnvVar])
+			}
+			dpa := &oadpv1alpha1.DataProtectionApplication{
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{
+							DefaultPlugins: []oadpv1alpha1.DefaultPlugin{
+								oadpv1alpha1.DefaultPluginAWS,
+								oadpv1alpha1.DefaultPluginOpenShift,
+								oadpv1alpha1.DefaultPluginCSI,
+							},
+						},
+					},
+					UnsupportedOverrides: tt.unsupportedOverrides,
+				},
+			}
+			err := validatePluginImages(dpa)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validatePluginImages() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr && err.Error() != tt.messageErr {
+				t.Errorf("validatePluginImages() error = %v, want %v", err, tt.messageErr)
+			}
+		})
+	}
+}
+
+func Test_extendedResourceRequestsMessages(t *testing.T) {
+	gpuResources := corev1.ResourceRequirements{
+		Limits: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("1"),
+			"nvidia.com/gpu":      resource.MustParse("1"),
+			"hugepages-2Mi":       resource.MustParse("100Mi"),
+			corev1.ResourceMemory: resource.MustParse("1Gi"),
+		},
+	}
+	tests := []struct {
+		name         string
+		config       *oadpv1alpha1.ApplicationConfig
+		wantMessages []string
+	}{
+		{
+			name: "standard resources only",
+			config: &oadpv1alpha1.ApplicationConfig{
+				Velero: &oadpv1alpha1.VeleroConfig{
+					PodConfig: &oadpv1alpha1.PodConfig{
+						ResourceAllocations: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{
+								corev1.ResourceCPU:              resource.MustParse("500m"),
+								corev1.ResourceMemory:           resource.MustParse("256Mi"),
+								corev1.ResourceEphemeralStorage: resource.MustParse("1Gi"),
+								"hugepages-1Gi":                 resource.MustParse("1Gi"),
+							},
+						},
+					},
+				},
+			},
+			wantMessages: []string{},
+		},
+		{
+			name: "velero requests an extraneous extended resource",
+			config: &oadpv1alpha1.ApplicationConfig{
+				Velero: &oadpv1alpha1.VeleroConfig{
+					PodConfig: &oadpv1alpha1.PodConfig{
+						ResourceAllocations: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{
+								corev1.ResourceCPU: resource.MustParse("500m"),
+								"nvidia.com/gpu":   resource.MustParse("1"),
+							},
+						},
+					},
+				},
+			},
+			wantMessages: []string{
+				"velero resource allocations include extended resource nvidia.com/gpu which velero does not use, pods only schedule on nodes providing it; remove it from podConfig.resourceAllocations",
+			},
+		},
+		{
+			name: "node agent limits an extraneous extended resource",
+			config: &oadpv1alpha1.ApplicationConfig{
+				Velero: &oadpv1alpha1.VeleroConfig{},
+				NodeAgent: &oadpv1alpha1.NodeAgentConfig{
+					NodeAgentCommonFields: oadpv1alpha1.NodeAgentCommonFields{
+						Enable:    pointer.Bool(true),
+						PodConfig: &oadpv1alpha1.PodConfig{ResourceAllocations: gpuResources},
+					},
+				},
+			},
+			wantMessages: []string{
+				"node-agent resource allocations include extended resource nvidia.com/gpu which node-agent does not use, pods only schedule on nodes providing it; remove it from podConfig.resourceAllocations",
+			},
+		},
+		{
+			name: "disabled node agent is not checked",
+			config: &oadpv1alpha1.ApplicationConfig{
+				Velero: &oadpv1alpha1.VeleroConfig{},
+				NodeAgent: &oadpv1alpha1.NodeAgentConfig{
+					NodeAgentCommonFields: oadpv1alpha1.NodeAgentCommonFields{
+						Enable:    pointer.Bool(false),
+						PodConfig: &oadpv1alpha1.PodConfig{ResourceAllocations: gpuResources},
+					},
+				},
+			},
+			wantMessages: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dpa := &oadpv1alpha1.DataProtectionApplication{
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{Configuration: tt.config},
+			}
+			if got := extendedResourceRequestsMessages(dpa); !reflect.DeepEqual(got, tt.wantMessages) {
+				t.Errorf("extendedResourceRequestsMessages() = %v, want %v", got, tt.wantMessages)
+			}
+		})
+	}
+}
+
+func TestDPAReconciler_pluginsFailingCredentialValidation(t *testing.T) {
+	secret := func(name string, data string) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test-ns"},
+			Data:       map[string][]byte{"cloud": []byte(data)},
+		}
+	}
+	azureCredentials := "AZURE_SUBSCRIPTION_ID=subscription\nAZURE_RESOURCE_GROUP=group\n"
+	tests := []struct {
+		name        string
+		secrets     []client.Object
+		wantPlugins []string
+	}{
+		{
+			name:        "valid credentials",
+			secrets:     []client.Object{secret("aws-credentials", "[default]\n"), secret("azure-credentials", azureCredentials)},
+			wantPlugins: []string{},
+		},
+		{
+			name:        "missing azure secret",
+			secrets:     []client.Object{secret("aws-credentials", "[default]\n")},
+			wantPlugins: []string{"azure"},
+		},
+		{
+			name:        "missing aws secret and invalid azure credentials",
+			secrets:     []client.Object{secret("azure-credentials", "AZURE_RESOURCE_GROUP=group\n")},
+			wantPlugins: []string{"aws", "azure"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dpa := &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-DPA-CR", Namespace: "test-ns"},
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{
+							DefaultPlugins: []oadpv1alpha1.DefaultPlugin{
+								oadpv1alpha1.DefaultPluginOpenShift,
+								oadpv1alpha1.DefaultPluginAWS,
+								oadpv1alpha1.DefaultPluginMicrosoftAzure,
+							},
+						},
+					},
+					SnapshotLocations: []oadpv1alpha1.SnapshotLocation{
+						{
+							Velero: &v1.VolumeSnapshotLocationSpec{
+								Provider:   AWSProvider,
+								Credential: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "aws-credentials"}, Key: "cloud"},
+							},
+						},
+						{
+							Velero: &v1.VolumeSnapshotLocationSpec{
+								Provider:   AzureProvider,
+								Credential: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "azure-credentials"}, Key: "cloud"},
+							},
+						},
+					},
+				},
+			}
+			fakeClient, err := getFakeClientFromObjects(append(tt.secrets, dpa)...)
+			if err != nil {
+				t.Errorf("error in creating fake client, likely programmer error")
+			}
+			r := &DPAReconciler{
+				Client:  fakeClient,
+				Scheme:  fakeClient.Scheme(),
+				Log:     logr.Discard(),
+				Context: newContextForTest(tt.name),
+				NamespacedName: types.NamespacedName{
+					Namespace: dpa.Namespace,
+					Name:      dpa.Name,
+				},
+				EventRecorder: record.NewFakeRecorder(10),
+			}
+			got, err := r.pluginsFailingCredentialValidation(dpa)
+			if err != nil {
+				t.Errorf("pluginsFailingCredentialValidation() unexpected error = %v", err)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.wantPlugins) {
+				t.Errorf("pluginsFailingCredentialValidation() = %v, want %v", got, tt.wantPlugins)
+			}
+		})
+	}
+}
+
+func Test_validateAzureSecretContent(t *testing.T) {
+	tests := []struct {
+		name           string
+		secretData     map[string][]byte
+		wantErrMessage string
+	}{
+		{
+			name: "service principal credentials",
+			secretData: map[string][]byte{"cloud": []byte(`[default]
+AZURE_SUBSCRIPTION_ID="subscription"
+AZURE_TENANT_ID=tenant
+AZURE_CLIENT_ID=client
+AZURE_CLIENT_SECRET=secret
+AZURE_RESOURCE_GROUP=group
+AZURE_CLOUD_NAME=AzurePublicCloud
+`)},
+		},
+		{
+			name:           "service principal credentials without client id",
+			secretData:     map[string][]byte{"cloud": []byte("AZURE_SUBSCRIPTION_ID=subscription\nAZURE_TENANT_ID=tenant\nAZURE_CLIENT_SECRET=secret\n")},
+			wantErrMessage: "azure credentials key cloud is missing AZURE_CLIENT_ID required for service principal authentication",
+		},
+		{
+			name:           "service principal credentials with empty tenant id",
+			secretData:     map[string][]byte{"cloud": []byte("AZURE_SUBSCRIPTION_ID=subscription\nAZURE_TENANT_ID=\nAZURE_CLIENT_ID=client\nAZURE_CLIENT_SECRET=secret\n")},
+			wantErrMessage: "azure credentials key cloud is missing AZURE_TENANT_ID required for service principal authentication",
+		},
+		{
+			name:       "workload identity credentials",
+			secretData: map[string][]byte{"cloud": []byte("AZURE_SUBSCRIPTION_ID=subscription\nAZURE_TENANT_ID=tenant\nAZURE_CLIENT_ID=client\nAZURE_FEDERATED_TOKEN_FILE=/var/run/secrets/openshift/serviceaccount/token\n")},
+		},
+		{
+			name:           "workload identity credentials without client id",
+			secretData:     map[string][]byte{"cloud": []byte("AZURE_SUBSCRIPTION_ID=subscription\nAZURE_TENANT_ID=tenant\nAZURE_FEDERATED_TOKEN_FILE=/var/run/secrets/openshift/serviceaccount/token\n")},
+			wantErrMessage: "azure credentials key cloud is missing AZURE_CLIENT_ID required for workload identity authentication",
+		},
+		{
+			name:       "client certificate credentials",
+			secretData: map[string][]byte{"cloud": []byte("AZURE_SUBSCRIPTION_ID=subscription\nAZURE_TENANT_ID=tenant\nAZURE_CLIENT_ID=client\nAZURE_CLIENT_CERTIFICATE_PATH=/credentials/client.pem\n")},
+		},
+		{
+			name:           "client certificate credentials without tenant id",
+			secretData:     map[string][]byte{"cloud": []byte("AZURE_SUBSCRIPTION_ID=subscription\nAZURE_CLIENT_ID=client\nAZURE_CLIENT_CERTIFICATE_PATH=/credentials/client.pem\n")},
+			wantErrMessage: "azure credentials key cloud is missing AZURE_TENANT_ID required for client certificate authentication",
+		},
+		{
+			name:       "managed identity credentials with a tenant id",
+			secretData: map[string][]byte{"cloud": []byte("AZURE_SUBSCRIPTION_ID=subscription\nAZURE_TENANT_ID=tenant\n")},
+		},
+		{
+			name:       "managed identity credentials",
+			secretData: map[string][]byte{"cloud": []byte("AZURE_SUBSCRIPTION_ID=subscription\nAZURE_RESOURCE_GROUP=group\n")},
+		},
+		{
+			name:       "user assigned managed identity credentials",
+			secretData: map[string][]byte{"cloud": []byte("AZURE_SUBSCRIPTION_ID=subscription\nAZURE_CLIENT_ID=client\n")},
+		},
+		{
+			name:           "managed identity credentials without subscription id",
+			secretData:     map[string][]byte{"cloud": []byte("# managed identity\nAZURE_RESOURCE_GROUP=group\n")},
+			wantErrMessage: "azure credentials key cloud is missing AZURE_SUBSCRIPTION_ID required for managed identity authentication",
+		},
+		{
+			name:       "storage account access key credentials",
+			secretData: map[string][]byte{"cloud": []byte("AZURE_STORAGE_ACCOUNT_ACCESS_KEY=key\nAZURE_CLOUD_NAME=AzurePublicCloud\n")},
+		},
+		{
+			name:           "credentials key not in secret",
+			secretData:     map[string][]byte{"azure": []byte("AZURE_SUBSCRIPTION_ID=subscription\n")},
+			wantErrMessage: "azure credentials key cloud not found in secret",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateAzureSecretContent(tt.secretData, "cloud")
+			if tt.wantErrMessage == "" {
+				if err != nil {
+					t.Errorf("validateAzureSecretContent() unexpected error = %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErrMessage {
+				t.Errorf("validateAzureSecretContent() error = %v, want %v", err, tt.wantErrMessage)
+			}
+		})
+	}
+}
+
+func Test_validateCloudStorageSecretContent(t *testing.T) {
+	tests := []struct {
+		name           string
+		provider       oadpv1alpha1.CloudStorageProvider
+		secretData     map[string][]byte
+		wantErrMessage string
+	}{
+		{
+			name:       "aws access key credentials",
+			provider:   oadpv1alpha1.AWSBucketProvider,
+			secretData: map[string][]byte{"cloud": []byte("[default]\naws_access_key_id = key\naws_secret_access_key = secret\n")},
+		},
+		{
+			name:       "aws web identity credentials",
+			provider:   oadpv1alpha1.AWSBucketProvider,
+			secretData: map[string][]byte{"cloud": []byte("[default]\nrole_arn = arn:aws:iam::123456789012:role/oadp\nweb_identity_token_file = /var/run/secrets/openshift/serviceaccount/token\n")},
+		},
+		{
+			name:           "malformed aws credentials",
+			provider:       oadpv1alpha1.AWSBucketProvider,
+			secretData:     map[string][]byte{"cloud": []byte("[default]\naws_access_key_id: key\naws_secret_access_key: secret\n")},
+			wantErrMessage: "aws credentials key cloud is missing aws_access_key_id, aws_secret_access_key",
+		},
+		{
+			name:           "aws credentials without secret access key",
+			provider:       oadpv1alpha1.AWSBucketProvider,
+			secretData:     map[string][]byte{"cloud": []byte("[default]\naws_access_key_id = key\n")},
+			wantErrMessage: "aws credentials key cloud is missing aws_secret_access_key",
+		},
+		{
+			name:       "azure service principal credentials",
+			provider:   oadpv1alpha1.AzureBucketProvider,
+			secretData: map[string][]byte{"cloud": []byte("AZURE_SUBSCRIPTION_ID=subscription\nAZURE_TENANT_ID=tenant\nAZURE_CLIENT_ID=client\nAZURE_CLIENT_SECRET=secret\n")},
+		},
+		{
+			name:           "malformed azure credentials",
+			provider:       oadpv1alpha1.AzureBucketProvider,
+			secretData:     map[string][]byte{"cloud": []byte("AZURE_SUBSCRIPTION_ID: subscription\n")},
+			wantErrMessage: "azure credentials key cloud is missing AZURE_SUBSCRIPTION_ID required for managed identity authentication",
+		},
+		{
+			name:       "gcp service account key",
+			provider:   oadpv1alpha1.GCPBucketProvider,
+			secretData: map[string][]byte{"cloud": []byte(`{"type": "service_account", "project_id": "project"}`)},
+		},
+		{
+			name:       "gcp workload identity federation credentials",
+			provider:   oadpv1alpha1.GCPBucketProvider,
+			secretData: map[string][]byte{"cloud": []byte(`{"type": "external_account", "audience": "audience"}`)},
+		},
+		{
+			name:           "malformed gcp credentials",
+			provider:       oadpv1alpha1.GCPBucketProvider,
+			secretData:     map[string][]byte{"cloud": []byte("type = service_account\n")},
+			wantErrMessage: "gcp credentials key cloud is not a JSON credentials file: invalid character 'y' in literal true (expecting 'r')",
+		},
+		{
+			name:           "gcp credentials with unknown type",
+			provider:       oadpv1alpha1.GCPBucketProvider,
+			secretData:     map[string][]byte{"cloud": []byte(`{"type": "authorized_user"}`)},
+			wantErrMessage: "gcp credentials key cloud has type \"authorized_user\", must be service_account or external_account",
+		},
+		{
+			name:           "credentials key not in secret",
+			provider:       oadpv1alpha1.AWSBucketProvider,
+			secretData:     map[string][]byte{"credentials": []byte("[default]\naws_access_key_id = key\naws_secret_access_key = secret\n")},
+			wantErrMessage: "aws credentials key cloud not found in secret",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateCloudStorageSecretContent(tt.provider, tt.secretData, "cloud")
+			if tt.wantErrMessage == "" {
+				if err != nil {
+					t.Errorf("validateCloudStorageSecretContent() unexpected error = %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErrMessage {
+				t.Errorf("validateCloudStorageSecretContent() error = %v, want %v", err, tt.wantErrMessage)
+			}
+		})
+	}
+}
+
+func TestDPAReconciler_validatePluginRequiredAPIs(t *testing.T) {
+	tests := []struct {
+		name           string
+		plugins        []oadpv1alpha1.DefaultPlugin
+		servedAPIs     []schema.GroupVersionKind
+		wantErrMessage string
+	}{
+		{
+			name:       "plugins with required APIs served",
+			plugins:    []oadpv1alpha1.DefaultPlugin{oadpv1alpha1.DefaultPluginAWS, oadpv1alpha1.DefaultPluginCSI, oadpv1alpha1.DefaultPluginKubeVirt},
+			servedAPIs: []schema.GroupVersionKind{pluginRequiredAPIs[oadpv1alpha1.DefaultPluginCSI].gvk, pluginRequiredAPIs[oadpv1alpha1.DefaultPluginKubeVirt].gvk},
+		},
+		{
+			name:           "kubevirt plugin without OpenShift Virtualization",
+			plugins:        []oadpv1alpha1.DefaultPlugin{oadpv1alpha1.DefaultPluginCSI, oadpv1alpha1.DefaultPluginKubeVirt},
+			servedAPIs:     []schema.GroupVersionKind{pluginRequiredAPIs[oadpv1alpha1.DefaultPluginCSI].gvk},
+			wantErrMessage: "kubevirt plugin requires the kubevirt.io/v1 VirtualMachine API, which is not available in the cluster; enable OpenShift Virtualization or remove the plugin from defaultPlugins",
+		},
+		{
+			name:           "csi plugin without volume snapshot API",
+			plugins:        []oadpv1alpha1.DefaultPlugin{oadpv1alpha1.DefaultPluginCSI},
+			wantErrMessage: "csi plugin requires the snapshot.storage.k8s.io/v1 VolumeSnapshot API, which is not available in the cluster; enable the CSI snapshot controller or remove the plugin from defaultPlugins",
+		},
+		{
+			name:    "plugins without required APIs",
+			plugins: []oadpv1alpha1.DefaultPlugin{oadpv1alpha1.DefaultPluginAWS, oadpv1alpha1.DefaultPluginOpenShift},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dpa := &oadpv1alpha1.DataProtectionApplication{
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{DefaultPlugins: tt.plugins},
+					},
+				},
+			}
+			restMapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{})
+			for _, gvk := range tt.servedAPIs {
+				restMapper.Add(gvk, meta.RESTScopeNamespace)
+			}
+			r := &DPAReconciler{Client: fake.NewClientBuilder().WithRESTMapper(restMapper).Build()}
+			err := r.validatePluginRequiredAPIs(dpa)
+			if tt.wantErrMessage == "" {
+				if err != nil {
+					t.Errorf("validatePluginRequiredAPIs() unexpected error = %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErrMessage {
+				t.Errorf("validatePluginRequiredAPIs() error = %v, want %v", err, tt.wantErrMessage)
+			}
+		})
+	}
+}
+
+func Test_validatePodLabels(t *testing.T) {
+	tests := []struct {
+		name           string
+		velero         *oadpv1alpha1.PodConfig
+		nodeAgent      *oadpv1alpha1.PodConfig
+		wantErrMessage string
+	}{
+		{
+			name:      "custom labels",
+			velero:    &oadpv1alpha1.PodConfig{Labels: map[string]string{"mesh": "enabled"}},
+			nodeAgent: &oadpv1alpha1.PodConfig{Labels: map[string]string{"team": "backup"}},
+		},
+		{
+			name:           "velero app label",
+			velero:         &oadpv1alpha1.PodConfig{Labels: map[string]string{"mesh": "enabled", "app.kubernetes.io/name": "other"}},
+			wantErrMessage: "velero podConfig label \"app.kubernetes.io/name\" is reserved by OADP and cannot be set",
+		},
+		{
+			name:           "velero component label",
+			velero:         &oadpv1alpha1.PodConfig{Labels: map[string]string{"component": "velero"}},
+			wantErrMessage: "velero podConfig label \"component\" is reserved by OADP and cannot be set",
+		},
+		{
+			name:           "node agent selector label",
+			nodeAgent:      &oadpv1alpha1.PodConfig{Labels: map[string]string{"name": "agent"}},
+			wantErrMessage: "node-agent podConfig label \"name\" is reserved by OADP and cannot be set",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dpa := &oadpv1alpha1.DataProtectionApplication{
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero:    &oadpv1alpha1.VeleroConfig{PodConfig: tt.velero},
+						NodeAgent: &oadpv1alpha1.NodeAgentConfig{NodeAgentCommonFields: oadpv1alpha1.NodeAgentCommonFields{PodConfig: tt.nodeAgent}},
+					},
+				},
+			}
+			err := validatePodLabels(dpa)
+			if tt.wantErrMessage == "" {
+				if err != nil {
+					t.Errorf("validatePodLabels() unexpected error = %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErrMessage {
+				t.Errorf("validatePodLabels() error = %v, want %v", err, tt.wantErrMessage)
+			}
+		})
+	}
+}
+
+func Test_validatePodAnnotations(t *testing.T) {
+	tests := []struct {
+		name           string
+		velero         *oadpv1alpha1.PodConfig
+		nodeAgent      *oadpv1alpha1.PodConfig
+		podAnnotations map[string]string
+		wantErrMessage string
+	}{
+		{
+			name:           "custom annotations",
+			velero:         &oadpv1alpha1.PodConfig{Annotations: map[string]string{"sidecar.istio.io/inject": "false", "team": "backup"}},
+			nodeAgent:      &oadpv1alpha1.PodConfig{Annotations: map[string]string{"Example.com/Owner": "backup"}},
+			podAnnotations: map[string]string{"team": "backup"},
+		},
+		{
+			name:           "velero annotation key with spaces",
+			velero:         &oadpv1alpha1.PodConfig{Annotations: map[string]string{"sidecar inject": "false"}},
+			wantErrMessage: "velero podConfig annotation key \"sidecar inject\" is not a valid annotation key: name part must consist of alphanumeric characters, '-', '_' or '.', and must start and end with an alphanumeric character (e.g. 'MyName',  or 'my.name',  or '123-abc', regex used for validation is '([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9]')",
+		},
+		{
+			name:           "node agent annotation key with empty prefix",
+			nodeAgent:      &oadpv1alpha1.PodConfig{Annotations: map[string]string{"/inject": "false"}},
+			wantErrMessage: "node-agent podConfig annotation key \"/inject\" is not a valid annotation key: prefix part must be non-empty",
+		},
+		{
+			name:           "velero annotation conflicting with podAnnotations",
+			velero:         &oadpv1alpha1.PodConfig{Annotations: map[string]string{"sidecar.istio.io/inject": "false"}},
+			podAnnotations: map[string]string{"sidecar.istio.io/inject": "true"},
+			wantErrMessage: "velero podConfig annotation \"sidecar.istio.io/inject\" value \"false\" conflicts with podAnnotations value \"true\"",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dpa := &oadpv1alpha1.DataProtectionApplication{
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero:    &oadpv1alpha1.VeleroConfig{PodConfig: tt.velero},
+						NodeAgent: &oadpv1alpha1.NodeAgentConfig{NodeAgentCommonFields: oadpv1alpha1.NodeAgentCommonFields{PodConfig: tt.nodeAgent}},
+					},
+					PodAnnotations: tt.podAnnotations,
+				},
+			}
+			err := validatePodAnnotations(dpa)
+			if tt.wantErrMessage == "" {
+				if err != nil {
+					t.Errorf("validatePodAnnotations() unexpected error = %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErrMessage {
+				t.Errorf("validatePodAnnotations() error = %v, want %v", err, tt.wantErrMessage)
+			}
+		})
+	}
+}
+
+func Test_validatePodScheduling(t *testing.T) {
+	tests := []struct {
+		name           string
+		velero         *oadpv1alpha1.PodConfig
+		nodeAgent      *oadpv1alpha1.PodConfig
+		wantErrMessage string
+	}{
+		{
+			name: "valid node selector and tolerations",
+			velero: &oadpv1alpha1.PodConfig{
+				NodeSelector: map[string]string{"node-role.kubernetes.io/infra": ""},
+				Tolerations: []corev1.Toleration{
+					{Key: "node-role.kubernetes.io/infra", Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule},
+					{Key: "dedicated", Value: "backup", Effect: corev1.TaintEffectNoExecute, TolerationSeconds: pointer.Int64(60)},
+				},
+			},
+			nodeAgent: &oadpv1alpha1.PodConfig{
+				Tolerations: []corev1.Toleration{{Operator: corev1.TolerationOpExists}},
+			},
+		},
+		{
+			name:           "velero node selector key with a space",
+			velero:         &oadpv1alpha1.PodConfig{NodeSelector: map[string]string{"node role": "infra"}},
+			wantErrMessage: "velero podConfig nodeSelector key \"node role\" is not a valid label key: name part must consist of alphanumeric characters, '-', '_' or '.', and must start and end with an alphanumeric character (e.g. 'MyName',  or 'my.name',  or '123-abc', regex used for validation is '([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9]')",
+		},
+		{
+			name:           "node agent node selector value with a slash",
+			nodeAgent:      &oadpv1alpha1.PodConfig{NodeSelector: map[string]string{"zone": "us/east"}},
+			wantErrMessage: "node-agent podConfig nodeSelector zone value \"us/east\" is not a valid label value: a valid label must be an empty string or consist of alphanumeric characters, '-', '_' or '.', and must start and end with an alphanumeric character (e.g. 'MyValue',  or 'my_value',  or '12345', regex used for validation is '(([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9])?')",
+		},
+		{
+			name:           "toleration with unknown operator",
+			velero:         &oadpv1alpha1.PodConfig{Tolerations: []corev1.Toleration{{Key: "dedicated", Operator: "In", Value: "backup"}}},
+			wantErrMessage: "velero podConfig tolerations[0] operator \"In\" must be one of Equal, Exists",
+		},
+		{
+			name:           "toleration with unknown effect",
+			nodeAgent:      &oadpv1alpha1.PodConfig{Tolerations: []corev1.Toleration{{Key: "dedicated", Operator: corev1.TolerationOpExists, Effect: "NoScheduling"}}},
+			wantErrMessage: "node-agent podConfig tolerations[0] effect \"NoScheduling\" must be one of NoSchedule, PreferNoSchedule, NoExecute",
+		},
+		{
+			name:           "toleration without key using Equal",
+			velero:         &oadpv1alpha1.PodConfig{Tolerations: []corev1.Toleration{{Operator: corev1.TolerationOpEqual, Value: "backup"}}},
+			wantErrMessage: "velero podConfig tolerations[0] operator must be Exists when key is empty",
+		},
+		{
+			name:           "toleration with Exists and a value",
+			velero:         &oadpv1alpha1.PodConfig{Tolerations: []corev1.Toleration{{Key: "dedicated", Operator: corev1.TolerationOpExists, Value: "backup"}}},
+			wantErrMessage: "velero podConfig tolerations[0] value must be empty when operator is Exists",
+		},
+		{
+			name:           "tolerationSeconds without NoExecute",
+			velero:         &oadpv1alpha1.PodConfig{Tolerations: []corev1.Toleration{{Key: "dedicated", Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule, TolerationSeconds: pointer.Int64(60)}}},
+			wantErrMessage: "velero podConfig tolerations[0] tolerationSeconds may only be set when effect is NoExecute",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dpa := &oadpv1alpha1.DataProtectionApplication{
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero:    &oadpv1alpha1.VeleroConfig{PodConfig: tt.velero},
+						NodeAgent: &oadpv1alpha1.NodeAgentConfig{NodeAgentCommonFields: oadpv1alpha1.NodeAgentCommonFields{PodConfig: tt.nodeAgent}},
+					},
+				},
+			}
+			err := validatePodScheduling(dpa)
+			if tt.wantErrMessage == "" {
+				if err != nil {
+					t.Errorf("validatePodScheduling() unexpected error = %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErrMessage {
+				t.Errorf("validatePodScheduling() error = %v, want %v", err, tt.wantErrMessage)
+			}
+		})
+	}
+}
+
+func TestDPAReconciler_recordValidationEvent(t *testing.T) {
+	dpa := &oadpv1alpha1.DataProtectionApplication{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-DPA-CR", Namespace: "test-ns", Generation: 1},
+	}
+	recorder := record.NewFakeRecorder(10)
+	r := &DPAReconciler{EventRecorder: recorder}
+	steps := []struct {
+		name       string
+		generation int64
+		err        error
+		wantEvent  string
+	}{
+		{
+			name:       "failure is recorded",
+			generation: 1,
+			err:        fmt.Errorf("sysctl kernel.shm_rmid_forced is not allowed"),
+			wantEvent:  "Warning ValidationFailed sysctl kernel.shm_rmid_forced is not allowed",
+		},
+		{
+			name:       "repeated failure is not recorded",
+			generation: 1,
+			err:        fmt.Errorf("sysctl kernel.shm_rmid_forced is not allowed"),
+		},
+		{
+			name:       "different failure is recorded",
+			generation: 1,
+			err:        fmt.Errorf("backupMaintenanceWindow start \"25:00\" must be a time of day in HH:MM format"),
+			wantEvent:  "Warning ValidationFailed backupMaintenanceWindow start \"25:00\" must be a time of day in HH:MM format",
+		},
+		{
+			name:       "success is recorded",
+			generation: 2,
+			wantEvent:  "Normal Validated DPA configuration is valid",
+		},
+		{
+			name:       "repeated success is not recorded",
+			generation: 2,
+		},
+		{
+			name:       "success for a new generation is recorded",
+			generation: 3,
+			wantEvent:  "Normal Validated DPA configuration is valid",
+		},
+	}
+	for _, step := range steps {
+		dpa.Generation = step.generation
+		r.recordValidationEvent(dpa, step.err)
+		select {
+		case event := <-recorder.Events:
+			if event != step.wantEvent {
+				t.Errorf("%s: recordValidationEvent() recorded %q, want %q", step.name, event, step.wantEvent)
+			}
+		default:
+			if step.wantEvent != "" {
+				t.Errorf("%s: recordValidationEvent() recorded no event, want %q", step.name, step.wantEvent)
+			}
+		}
+	}
+}
+
+func TestDPAReconciler_ValidateDataProtectionCRValidationErrorCodes(t *testing.T) {
+	tests := []struct {
+		name      string
+		spec      oadpv1alpha1.DataProtectionApplicationSpec
+		wantCodes []oadpv1alpha1.ValidationErrorCode
+	}{
+		{
+			name: "valid DPA",
+			spec: oadpv1alpha1.DataProtectionApplicationSpec{
+				Configuration: &oadpv1alpha1.ApplicationConfig{
+					Velero: &oadpv1alpha1.VeleroConfig{NoDefaultBackupLocation: true},
+				},
+				BackupImages: pointer.Bool(false),
+			},
+		},
+		{
+			name: "no backup locations",
+			spec: oadpv1alpha1.DataProtectionApplicationSpec{
+				Configuration: &oadpv1alpha1.ApplicationConfig{
+					Velero: &oadpv1alpha1.VeleroConfig{},
+				},
+			},
+			wantCodes: []oadpv1alpha1.ValidationErrorCode{oadpv1alpha1.ValidationErrorCodeInvalidSpec},
+		},
+		{
+			name: "several independent errors",
+			spec: oadpv1alpha1.DataProtectionApplicationSpec{
+				Configuration: &oadpv1alpha1.ApplicationConfig{
+					Velero: &oadpv1alpha1.VeleroConfig{
+						NoDefaultBackupLocation: true,
+						Sysctls:                 []corev1.Sysctl{{Name: "vm.swappiness", Value: "10"}},
+						PodConfig:               &oadpv1alpha1.PodConfig{Labels: map[string]string{"component": "backup"}},
+					},
+					NodeAgent: &oadpv1alpha1.NodeAgentConfig{
+						NodeAgentCommonFields: oadpv1alpha1.NodeAgentCommonFields{
+							PodConfig: &oadpv1alpha1.PodConfig{
+								MaxUnavailable: &intstr.IntOrString{Type: intstr.Int, IntVal: 0},
+							},
+						},
+					},
+				},
+				BackupMaintenanceWindow: &oadpv1alpha1.BackupMaintenanceWindow{Start: "25:00", Duration: metav1.Duration{Duration: time.Hour}},
+				BackupImages:            pointer.Bool(false),
+			},
+			wantCodes: []oadpv1alpha1.ValidationErrorCode{
+				oadpv1alpha1.ValidationErrorCodeInvalidBackupMaintenanceWindow,
+				oadpv1alpha1.ValidationErrorCodeUnsafeSysctl,
+				oadpv1alpha1.ValidationErrorCodeInvalidNodeAgentMaxUnavailable,
+				oadpv1alpha1.ValidationErrorCodeReservedPodLabel,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dpa := &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-DPA-CR", Namespace: "test-ns"},
+				Spec:       tt.spec,
+			}
+			fakeClient, err := getFakeClientFromObjects(dpa)
+			if err != nil {
+				t.Errorf("error in creating fake client, likely programmer error")
+			}
+			r := &DPAReconciler{
+				Client:  fakeClient,
+				Scheme:  fakeClient.Scheme(),
+				Log:     logr.Discard(),
+				Context: newContextForTest(tt.name),
+				NamespacedName: types.NamespacedName{
+					Namespace: dpa.Namespace,
+					Name:      dpa.Name,
+				},
+				EventRecorder: record.NewFakeRecorder(10),
+			}
+			_, err = r.ValidateDataProtectionCR(r.Log)
+			var gotCodes []oadpv1alpha1.ValidationErrorCode
+			for _, failure := range validationErrors(err) {
+				if failure.Message == "" {
+					t.Errorf("validationErrors() returned %s without a message", failure.Code)
+				}
+				gotCodes = append(gotCodes, failure.Code)
+			}
+			if !reflect.DeepEqual(gotCodes, tt.wantCodes) {
+				t.Errorf("validationErrors() codes = %v, want %v, error = %v", gotCodes, tt.wantCodes, err)
 			}
 		})
 	}
@@ -2,16 +2,24 @@ package controllers
 
 import (
 	"fmt"
+	"net"
 	"os"
+	"path"
 	"reflect"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
+	"unicode"
 
 	"github.com/go-logr/logr"
 	"github.com/google/go-cmp/cmp"
 	"github.com/operator-framework/operator-lib/proxy"
 	"github.com/sirupsen/logrus"
+	velerov1 "github.com/vmware-tanzu/velero/pkg/apis/velero/v1"
 	"github.com/vmware-tanzu/velero/pkg/install"
+	"github.com/vmware-tanzu/velero/pkg/restore"
 	"github.com/vmware-tanzu/velero/pkg/util/boolptr"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -37,17 +45,70 @@ const (
 	VeleroAzureSecretName = "cloud-credentials-azure"
 	VeleroGCPSecretName   = "cloud-credentials-gcp"
 	enableCSIFeatureFlag  = "EnableCSI"
+	noSecretFeatureFlag   = "no-secret"
 	veleroIOPrefix        = "velero.io/"
 
 	VeleroReplicaOverride = "VELERO_DEBUG_REPLICAS_OVERRIDE"
 
 	defaultFsBackupTimeout = "4h"
 
+	restoreResourcePrioritiesDataKey = "restoreResourcePriorities"
+
+	defaultServiceAccountTokenAudience = "openshift"
+	// maximum length we accept for a projected service account token audience
+	maxServiceAccountTokenAudienceLength        = 1024
+	defaultServiceAccountTokenExpirationSeconds = int64(3600)
+	// bounds of a projected service account token expiration enforced by the kubernetes API
+	minServiceAccountTokenExpirationSeconds = int64(600)
+	maxServiceAccountTokenExpirationSeconds = int64(1 << 32)
+
+	garbageCollectionController = "gc"
+
+	// DPAs annotated with this key set to true opt in to exposing the velero profiler on profilerAddress
+	oadpEnableVeleroProfilerAnnotation = "oadp.openshift.io/enable-velero-profiler"
+
+	veleroLogsVolumeName       = "velero-logs"
+	veleroLogFileName          = "velero.log"
+	veleroLogFileEnvKey        = "VELERO_LOG_FILE"
+	veleroLogFileMaxSizeEnvKey = "VELERO_LOG_FILE_MAX_SIZE"
+	// velero.log is rotated to velero.log.1 at this size, or a quarter of the emptyDir sizeLimit if smaller, so the
+	// log volume holds at most about twice that
+	defaultVeleroLogFileMaxSize = int64(100 * 1024 * 1024)
+	// veleroLogFileScript rotates the log file on start, then redirects its output to a loop copying each line to
+	// stdout and to the log file, rotating it once it reaches the maximum size, and execs the command
+	veleroLogFileScript = `log="$VELERO_LOG_FILE"
+if [ -e "$log" ]; then mv -f "$log" "$log.1"; fi
+exec > >(
+	LC_ALL=C
+	exec 3>>"$log"
+	size=0
+	while IFS= read -r line || [ -n "$line" ]; do
+		printf '%s\n' "$line"
+		printf '%s\n' "$line" >&3
+		size=$((size + ${#line} + 1))
+		if [ "$size" -ge "$VELERO_LOG_FILE_MAX_SIZE" ]; then
+			mv -f "$log" "$log.1"
+			exec 3>"$log"
+			size=0
+		fi
+	done
+) 2>&1
+exec "$0" "$@"`
+
 	TrueVal  = "true"
 	FalseVal = "false"
 )
 
 var (
+	// controllers disabled by the deprecated velero --restore-only flag
+	restoreOnlyDisabledControllers = []string{
+		"backup",
+		"backup-deletion",
+		"backup-finalizer",
+		"backup-operations",
+		garbageCollectionController,
+		"schedule",
+	}
 	veleroLabelSelector = &metav1.LabelSelector{
 		MatchLabels: map[string]string{
 			"k8s-app":   "openshift-adp",
@@ -61,6 +122,36 @@ var (
 		"app.kubernetes.io/component":  Server,
 		oadpv1alpha1.OadpOperatorLabel: "True",
 	}
+	// feature flags removed from velero, keyed by the velero version that removed them. None has been removed yet,
+	// velero 1.14 merged the CSI plugin but still requires EnableCSI for CSI snapshots.
+	removedVeleroFeatureFlags = map[string][]string{}
+	// feature flags recognized by velero, update when moving to a new velero version
+	veleroFeatureFlags = []string{
+		velerov1.CSIFeatureFlag,
+		velerov1.APIGroupVersionsFeatureFlag,
+	}
+	// feature flags read by OADP itself, which velero ignores
+	oadpFeatureFlags = []string{
+		noSecretFeatureFlag,
+	}
+	// matches the major and minor version at the start of a velero image tag, e.g. v1.12.1
+	veleroVersionRegexp = regexp.MustCompile(`^v?(\d+)\.(\d+)`)
+	// matches an image reference with an optional tag and digest, following the grammar of the distribution
+	// reference package: [domain[:port]/]path[:tag][@digest]
+	imageReferenceRegexp = regexp.MustCompile(`^` +
+		`(?:(?:[a-zA-Z0-9]|[a-zA-Z0-9][a-zA-Z0-9-]*[a-zA-Z0-9])(?:\.(?:[a-zA-Z0-9]|[a-zA-Z0-9][a-zA-Z0-9-]*[a-zA-Z0-9]))*(?::[0-9]+)?/)?` +
+		`[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*(?:/[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*)*` +
+		`(?::([\w][\w.-]{0,127}))?` +
+		`(?:@([A-Za-z][A-Za-z0-9]*(?:[-_+.][A-Za-z][A-Za-z0-9]*)*:[0-9a-fA-F]{32,}))?$`)
+	// sysctls kubernetes allows on pods without enabling them on the kubelet
+	safeSysctls = map[string]bool{
+		"kernel.shm_rmid_forced":              true,
+		"net.ipv4.ip_local_port_range":        true,
+		"net.ipv4.ip_local_reserved_ports":    true,
+		"net.ipv4.ip_unprivileged_port_start": true,
+		"net.ipv4.ping_group_range":           true,
+		"net.ipv4.tcp_syncookies":             true,
+	}
 )
 
 func (r *DPAReconciler) ReconcileVeleroDeployment(log logr.Logger) (bool, error) {
@@ -204,6 +295,83 @@ func (r *DPAReconciler) buildVeleroDeployment(veleroDeployment *appsv1.Deploymen
 	return r.customizeVeleroDeployment(dpa, veleroDeployment)
 }
 
+// buildVeleroServerArgs returns the arguments of the velero server container, as built for the velero deployment.
+// Flag values passed as a separate argument are joined to their flag with "=", and the flags are sorted after the
+// server command, so the arguments only depend on the DPA and not on the order the deployment is built in.
+func (r *DPAReconciler) buildVeleroServerArgs(dpa *oadpv1alpha1.DataProtectionApplication) ([]string, error) {
+	veleroDeployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      common.Velero,
+			Namespace: dpa.Namespace,
+		},
+	}
+	// building the deployment auto corrects the DPA
+	if err := r.buildVeleroDeployment(veleroDeployment, dpa.DeepCopy()); err != nil {
+		return nil, err
+	}
+	for _, container := range veleroDeployment.Spec.Template.Spec.Containers {
+		if container.Name == common.Velero {
+			return sortedServerArgs(container.Args), nil
+		}
+	}
+	return nil, fmt.Errorf("could not find velero container in Deployment")
+}
+
+// installedPlugins returns the plugins with an init container in the velero deployment
+func (r *DPAReconciler) installedPlugins(dpa *oadpv1alpha1.DataProtectionApplication) ([]string, error) {
+	veleroDeployment := appsv1.Deployment{}
+	if err := r.Get(r.Context, types.NamespacedName{Name: common.Velero, Namespace: dpa.Namespace}, &veleroDeployment); err != nil {
+		return nil, err
+	}
+	return pluginInitContainerNames(veleroDeployment.Spec.Template.Spec.InitContainers), nil
+}
+
+// pluginInitContainerNames returns the plugin of each init container copying a plugin into the plugins volume,
+// default plugins are named as in the DPA and custom plugins by their init container name
+func pluginInitContainerNames(initContainers []corev1.Container) []string {
+	defaultPlugins := map[string]oadpv1alpha1.DefaultPlugin{}
+	for plugin, fields := range credentials.PluginSpecificFields {
+		defaultPlugins[fields.PluginName] = plugin
+	}
+	plugins := []string{}
+	for _, initContainer := range initContainers {
+		copiesPlugin := false
+		for _, mount := range initContainer.VolumeMounts {
+			if mount.Name == "plugins" && mount.MountPath == "/target" {
+				copiesPlugin = true
+			}
+		}
+		if !copiesPlugin {
+			continue
+		}
+		if plugin, found := defaultPlugins[initContainer.Name]; found {
+			plugins = append(plugins, string(plugin))
+			continue
+		}
+		plugins = append(plugins, initContainer.Name)
+	}
+	return plugins
+}
+
+func sortedServerArgs(args []string) []string {
+	commands := []string{}
+	flags := []string{}
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if !strings.HasPrefix(arg, "-") {
+			commands = append(commands, arg)
+			continue
+		}
+		if !strings.Contains(arg, "=") && i+1 < len(args) && !strings.HasPrefix(args[i+1], "-") {
+			arg = arg + "=" + args[i+1]
+			i++
+		}
+		flags = append(flags, arg)
+	}
+	sort.Strings(flags)
+	return append(commands, flags...)
+}
+
 func (r *DPAReconciler) customizeVeleroDeployment(dpa *oadpv1alpha1.DataProtectionApplication, veleroDeployment *appsv1.Deployment) error {
 	//append dpa labels
 	var err error
@@ -234,20 +402,32 @@ func (r *DPAReconciler) customizeVeleroDeployment(dpa *oadpv1alpha1.DataProtecti
 			return fmt.Errorf("velero deployment template custom label: %v", err)
 		}
 	}
+	// add custom pod annotations
+	if dpa.Spec.Configuration.Velero != nil && dpa.Spec.Configuration.Velero.PodConfig != nil && dpa.Spec.Configuration.Velero.PodConfig.Annotations != nil {
+		veleroDeployment.Spec.Template.Annotations, err = common.AppendUniqueKeyTOfTMaps(veleroDeployment.Spec.Template.Annotations, dpa.Spec.Configuration.Velero.PodConfig.Annotations)
+		if err != nil {
+			return fmt.Errorf("velero deployment template custom annotation: %v", err)
+		}
+	}
 
 	hasShortLivedCredentials, err := credentials.BslUsesShortLivedCredential(dpa.Spec.BackupLocations, dpa.Namespace)
+	// an explicit audience or expiration always requires the projected token
+	projectServiceAccountToken := hasShortLivedCredentials || dpa.Spec.Configuration.Velero.ServiceAccountTokenAudience != "" ||
+		dpa.Spec.Configuration.Velero.ServiceAccountTokenExpirationSeconds != nil
+	serviceAccountTokenAudience := defaultServiceAccountTokenAudience
+	if dpa.Spec.Configuration.Velero.ServiceAccountTokenAudience != "" {
+		serviceAccountTokenAudience = dpa.Spec.Configuration.Velero.ServiceAccountTokenAudience
+	}
 
 	// Selector: veleroDeployment.Spec.Selector,
-	replicas := int32(1)
-	if value, present := os.LookupEnv(VeleroReplicaOverride); present {
-		if converted, err := strconv.Atoi(value); err == nil {
-			replicas = int32(converted)
-		}
-	}
+	replicas := getVeleroReplicas()
 	veleroDeployment.Spec.Replicas = &replicas
+	// clear a previously shared process namespace when unset
+	veleroDeployment.Spec.Template.Spec.ShareProcessNamespace = nil
 	if dpa.Spec.Configuration.Velero.PodConfig != nil {
 		veleroDeployment.Spec.Template.Spec.Tolerations = dpa.Spec.Configuration.Velero.PodConfig.Tolerations
 		veleroDeployment.Spec.Template.Spec.NodeSelector = dpa.Spec.Configuration.Velero.PodConfig.NodeSelector
+		veleroDeployment.Spec.Template.Spec.ShareProcessNamespace = dpa.Spec.Configuration.Velero.PodConfig.ShareProcessNamespace
 	}
 	veleroDeployment.Spec.Template.Spec.Volumes = append(veleroDeployment.Spec.Template.Spec.Volumes,
 		corev1.Volume{
@@ -257,8 +437,11 @@ func (r *DPAReconciler) customizeVeleroDeployment(dpa *oadpv1alpha1.DataProtecti
 			},
 		})
 
-	if hasShortLivedCredentials {
-		expirationSeconds := int64(3600)
+	if projectServiceAccountToken {
+		expirationSeconds := defaultServiceAccountTokenExpirationSeconds
+		if dpa.Spec.Configuration.Velero.ServiceAccountTokenExpirationSeconds != nil {
+			expirationSeconds = *dpa.Spec.Configuration.Velero.ServiceAccountTokenExpirationSeconds
+		}
 		veleroDeployment.Spec.Template.Spec.Volumes = append(veleroDeployment.Spec.Template.Spec.Volumes,
 			corev1.Volume{
 				Name: "bound-sa-token",
@@ -268,7 +451,7 @@ func (r *DPAReconciler) customizeVeleroDeployment(dpa *oadpv1alpha1.DataProtecti
 						Sources: []corev1.VolumeProjection{
 							{
 								ServiceAccountToken: &corev1.ServiceAccountTokenProjection{
-									Audience:          "openshift",
+									Audience:          serviceAccountTokenAudience,
 									ExpirationSeconds: &expirationSeconds,
 									Path:              "token",
 								},
@@ -287,12 +470,22 @@ func (r *DPAReconciler) customizeVeleroDeployment(dpa *oadpv1alpha1.DataProtecti
 		veleroDeployment.Spec.Template.Spec.InitContainers = []corev1.Container{}
 	}
 
+	if err := customizePluginsVolume(dpa, veleroDeployment); err != nil {
+		return err
+	}
+
 	// attach DNS policy and config if enabled
 	veleroDeployment.Spec.Template.Spec.DNSPolicy = dpa.Spec.PodDnsPolicy
 	if !reflect.DeepEqual(dpa.Spec.PodDnsConfig, corev1.PodDNSConfig{}) {
 		veleroDeployment.Spec.Template.Spec.DNSConfig = &dpa.Spec.PodDnsConfig
 	}
 
+	// attach sysctls, clearing previously set ones when unset
+	if veleroDeployment.Spec.Template.Spec.SecurityContext == nil {
+		veleroDeployment.Spec.Template.Spec.SecurityContext = &corev1.PodSecurityContext{}
+	}
+	veleroDeployment.Spec.Template.Spec.SecurityContext.Sysctls = dpa.Spec.Configuration.Velero.Sysctls
+
 	// if metrics address is set, change annotation and ports
 	var prometheusPort *int
 	if dpa.Spec.Configuration.Velero.Args != nil &&
@@ -315,7 +508,7 @@ func (r *DPAReconciler) customizeVeleroDeployment(dpa *oadpv1alpha1.DataProtecti
 			break
 		}
 	}
-	if err := r.customizeVeleroContainer(dpa, veleroDeployment, veleroContainer, hasShortLivedCredentials, prometheusPort); err != nil {
+	if err := r.customizeVeleroContainer(dpa, veleroDeployment, veleroContainer, projectServiceAccountToken, prometheusPort); err != nil {
 		return err
 	}
 
@@ -368,6 +561,14 @@ func (r *DPAReconciler) customizeVeleroDeployment(dpa *oadpv1alpha1.DataProtecti
 	disableInformerCache := disableInformerCacheValue(dpa)
 	veleroContainer.Args = append(veleroContainer.Args, fmt.Sprintf("--disable-informer-cache=%s", disableInformerCache))
 
+	if disabledControllers := getDisabledControllers(dpa); len(disabledControllers) > 0 {
+		veleroContainer.Args = append(veleroContainer.Args, fmt.Sprintf("--disable-controllers=%s", strings.Join(disabledControllers, ",")))
+	}
+
+	if profilerEnabled(dpa) && dpa.Spec.Configuration.Velero.ProfilerAddress != "" {
+		veleroContainer.Args = append(veleroContainer.Args, fmt.Sprintf("--profiler-address=%s", dpa.Spec.Configuration.Velero.ProfilerAddress))
+	}
+
 	// Set defaults to avoid update events
 	if veleroDeployment.Spec.Strategy.Type == "" {
 		veleroDeployment.Spec.Strategy.Type = appsv1.RollingUpdateDeploymentStrategyType
@@ -385,10 +586,259 @@ func (r *DPAReconciler) customizeVeleroDeployment(dpa *oadpv1alpha1.DataProtecti
 		veleroDeployment.Spec.ProgressDeadlineSeconds = pointer.Int32(600)
 	}
 	setPodTemplateSpecDefaults(&veleroDeployment.Spec.Template)
-	return credentials.AppendPluginSpecificSpecs(dpa, veleroDeployment, veleroContainer, providerNeedsDefaultCreds, hasCloudStorage)
+	if err := credentials.AppendPluginSpecificSpecs(dpa, veleroDeployment, veleroContainer, providerNeedsDefaultCreds, hasCloudStorage); err != nil {
+		return err
+	}
+	r.appendBackupLocationCredentialMounts(dpa, veleroDeployment, veleroContainer)
+	appendPluginConfigFileMounts(dpa, veleroDeployment, veleroContainer)
+	return appendLogFileVolume(dpa, veleroDeployment, veleroContainer)
+}
+
+// appendBackupLocationCredentialMounts mounts the credential secret of each backup location
+// that sets credentialMountPath into the velero container
+func (r *DPAReconciler) appendBackupLocationCredentialMounts(dpa *oadpv1alpha1.DataProtectionApplication, veleroDeployment *appsv1.Deployment, veleroContainer *corev1.Container) {
+	for i, bslSpec := range dpa.Spec.BackupLocations {
+		if bslSpec.CredentialMountPath == "" {
+			continue
+		}
+		secretName, _ := r.getSecretNameAndKeyforBackupLocation(bslSpec)
+		if secretName == "" {
+			continue
+		}
+		volumeName := fmt.Sprintf("bsl-credentials-%d", i+1)
+		veleroDeployment.Spec.Template.Spec.Volumes = append(veleroDeployment.Spec.Template.Spec.Volumes,
+			corev1.Volume{
+				Name: volumeName,
+				VolumeSource: corev1.VolumeSource{
+					Secret: &corev1.SecretVolumeSource{
+						SecretName:  secretName,
+						DefaultMode: common.DefaultModePtr(),
+					},
+				},
+			})
+		veleroContainer.VolumeMounts = append(veleroContainer.VolumeMounts,
+			corev1.VolumeMount{
+				Name:      volumeName,
+				MountPath: path.Clean(bslSpec.CredentialMountPath),
+				ReadOnly:  true,
+			})
+	}
+}
+
+// appendPluginConfigFileMounts mounts the ConfigMap of each plugin config file into the velero container
+func appendPluginConfigFileMounts(dpa *oadpv1alpha1.DataProtectionApplication, veleroDeployment *appsv1.Deployment, veleroContainer *corev1.Container) {
+	for i, configFile := range dpa.Spec.Configuration.Velero.PluginConfigFiles {
+		volumeName := fmt.Sprintf("plugin-config-%d", i+1)
+		veleroDeployment.Spec.Template.Spec.Volumes = append(veleroDeployment.Spec.Template.Spec.Volumes,
+			corev1.Volume{
+				Name: volumeName,
+				VolumeSource: corev1.VolumeSource{
+					ConfigMap: &corev1.ConfigMapVolumeSource{
+						LocalObjectReference: corev1.LocalObjectReference{Name: configFile.ConfigMap},
+					},
+				},
+			})
+		veleroContainer.VolumeMounts = append(veleroContainer.VolumeMounts,
+			corev1.VolumeMount{
+				Name:      volumeName,
+				MountPath: path.Clean(configFile.MountPath),
+				ReadOnly:  true,
+			})
+	}
+}
+
+// appendLogFileVolume mounts the log volume into the velero container and wraps the velero command so its output
+// is also written to the log file. bash redirects its output to the rotating copy loop then execs the command, so
+// velero keeps receiving the termination signal and its exit code is the container exit code.
+func appendLogFileVolume(dpa *oadpv1alpha1.DataProtectionApplication, veleroDeployment *appsv1.Deployment, veleroContainer *corev1.Container) error {
+	logFile := dpa.Spec.Configuration.Velero.LogFile
+	if logFile == nil {
+		return nil
+	}
+	volumeSource, err := getVolumeSource("logFile", logFile.SizeLimit, logFile.PersistentVolumeClaim)
+	if err != nil {
+		return err
+	}
+	veleroDeployment.Spec.Template.Spec.Volumes = append(veleroDeployment.Spec.Template.Spec.Volumes,
+		corev1.Volume{
+			Name:         veleroLogsVolumeName,
+			VolumeSource: *volumeSource,
+		})
+	veleroContainer.VolumeMounts = append(veleroContainer.VolumeMounts,
+		corev1.VolumeMount{
+			Name:      veleroLogsVolumeName,
+			MountPath: path.Clean(logFile.MountPath),
+		})
+	maxSize := defaultVeleroLogFileMaxSize
+	if volumeSource.EmptyDir != nil && volumeSource.EmptyDir.SizeLimit != nil && volumeSource.EmptyDir.SizeLimit.Value()/4 < maxSize {
+		maxSize = volumeSource.EmptyDir.SizeLimit.Value() / 4
+	}
+	veleroContainer.Env = common.AppendUniqueEnvVars(veleroContainer.Env, []corev1.EnvVar{
+		{
+			Name:  veleroLogFileEnvKey,
+			Value: path.Join(logFile.MountPath, veleroLogFileName),
+		},
+		{
+			Name:  veleroLogFileMaxSizeEnvKey,
+			Value: strconv.FormatInt(maxSize, 10),
+		},
+	})
+	// the command is passed as $0 and $@ of the script, followed by the container args
+	veleroContainer.Command = append([]string{"/bin/bash", "-c", veleroLogFileScript}, veleroContainer.Command...)
+	return nil
+}
+
+// validateLogFile checks the log volume is mounted at an absolute path that does not collide with another mount
+// in the Velero pod
+func validateLogFile(dpa *oadpv1alpha1.DataProtectionApplication) error {
+	logFile := dpa.Spec.Configuration.Velero.LogFile
+	if logFile == nil {
+		return nil
+	}
+	if !path.IsAbs(logFile.MountPath) {
+		return fmt.Errorf("logFile mountPath %q must be an absolute path", logFile.MountPath)
+	}
+	mountPath := path.Clean(logFile.MountPath)
+	if mountPath == "/" {
+		return fmt.Errorf("logFile mountPath cannot be the root directory")
+	}
+	usedMountPaths := reservedVeleroMountPaths()
+	for i, bslSpec := range dpa.Spec.BackupLocations {
+		if bslSpec.CredentialMountPath != "" {
+			usedMountPaths[path.Clean(bslSpec.CredentialMountPath)] = fmt.Sprintf("backupLocations[%d] credentials", i)
+		}
+	}
+	for i, configFile := range dpa.Spec.Configuration.Velero.PluginConfigFiles {
+		usedMountPaths[path.Clean(configFile.MountPath)] = fmt.Sprintf("pluginConfigFiles[%d]", i)
+	}
+	for usedPath, usedBy := range usedMountPaths {
+		if mountPathsOverlap(mountPath, usedPath) {
+			return fmt.Errorf("logFile mountPath %s collides with %s mounted at %s", logFile.MountPath, usedBy, usedPath)
+		}
+	}
+	_, err := getVolumeSource("logFile", logFile.SizeLimit, logFile.PersistentVolumeClaim)
+	return err
+}
+
+// validatePluginConfigFiles ensures every plugin config file ConfigMap exists and is mounted at an absolute
+// path that does not collide with another mount in the Velero pod
+func (r *DPAReconciler) validatePluginConfigFiles(dpa *oadpv1alpha1.DataProtectionApplication) error {
+	usedMountPaths := reservedVeleroMountPaths()
+	for i, bslSpec := range dpa.Spec.BackupLocations {
+		if bslSpec.CredentialMountPath != "" {
+			usedMountPaths[path.Clean(bslSpec.CredentialMountPath)] = fmt.Sprintf("backupLocations[%d] credentials", i)
+		}
+	}
+	for i, configFile := range dpa.Spec.Configuration.Velero.PluginConfigFiles {
+		if configFile.ConfigMap == "" {
+			return fmt.Errorf("pluginConfigFiles[%d] configMap must be set", i)
+		}
+		if !path.IsAbs(configFile.MountPath) {
+			return fmt.Errorf("pluginConfigFiles[%d] mountPath %q must be an absolute path", i, configFile.MountPath)
+		}
+		mountPath := path.Clean(configFile.MountPath)
+		if mountPath == "/" {
+			return fmt.Errorf("pluginConfigFiles[%d] mountPath cannot be the root directory", i)
+		}
+		for usedPath, usedBy := range usedMountPaths {
+			if mountPathsOverlap(mountPath, usedPath) {
+				return fmt.Errorf("pluginConfigFiles[%d] mountPath %s collides with %s mounted at %s", i, configFile.MountPath, usedBy, usedPath)
+			}
+		}
+		usedMountPaths[mountPath] = fmt.Sprintf("pluginConfigFiles[%d]", i)
+
+		configMap := corev1.ConfigMap{}
+		if err := r.Get(r.Context, types.NamespacedName{Namespace: dpa.Namespace, Name: configFile.ConfigMap}, &configMap); err != nil {
+			return fmt.Errorf("pluginConfigFiles[%d] configMap %s/%s: %w", i, dpa.Namespace, configFile.ConfigMap, err)
+		}
+	}
+	return nil
+}
+
+// getPluginsVolumeSource returns the volume source for the plugins volume configured in the DPA,
+// or nil if the Velero install default should be kept
+func getPluginsVolumeSource(dpa *oadpv1alpha1.DataProtectionApplication) (*corev1.VolumeSource, error) {
+	if dpa.Spec.Configuration == nil || dpa.Spec.Configuration.Velero == nil || dpa.Spec.Configuration.Velero.PluginsVolume == nil {
+		return nil, nil
+	}
+	pluginsVolume := dpa.Spec.Configuration.Velero.PluginsVolume
+	if pluginsVolume.SizeLimit == "" && pluginsVolume.PersistentVolumeClaim == "" {
+		return nil, nil
+	}
+	return getVolumeSource("pluginsVolume", pluginsVolume.SizeLimit, pluginsVolume.PersistentVolumeClaim)
+}
+
+// getVolumeSource returns a volume source backed by the persistentVolumeClaim if set, or else an emptyDir with
+// the optional sizeLimit. field is the DPA field the volume is configured in, for error messages.
+func getVolumeSource(field, sizeLimit, persistentVolumeClaim string) (*corev1.VolumeSource, error) {
+	if sizeLimit != "" && persistentVolumeClaim != "" {
+		return nil, fmt.Errorf("%s sizeLimit and persistentVolumeClaim cannot both be set", field)
+	}
+	if persistentVolumeClaim != "" {
+		return &corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+				ClaimName: persistentVolumeClaim,
+			},
+		}, nil
+	}
+	if sizeLimit == "" {
+		return &corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}, nil
+	}
+	quantity, err := resource.ParseQuantity(sizeLimit)
+	if err != nil {
+		return nil, fmt.Errorf("%s sizeLimit %q is invalid: %v", field, sizeLimit, err)
+	}
+	if quantity.Sign() <= 0 {
+		return nil, fmt.Errorf("%s sizeLimit %q must be greater than zero", field, sizeLimit)
+	}
+	return &corev1.VolumeSource{
+		EmptyDir: &corev1.EmptyDirVolumeSource{
+			SizeLimit: &quantity,
+		},
+	}, nil
+}
+
+// customizePluginsVolume replaces the plugins volume created by the Velero install with the one configured in the DPA
+func customizePluginsVolume(dpa *oadpv1alpha1.DataProtectionApplication, veleroDeployment *appsv1.Deployment) error {
+	volumeSource, err := getPluginsVolumeSource(dpa)
+	if err != nil || volumeSource == nil {
+		return err
+	}
+	for i, volume := range veleroDeployment.Spec.Template.Spec.Volumes {
+		if volume.Name == "plugins" {
+			veleroDeployment.Spec.Template.Spec.Volumes[i].VolumeSource = *volumeSource
+		}
+	}
+	return nil
 }
 
-func (r *DPAReconciler) customizeVeleroContainer(dpa *oadpv1alpha1.DataProtectionApplication, veleroDeployment *appsv1.Deployment, veleroContainer *corev1.Container, hasShortLivedCredentials bool, prometheusPort *int) error {
+// customizeReadOnlyRootFilesystem makes the velero container root filesystem read-only. The scratch, plugins and
+// certs directories are already emptyDirs, so an emptyDir is mounted at /tmp for the temporary files velero writes,
+// and HOME, below which the kopia repository config is written, points to it
+func customizeReadOnlyRootFilesystem(veleroDeployment *appsv1.Deployment, veleroContainer *corev1.Container) {
+	veleroDeployment.Spec.Template.Spec.Volumes = append(veleroDeployment.Spec.Template.Spec.Volumes,
+		corev1.Volume{
+			Name: "tmp",
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		})
+	veleroContainer.VolumeMounts = append(veleroContainer.VolumeMounts,
+		corev1.VolumeMount{
+			Name:      "tmp",
+			MountPath: "/tmp",
+		})
+	veleroContainer.Env = common.AppendUniqueEnvVars(veleroContainer.Env, []corev1.EnvVar{{
+		Name:  "HOME",
+		Value: "/tmp",
+	}})
+	if veleroContainer.SecurityContext == nil {
+		veleroContainer.SecurityContext = &corev1.SecurityContext{}
+	}
+	veleroContainer.SecurityContext.ReadOnlyRootFilesystem = pointer.Bool(true)
+}
+
+func (r *DPAReconciler) customizeVeleroContainer(dpa *oadpv1alpha1.DataProtectionApplication, veleroDeployment *appsv1.Deployment, veleroContainer *corev1.Container, projectServiceAccountToken bool, prometheusPort *int) error {
 	if veleroContainer == nil {
 		return fmt.Errorf("could not find velero container in Deployment")
 	}
@@ -401,6 +851,9 @@ func (r *DPAReconciler) customizeVeleroContainer(dpa *oadpv1alpha1.DataProtectio
 		}
 	}
 	veleroContainer.ImagePullPolicy = corev1.PullAlways
+	if len(dpa.Spec.Configuration.Velero.Command) > 0 {
+		veleroContainer.Command = dpa.Spec.Configuration.Velero.Command
+	}
 	veleroContainer.VolumeMounts = append(veleroContainer.VolumeMounts,
 		corev1.VolumeMount{
 			Name:      "certs",
@@ -408,7 +861,7 @@ func (r *DPAReconciler) customizeVeleroContainer(dpa *oadpv1alpha1.DataProtectio
 		},
 	)
 
-	if hasShortLivedCredentials {
+	if projectServiceAccountToken {
 		veleroContainer.VolumeMounts = append(veleroContainer.VolumeMounts,
 			corev1.VolumeMount{
 				Name:      "bound-sa-token",
@@ -428,16 +881,23 @@ func (r *DPAReconciler) customizeVeleroContainer(dpa *oadpv1alpha1.DataProtectio
 			Value: "true",
 		}})
 	}
+	if boolptr.IsSetToTrue(dpa.Spec.Configuration.Velero.ReadOnlyRootFilesystem) {
+		customizeReadOnlyRootFilesystem(veleroDeployment, veleroContainer)
+	}
 
 	// Enable user to specify --fs-backup-timeout (defaults to 4h)
 	// Append FS timeout option manually. Not configurable via install package, missing from podTemplateConfig struct. See: https://github.com/vmware-tanzu/velero/blob/8d57215ded1aa91cdea2cf091d60e072ce3f340f/pkg/install/deployment.go#L34-L45
 	veleroContainer.Args = append(veleroContainer.Args, fmt.Sprintf("--fs-backup-timeout=%s", getFsBackupTimeout(dpa)))
 	// Overriding velero restore resource priorities to OpenShift default (ie. SecurityContextConstraints needs to be restored before pod/SA)
-	veleroContainer.Args = append(veleroContainer.Args, fmt.Sprintf("--restore-resource-priorities=%s", common.DefaultRestoreResourcePriorities.String()))
+	// unless a ConfigMap with custom priorities is referenced
+	restoreResourcePriorities, err := r.getRestoreResourcePriorities(dpa)
+	if err != nil {
+		return err
+	}
+	veleroContainer.Args = append(veleroContainer.Args, fmt.Sprintf("--restore-resource-priorities=%s", restoreResourcePriorities))
 	setContainerDefaults(veleroContainer)
 	// if server args is set, override the default server args
 	if dpa.Spec.Configuration.Velero.Args != nil {
-		var err error
 		veleroContainer.Args, err = dpa.Spec.Configuration.Velero.Args.StringArr(
 			dpa.Spec.Configuration.Velero.FeatureFlags,
 			dpa.Spec.Configuration.Velero.LogLevel)
@@ -458,6 +918,30 @@ func getFsBackupTimeout(dpa *oadpv1alpha1.DataProtectionApplication) string {
 	return defaultFsBackupTimeout
 }
 
+// getRestoreResourcePriorities returns the restore resource priorities from the ConfigMap referenced by
+// restoreResourcePrioritiesConfigMap, or the OADP defaults if none is referenced.
+// Velero only accepts priorities as a flag value, so the ConfigMap content is passed inline instead of mounted.
+func (r *DPAReconciler) getRestoreResourcePriorities(dpa *oadpv1alpha1.DataProtectionApplication) (string, error) {
+	if dpa.Spec.Configuration.Velero.RestoreResourcePrioritiesConfigMap == "" {
+		return common.DefaultRestoreResourcePriorities.String(), nil
+	}
+	configMap := corev1.ConfigMap{}
+	if err := r.Get(r.Context, types.NamespacedName{Namespace: dpa.Namespace, Name: dpa.Spec.Configuration.Velero.RestoreResourcePrioritiesConfigMap}, &configMap); err != nil {
+		return "", fmt.Errorf("restoreResourcePrioritiesConfigMap %s/%s: %w", dpa.Namespace, dpa.Spec.Configuration.Velero.RestoreResourcePrioritiesConfigMap, err)
+	}
+	value, found := configMap.Data[restoreResourcePrioritiesDataKey]
+	if !found || strings.TrimSpace(value) == "" {
+		return "", retriable(fmt.Errorf("restoreResourcePrioritiesConfigMap %s/%s is missing data for key %s", configMap.Namespace, configMap.Name, restoreResourcePrioritiesDataKey))
+	}
+	// allow one resource per line in the ConfigMap
+	value = strings.Join(strings.Fields(strings.ReplaceAll(value, ",", " ")), ",")
+	priorities := restore.Priorities{}
+	if err := priorities.Set(value); err != nil {
+		return "", fmt.Errorf("restoreResourcePrioritiesConfigMap %s/%s has invalid priorities: %v", configMap.Namespace, configMap.Name, err)
+	}
+	return priorities.String(), nil
+}
+
 func getDefaultSnapshotMoveDataValue(dpa *oadpv1alpha1.DataProtectionApplication) string {
 	if dpa.Spec.Configuration.Velero != nil && boolptr.IsSetToTrue(dpa.Spec.Configuration.Velero.DefaultSnapshotMoveData) {
 		return TrueVal
@@ -489,6 +973,246 @@ func disableInformerCacheValue(dpa *oadpv1alpha1.DataProtectionApplication) stri
 	return FalseVal
 }
 
+// validateServiceAccountTokenAudience returns an error if the audience set for the projected
+// service account token cannot be used as a token audience
+func validateServiceAccountTokenAudience(dpa *oadpv1alpha1.DataProtectionApplication) error {
+	audience := dpa.Spec.Configuration.Velero.ServiceAccountTokenAudience
+	if audience == "" {
+		return nil
+	}
+	if strings.IndexFunc(audience, unicode.IsSpace) != -1 {
+		return fmt.Errorf("serviceAccountTokenAudience %q must not contain whitespace", audience)
+	}
+	if len(audience) > maxServiceAccountTokenAudienceLength {
+		return fmt.Errorf("serviceAccountTokenAudience must not be longer than %d characters", maxServiceAccountTokenAudienceLength)
+	}
+	return nil
+}
+
+// validateServiceAccountTokenExpiration returns an error if the expiration set for the projected
+// service account token is outside the range accepted by the kubernetes API
+func validateServiceAccountTokenExpiration(dpa *oadpv1alpha1.DataProtectionApplication) error {
+	expirationSeconds := dpa.Spec.Configuration.Velero.ServiceAccountTokenExpirationSeconds
+	if expirationSeconds == nil {
+		return nil
+	}
+	if *expirationSeconds < minServiceAccountTokenExpirationSeconds || *expirationSeconds > maxServiceAccountTokenExpirationSeconds {
+		return fmt.Errorf("serviceAccountTokenExpirationSeconds %d must be between %d and %d", *expirationSeconds,
+			minServiceAccountTokenExpirationSeconds, maxServiceAccountTokenExpirationSeconds)
+	}
+	return nil
+}
+
+// validateShareProcessNamespace returns an error if shareProcessNamespace is set on the node agent podConfig. The
+// node agent container is privileged and mounts the host pod volumes, sharing its process namespace would expose
+// them to every container of the pod through /proc.
+func validateShareProcessNamespace(dpa *oadpv1alpha1.DataProtectionApplication) error {
+	if podConfig := getNodeAgentPodConfig(dpa); podConfig != nil && boolptr.IsSetToTrue(podConfig.ShareProcessNamespace) {
+		return fmt.Errorf("node agent podConfig shareProcessNamespace cannot be set, the node agent container is privileged")
+	}
+	return nil
+}
+
+// validateVeleroSysctls returns an error if a sysctl set on the Velero pod is not in the safe set,
+// as pods with unsafe sysctls are rejected unless the kubelet allows them
+func validateVeleroSysctls(dpa *oadpv1alpha1.DataProtectionApplication) error {
+	for _, sysctl := range dpa.Spec.Configuration.Velero.Sysctls {
+		if !safeSysctls[sysctl.Name] {
+			return fmt.Errorf("sysctl %s is not allowed on the Velero pod, use one of: %s", sysctl.Name, strings.Join(sortedKeys(safeSysctls), ", "))
+		}
+	}
+	return nil
+}
+
+// validateClientPageSize rejects a negative velero client-page-size arg, 0 disables paging
+func validateClientPageSize(dpa *oadpv1alpha1.DataProtectionApplication) error {
+	args := dpa.Spec.Configuration.Velero.Args
+	if args == nil || args.ClientPageSize == nil {
+		return nil
+	}
+	if *args.ClientPageSize < 0 {
+		return fmt.Errorf("velero args client-page-size %d must not be negative", *args.ClientPageSize)
+	}
+	return nil
+}
+
+// validateDefaultItemOperationTimeout checks defaultItemOperationTimeout is a positive duration
+func validateDefaultItemOperationTimeout(dpa *oadpv1alpha1.DataProtectionApplication) error {
+	timeout := dpa.Spec.Configuration.Velero.DefaultItemOperationTimeout
+	if timeout == "" {
+		return nil
+	}
+	duration, err := time.ParseDuration(timeout)
+	if err != nil {
+		return fmt.Errorf("defaultItemOperationTimeout %q is not a valid duration: %v", timeout, err)
+	}
+	if duration <= 0 {
+		return fmt.Errorf("defaultItemOperationTimeout %q must be greater than zero", timeout)
+	}
+	return nil
+}
+
+// validateResourceTimeout checks resourceTimeout is a positive duration, not lower than defaultItemOperationTimeout.
+// The velero defaults are not compared, as the default resource timeout is lower than the default item operation
+// timeout.
+func validateResourceTimeout(dpa *oadpv1alpha1.DataProtectionApplication) error {
+	timeout := dpa.Spec.Configuration.Velero.ResourceTimeout
+	if timeout == "" {
+		return nil
+	}
+	duration, err := time.ParseDuration(timeout)
+	if err != nil {
+		return fmt.Errorf("resourceTimeout %q is not a valid duration: %v", timeout, err)
+	}
+	if duration <= 0 {
+		return fmt.Errorf("resourceTimeout %q must be greater than zero", timeout)
+	}
+	itemOperationTimeout := dpa.Spec.Configuration.Velero.DefaultItemOperationTimeout
+	// invalid item operation timeouts are reported by validateDefaultItemOperationTimeout
+	if itemOperationDuration, err := time.ParseDuration(itemOperationTimeout); err == nil && duration < itemOperationDuration {
+		return fmt.Errorf("resourceTimeout %q must not be lower than defaultItemOperationTimeout %q", timeout, itemOperationTimeout)
+	}
+	return nil
+}
+
+// validateVeleroCommand checks a velero command override still runs the velero binary, which receives
+// the server args managed by the operator
+func validateVeleroCommand(dpa *oadpv1alpha1.DataProtectionApplication) error {
+	command := dpa.Spec.Configuration.Velero.Command
+	if len(command) == 0 {
+		return nil
+	}
+	for _, element := range command {
+		if path.Base(element) == common.Velero {
+			return nil
+		}
+	}
+	return fmt.Errorf("velero command %q must invoke the velero binary, for example [\"/wrapper\", \"/velero\"]", strings.Join(command, " "))
+}
+
+// profilerEnabled returns whether the DPA opted in to exposing the velero profiler on profilerAddress
+func profilerEnabled(dpa *oadpv1alpha1.DataProtectionApplication) bool {
+	return dpa.Annotations[oadpEnableVeleroProfilerAnnotation] == TrueVal
+}
+
+// validateProfilerAddress checks profilerAddress is a host:port address and that the DPA opted in to exposing
+// the velero profiler, so setting the address alone does not expose it
+func validateProfilerAddress(dpa *oadpv1alpha1.DataProtectionApplication) error {
+	address := dpa.Spec.Configuration.Velero.ProfilerAddress
+	if address == "" {
+		return nil
+	}
+	_, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return fmt.Errorf("velero profilerAddress %q must be a host:port address, for example :6060", address)
+	}
+	if portNumber, err := strconv.Atoi(port); err != nil || portNumber < 1 || portNumber > 65535 {
+		return fmt.Errorf("velero profilerAddress %q port must be between 1 and 65535", address)
+	}
+	if !profilerEnabled(dpa) {
+		return fmt.Errorf("velero profilerAddress requires the %s annotation set to true on the DPA", oadpEnableVeleroProfilerAnnotation)
+	}
+	return nil
+}
+
+// velero listens on these addresses unless the server args set them
+const (
+	veleroDefaultMetricsAddress  = ":8085"
+	veleroDefaultProfilerAddress = "localhost:6060"
+)
+
+// validateVeleroListenPorts checks the metrics and profiler servers of velero listen on different ports, as velero
+// fails to start when the second server cannot bind its port. Addresses that are not host:port are reported by
+// the checks of the field.
+func validateVeleroListenPorts(dpa *oadpv1alpha1.DataProtectionApplication) error {
+	metricsAddress := veleroDefaultMetricsAddress
+	profilerAddress := veleroDefaultProfilerAddress
+	if args := dpa.Spec.Configuration.Velero.Args; args != nil {
+		if args.MetricsAddress != "" {
+			metricsAddress = args.MetricsAddress
+		}
+		if args.ProfilerAddress != "" {
+			profilerAddress = args.ProfilerAddress
+		}
+	}
+	// the profilerAddress field is passed after the server args, so it takes precedence
+	if profilerEnabled(dpa) && dpa.Spec.Configuration.Velero.ProfilerAddress != "" {
+		profilerAddress = dpa.Spec.Configuration.Velero.ProfilerAddress
+	}
+	_, metricsPort, err := net.SplitHostPort(metricsAddress)
+	if err != nil {
+		return nil
+	}
+	_, profilerPort, err := net.SplitHostPort(profilerAddress)
+	if err != nil {
+		return nil
+	}
+	if metricsPort == profilerPort {
+		return fmt.Errorf("velero metrics address %q and profiler address %q use the same port %s, set a different port for one of them", metricsAddress, profilerAddress, metricsPort)
+	}
+	return nil
+}
+
+// getDisabledControllers returns the velero controllers disabled by restoreOnlyMode and disableGarbageCollection
+func getDisabledControllers(dpa *oadpv1alpha1.DataProtectionApplication) []string {
+	disabledControllers := []string{}
+	// restore only mode replaces the deprecated --restore-only flag by disabling the same controllers,
+	// garbage collection included
+	if boolptr.IsSetToTrue(dpa.Spec.Configuration.Velero.RestoreOnlyMode) {
+		return append(disabledControllers, restoreOnlyDisabledControllers...)
+	}
+	if boolptr.IsSetToTrue(dpa.Spec.Configuration.Velero.DisableGarbageCollection) {
+		disabledControllers = append(disabledControllers, garbageCollectionController)
+	}
+	return disabledControllers
+}
+
+// validateDisableGarbageCollection returns an error if garbage collection is disabled while unpaused schedules
+// in the DPA namespace set a backup ttl, as their backups would never expire
+func (r *DPAReconciler) validateDisableGarbageCollection(dpa *oadpv1alpha1.DataProtectionApplication) error {
+	if !boolptr.IsSetToTrue(dpa.Spec.Configuration.Velero.DisableGarbageCollection) {
+		return nil
+	}
+	schedules := velerov1.ScheduleList{}
+	if err := r.List(r.Context, &schedules, client.InNamespace(dpa.Namespace)); err != nil {
+		return err
+	}
+	expiringSchedules := []string{}
+	for _, schedule := range schedules.Items {
+		if !schedule.Spec.Paused && schedule.Spec.Template.TTL.Duration > 0 {
+			expiringSchedules = append(expiringSchedules, schedule.Name)
+		}
+	}
+	if len(expiringSchedules) > 0 {
+		sort.Strings(expiringSchedules)
+		return retriable(fmt.Errorf("disableGarbageCollection cannot be enabled while schedules rely on backup ttl expiry, remove the ttl from or pause schedules: %s", strings.Join(expiringSchedules, ", ")))
+	}
+	return nil
+}
+
+// validateRestoreOnlyMode returns an error if restore only mode is enabled while unpaused schedules
+// exist in the DPA namespace, as they would silently stop creating backups
+func (r *DPAReconciler) validateRestoreOnlyMode(dpa *oadpv1alpha1.DataProtectionApplication) error {
+	if !boolptr.IsSetToTrue(dpa.Spec.Configuration.Velero.RestoreOnlyMode) {
+		return nil
+	}
+	schedules := velerov1.ScheduleList{}
+	if err := r.List(r.Context, &schedules, client.InNamespace(dpa.Namespace)); err != nil {
+		return err
+	}
+	activeSchedules := []string{}
+	for _, schedule := range schedules.Items {
+		if !schedule.Spec.Paused {
+			activeSchedules = append(activeSchedules, schedule.Name)
+		}
+	}
+	if len(activeSchedules) > 0 {
+		sort.Strings(activeSchedules)
+		return retriable(fmt.Errorf("restoreOnlyMode cannot be enabled while schedules are active, pause or delete schedules: %s", strings.Join(activeSchedules, ", ")))
+	}
+	return nil
+}
+
 func (r *DPAReconciler) isSTSTokenNeeded(bsls []oadpv1alpha1.BackupLocation, ns string) bool {
 
 	for _, bsl := range bsls {
@@ -521,6 +1245,155 @@ func getVeleroImage(dpa *oadpv1alpha1.DataProtectionApplication) string {
 	return os.Getenv("RELATED_IMAGE_VELERO")
 }
 
+// validateVeleroImageOverride checks the velero image override is an image reference with a tag or digest, as
+// images without either are pulled with the latest tag
+func validateVeleroImageOverride(dpa *oadpv1alpha1.DataProtectionApplication) error {
+	image := dpa.Spec.UnsupportedOverrides[oadpv1alpha1.VeleroImageKey]
+	if image == "" {
+		return nil
+	}
+	match := imageReferenceRegexp.FindStringSubmatch(image)
+	if match == nil {
+		return fmt.Errorf("unsupportedOverrides %s %q is not a valid image reference", oadpv1alpha1.VeleroImageKey, image)
+	}
+	if match[1] == "" && match[2] == "" {
+		return fmt.Errorf("unsupportedOverrides %s %q must have a tag or digest, images without one are pulled with the latest tag", oadpv1alpha1.VeleroImageKey, image)
+	}
+	return nil
+}
+
+// getVeleroImageVersion returns the major and minor velero version from the tag of a velero image, ok is false
+// when the image is not tagged with a version, e.g. latest or a digest
+func getVeleroImageVersion(image string) (major, minor int, ok bool) {
+	tag, found := getImageTag(image)
+	if !found {
+		return 0, 0, false
+	}
+	return parseVeleroVersion(tag)
+}
+
+// getImageTag returns the tag of an image, found is false for images only referenced by digest
+func getImageTag(image string) (tag string, found bool) {
+	image, _, _ = strings.Cut(image, "@")
+	image = image[strings.LastIndex(image, "/")+1:]
+	_, tag, found = strings.Cut(image, ":")
+	return tag, found
+}
+
+// warnDivergentPluginImageTags emits a warning for every custom plugin image tagged differently than the velero image
+func (r *DPAReconciler) warnDivergentPluginImageTags(log logr.Logger, dpa *oadpv1alpha1.DataProtectionApplication) {
+	for _, msg := range divergentPluginImageTags(dpa) {
+		r.warnEvent(log, dpa, "DivergentPluginImageTag", msg)
+	}
+}
+
+// divergentPluginImageTags returns a message for each custom plugin image with a tag other than the tag of the
+// velero image. The velero and plugin images of a release share their tag, plugins from another release may use a
+// plugin protocol velero does not support. Images only referenced by digest are not compared.
+func divergentPluginImageTags(dpa *oadpv1alpha1.DataProtectionApplication) []string {
+	messages := []string{}
+	veleroImage := getVeleroImage(dpa)
+	veleroTag, found := getImageTag(veleroImage)
+	if !found {
+		return messages
+	}
+	for _, plugin := range dpa.Spec.Configuration.Velero.CustomPlugins {
+		if tag, found := getImageTag(plugin.Image); found && tag != veleroTag {
+			messages = append(messages, fmt.Sprintf("custom plugin %s image %s is tagged %s, but velero image %s is tagged %s, plugins from another release may not be compatible with velero", plugin.Name, plugin.Image, tag, veleroImage, veleroTag))
+		}
+	}
+	return messages
+}
+
+func parseVeleroVersion(version string) (major, minor int, ok bool) {
+	match := veleroVersionRegexp.FindStringSubmatch(version)
+	if match == nil {
+		return 0, 0, false
+	}
+	major, _ = strconv.Atoi(match[1])
+	minor, _ = strconv.Atoi(match[2])
+	return major, minor, true
+}
+
+// validateRemovedFeatureFlags returns an error if a velero feature flag was removed in, or before, the velero
+// version of the velero image. Images not tagged with a version are not checked.
+func validateRemovedFeatureFlags(dpa *oadpv1alpha1.DataProtectionApplication) error {
+	image := getVeleroImage(dpa)
+	major, minor, ok := getVeleroImageVersion(image)
+	if !ok {
+		return nil
+	}
+	for removedIn, flags := range removedVeleroFeatureFlags {
+		removedMajor, removedMinor, _ := parseVeleroVersion(removedIn)
+		if major < removedMajor || (major == removedMajor && minor < removedMinor) {
+			continue
+		}
+		for _, flag := range flags {
+			if dpa.Spec.Configuration.Velero.HasFeatureFlag(flag) {
+				return fmt.Errorf("velero feature flag %s was removed in velero %s and is not supported by velero image %s", flag, removedIn, image)
+			}
+		}
+	}
+	return nil
+}
+
+// unrecognizedFeatureFlags returns a message for each feature flag that is neither a velero nor an OADP feature
+// flag, suggesting the recognized flag it differs from only by case
+func unrecognizedFeatureFlags(dpa *oadpv1alpha1.DataProtectionApplication) []string {
+	if dpa.Spec.Configuration == nil || dpa.Spec.Configuration.Velero == nil {
+		return nil
+	}
+	recognized := append(append([]string{}, veleroFeatureFlags...), oadpFeatureFlags...)
+	messages := []string{}
+	for _, flag := range dpa.Spec.Configuration.Velero.FeatureFlags {
+		message := fmt.Sprintf("feature flag %q is not recognized and is ignored", flag)
+		found := false
+		for _, recognizedFlag := range recognized {
+			if flag == recognizedFlag {
+				found = true
+				break
+			}
+			if strings.EqualFold(flag, recognizedFlag) {
+				message = fmt.Sprintf("feature flag %q is not recognized and is ignored, did you mean %s?", flag, recognizedFlag)
+			}
+		}
+		if !found {
+			messages = append(messages, message)
+		}
+	}
+	return messages
+}
+
+// validateNoSecretFeatureFlag returns an error if the no-secret feature flag is set while a velero backup or
+// snapshot location sets a credential, as no-secret skips the credentials the location relies on
+func validateNoSecretFeatureFlag(dpa *oadpv1alpha1.DataProtectionApplication) error {
+	if !dpa.Spec.Configuration.Velero.HasFeatureFlag(noSecretFeatureFlag) {
+		return nil
+	}
+	for i, location := range dpa.Spec.BackupLocations {
+		if location.Velero != nil && location.Velero.Credential != nil {
+			return fmt.Errorf("feature flag %s cannot be set while backupLocations[%d] sets credential %s, remove the flag or the credential", noSecretFeatureFlag, i, location.Velero.Credential.Name)
+		}
+	}
+	for i, location := range dpa.Spec.SnapshotLocations {
+		if location.Velero != nil && location.Velero.Credential != nil {
+			return fmt.Errorf("feature flag %s cannot be set while snapshotLocations[%d] sets credential %s, remove the flag or the credential", noSecretFeatureFlag, i, location.Velero.Credential.Name)
+		}
+	}
+	return nil
+}
+
+// getVeleroReplicas returns the replica count of the velero deployment
+func getVeleroReplicas() int32 {
+	replicas := int32(1)
+	if value, present := os.LookupEnv(VeleroReplicaOverride); present {
+		if converted, err := strconv.Atoi(value); err == nil {
+			replicas = int32(converted)
+		}
+	}
+	return replicas
+}
+
 func getDpaAppLabels(dpa *oadpv1alpha1.DataProtectionApplication) map[string]string {
 	//append dpa name
 	if dpa != nil {
@@ -593,6 +1466,33 @@ func (r *DPAReconciler) getVeleroResourceReqs(dpa *oadpv1alpha1.DataProtectionAp
 			}
 		}
 
+		if dpa.Spec.Configuration.Velero.PodConfig.ResourceAllocations.Requests.StorageEphemeral() != nil && dpa.Spec.Configuration.Velero.PodConfig.ResourceAllocations.Requests.StorageEphemeral().Value() != 0 {
+			parsedQuantity, err := resource.ParseQuantity(dpa.Spec.Configuration.Velero.PodConfig.ResourceAllocations.Requests.StorageEphemeral().String())
+			ResourcesReqs.Requests[corev1.ResourceEphemeralStorage] = parsedQuantity
+			if err != nil {
+				return ResourcesReqs, err
+			}
+		}
+
+		if dpa.Spec.Configuration.Velero.PodConfig.ResourceAllocations.Limits.StorageEphemeral() != nil && dpa.Spec.Configuration.Velero.PodConfig.ResourceAllocations.Limits.StorageEphemeral().Value() != 0 {
+			if ResourcesReqs.Limits == nil {
+				ResourcesReqs.Limits = corev1.ResourceList{}
+			}
+			parsedQuantity, err := resource.ParseQuantity(dpa.Spec.Configuration.Velero.PodConfig.ResourceAllocations.Limits.StorageEphemeral().String())
+			ResourcesReqs.Limits[corev1.ResourceEphemeralStorage] = parsedQuantity
+			if err != nil {
+				return ResourcesReqs, err
+			}
+		}
+
+		if err := validateEphemeralStorage(ResourcesReqs); err != nil {
+			return ResourcesReqs, fmt.Errorf("velero %v", err)
+		}
+
+		if err := validateResourceLimits(ResourcesReqs); err != nil {
+			return ResourcesReqs, fmt.Errorf("velero container %v", err)
+		}
+
 	}
 
 	return ResourcesReqs, nil
@@ -649,6 +1549,33 @@ func getResticResourceReqs(dpa *oadpv1alpha1.DataProtectionApplication) (corev1.
 			}
 		}
 
+		if dpa.Spec.Configuration.Restic.PodConfig.ResourceAllocations.Requests.StorageEphemeral() != nil && dpa.Spec.Configuration.Restic.PodConfig.ResourceAllocations.Requests.StorageEphemeral().Value() != 0 {
+			parsedQuantity, err := resource.ParseQuantity(dpa.Spec.Configuration.Restic.PodConfig.ResourceAllocations.Requests.StorageEphemeral().String())
+			ResourcesReqs.Requests[corev1.ResourceEphemeralStorage] = parsedQuantity
+			if err != nil {
+				return ResourcesReqs, err
+			}
+		}
+
+		if dpa.Spec.Configuration.Restic.PodConfig.ResourceAllocations.Limits.StorageEphemeral() != nil && dpa.Spec.Configuration.Restic.PodConfig.ResourceAllocations.Limits.StorageEphemeral().Value() != 0 {
+			if ResourcesReqs.Limits == nil {
+				ResourcesReqs.Limits = corev1.ResourceList{}
+			}
+			parsedQuantity, err := resource.ParseQuantity(dpa.Spec.Configuration.Restic.PodConfig.ResourceAllocations.Limits.StorageEphemeral().String())
+			ResourcesReqs.Limits[corev1.ResourceEphemeralStorage] = parsedQuantity
+			if err != nil {
+				return ResourcesReqs, err
+			}
+		}
+
+		if err := validateEphemeralStorage(ResourcesReqs); err != nil {
+			return ResourcesReqs, fmt.Errorf("restic %v", err)
+		}
+
+		if err := validateResourceLimits(ResourcesReqs); err != nil {
+			return ResourcesReqs, fmt.Errorf("restic container %v", err)
+		}
+
 	}
 
 	return ResourcesReqs, nil
@@ -707,11 +1634,61 @@ func getNodeAgentResourceReqs(dpa *oadpv1alpha1.DataProtectionApplication) (core
 			}
 		}
 
+		if dpa.Spec.Configuration.NodeAgent.PodConfig.ResourceAllocations.Requests.StorageEphemeral() != nil && dpa.Spec.Configuration.NodeAgent.PodConfig.ResourceAllocations.Requests.StorageEphemeral().Value() != 0 {
+			parsedQuantity, err := resource.ParseQuantity(dpa.Spec.Configuration.NodeAgent.PodConfig.ResourceAllocations.Requests.StorageEphemeral().String())
+			ResourcesReqs.Requests[corev1.ResourceEphemeralStorage] = parsedQuantity
+			if err != nil {
+				return ResourcesReqs, err
+			}
+		}
+
+		if dpa.Spec.Configuration.NodeAgent.PodConfig.ResourceAllocations.Limits.StorageEphemeral() != nil && dpa.Spec.Configuration.NodeAgent.PodConfig.ResourceAllocations.Limits.StorageEphemeral().Value() != 0 {
+			if ResourcesReqs.Limits == nil {
+				ResourcesReqs.Limits = corev1.ResourceList{}
+			}
+			parsedQuantity, err := resource.ParseQuantity(dpa.Spec.Configuration.NodeAgent.PodConfig.ResourceAllocations.Limits.StorageEphemeral().String())
+			ResourcesReqs.Limits[corev1.ResourceEphemeralStorage] = parsedQuantity
+			if err != nil {
+				return ResourcesReqs, err
+			}
+		}
+
+		if err := validateEphemeralStorage(ResourcesReqs); err != nil {
+			return ResourcesReqs, fmt.Errorf("nodeAgent %v", err)
+		}
+
+		if err := validateResourceLimits(ResourcesReqs); err != nil {
+			return ResourcesReqs, fmt.Errorf("nodeAgent container %v", err)
+		}
+
 	}
 
 	return ResourcesReqs, nil
 }
 
+// validateEphemeralStorage returns an error if the ephemeral storage request is higher than its limit
+func validateEphemeralStorage(resourceReqs corev1.ResourceRequirements) error {
+	request, hasRequest := resourceReqs.Requests[corev1.ResourceEphemeralStorage]
+	limit, hasLimit := resourceReqs.Limits[corev1.ResourceEphemeralStorage]
+	if hasRequest && hasLimit && request.Cmp(limit) > 0 {
+		return fmt.Errorf("ephemeral-storage request %s must be less than or equal to limit %s", request.String(), limit.String())
+	}
+	return nil
+}
+
+// validateResourceLimits returns an error if the cpu or memory limit is lower than its request, which would otherwise
+// only surface as a pod creation failure
+func validateResourceLimits(resourceReqs corev1.ResourceRequirements) error {
+	for _, resourceName := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+		request, hasRequest := resourceReqs.Requests[resourceName]
+		limit, hasLimit := resourceReqs.Limits[resourceName]
+		if hasRequest && hasLimit && limit.Cmp(request) < 0 {
+			return fmt.Errorf("%s limit (%s) is less than request (%s)", resourceName, limit.String(), request.String())
+		}
+	}
+	return nil
+}
+
 // noDefaultCredentials determines if a provider needs the default credentials.
 // This returns a map of providers found to if they need a default credential,
 // a boolean if Cloud Storage backup storage location was used and an error if any occured.
@@ -721,7 +1698,7 @@ func (r DPAReconciler) noDefaultCredentials(dpa oadpv1alpha1.DataProtectionAppli
 	if dpa.Spec.Configuration.Velero.NoDefaultBackupLocation {
 		needDefaultCred := false
 
-		if dpa.Spec.UnsupportedOverrides[oadpv1alpha1.OperatorTypeKey] == oadpv1alpha1.OperatorTypeMTC {
+		if dpa.IsOperatorTypeMTC() {
 			// MTC requires default credentials
 			needDefaultCred = true
 		}
@@ -733,11 +1710,12 @@ func (r DPAReconciler) noDefaultCredentials(dpa oadpv1alpha1.DataProtectionAppli
 		}
 	} else {
 		for _, bsl := range dpa.Spec.BackupLocations {
-			if bsl.Velero != nil && bsl.Velero.Credential == nil {
+			// backup locations using GCP workload identity need no credentials, like those with their own credential
+			if bsl.Velero != nil && bsl.Velero.Credential == nil && !usesGCPWorkloadIdentity(bsl.Velero) {
 				bslProvider := strings.TrimPrefix(bsl.Velero.Provider, veleroIOPrefix)
 				providerNeedsDefaultCreds[bslProvider] = true
 			}
-			if bsl.Velero != nil && bsl.Velero.Credential != nil {
+			if bsl.Velero != nil && (bsl.Velero.Credential != nil || usesGCPWorkloadIdentity(bsl.Velero)) {
 				bslProvider := strings.TrimPrefix(bsl.Velero.Provider, veleroIOPrefix)
 				if _, found := providerNeedsDefaultCreds[bslProvider]; !found {
 					providerNeedsDefaultCreds[bslProvider] = false
@@ -11,6 +11,7 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/google/go-cmp/cmp"
 	"github.com/sirupsen/logrus"
+	velerov1 "github.com/vmware-tanzu/velero/pkg/apis/velero/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -2499,24 +2500,14 @@ func TestDPAReconciler_buildVeleroDeployment(t *testing.T) {
 			},
 		},
 		{
-			name: "given valid DPA CR and ResourceTimeout is defined correctly, ResourceTimeout is set",
+			name: "given valid DPA CR and ServiceAccountTokenAudience is set, projected service account token volume is built",
 			veleroDeployment: &appsv1.Deployment{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "test-velero-deployment",
 					Namespace: "test-ns",
 				},
 				Spec: appsv1.DeploymentSpec{
-					Selector: &metav1.LabelSelector{
-						MatchLabels: map[string]string{
-							"app.kubernetes.io/name":       common.Velero,
-							"app.kubernetes.io/instance":   "test-Velero-CR",
-							"app.kubernetes.io/managed-by": common.OADPOperator,
-							"app.kubernetes.io/component":  Server,
-							"component":                    "velero",
-							"deploy":                       "velero",
-							oadpv1alpha1.OadpOperatorLabel: "True",
-						},
-					},
+					Selector: &metav1.LabelSelector{MatchLabels: veleroDeploymentMatchLabels},
 				},
 			},
 			dpa: &oadpv1alpha1.DataProtectionApplication{
@@ -2527,8 +2518,7 @@ func TestDPAReconciler_buildVeleroDeployment(t *testing.T) {
 				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
 					Configuration: &oadpv1alpha1.ApplicationConfig{
 						Velero: &oadpv1alpha1.VeleroConfig{
-							LogLevel:        logrus.InfoLevel.String(),
-							ResourceTimeout: "5m",
+							ServiceAccountTokenAudience: "sts.amazonaws.com",
 						},
 					},
 				},
@@ -2538,49 +2528,17 @@ func TestDPAReconciler_buildVeleroDeployment(t *testing.T) {
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "test-velero-deployment",
 					Namespace: "test-ns",
-					Labels: map[string]string{
-						"app.kubernetes.io/name":       common.Velero,
-						"app.kubernetes.io/instance":   "test-Velero-CR",
-						"app.kubernetes.io/managed-by": common.OADPOperator,
-						"app.kubernetes.io/component":  Server,
-						"component":                    "velero",
-						oadpv1alpha1.OadpOperatorLabel: "True",
-					},
+					Labels:    veleroDeploymentLabel,
 				},
 				TypeMeta: metav1.TypeMeta{
 					Kind:       "Deployment",
 					APIVersion: appsv1.SchemeGroupVersion.String(),
 				},
 				Spec: appsv1.DeploymentSpec{
-					Selector: &metav1.LabelSelector{
-						MatchLabels: map[string]string{
-							"app.kubernetes.io/name":       common.Velero,
-							"app.kubernetes.io/instance":   "test-Velero-CR",
-							"app.kubernetes.io/managed-by": common.OADPOperator,
-							"app.kubernetes.io/component":  Server,
-							"component":                    "velero",
-							"deploy":                       "velero",
-							oadpv1alpha1.OadpOperatorLabel: "True",
-						},
-					},
+					Selector: &metav1.LabelSelector{MatchLabels: veleroDeploymentMatchLabels},
 					Replicas: pointer.Int32(1),
 					Template: corev1.PodTemplateSpec{
-						ObjectMeta: metav1.ObjectMeta{
-							Labels: map[string]string{
-								"app.kubernetes.io/name":       common.Velero,
-								"app.kubernetes.io/instance":   "test-Velero-CR",
-								"app.kubernetes.io/managed-by": common.OADPOperator,
-								"app.kubernetes.io/component":  Server,
-								"component":                    "velero",
-								"deploy":                       "velero",
-								oadpv1alpha1.OadpOperatorLabel: "True",
-							},
-							Annotations: map[string]string{
-								"prometheus.io/scrape": "true",
-								"prometheus.io/port":   "8085",
-								"prometheus.io/path":   "/metrics",
-							},
-						},
+						ObjectMeta: veleroPodObjectMeta,
 						Spec: corev1.PodSpec{
 							RestartPolicy:      corev1.RestartPolicyAlways,
 							ServiceAccountName: common.Velero,
@@ -2589,86 +2547,40 @@ func TestDPAReconciler_buildVeleroDeployment(t *testing.T) {
 									Name:            common.Velero,
 									Image:           common.VeleroImage,
 									ImagePullPolicy: corev1.PullAlways,
-									Ports: []corev1.ContainerPort{
-										{
-											Name:          "metrics",
-											ContainerPort: 8085,
-										},
-									},
-									Resources: corev1.ResourceRequirements{
-										Requests: corev1.ResourceList{
-											corev1.ResourceCPU:    resource.MustParse("500m"),
-											corev1.ResourceMemory: resource.MustParse("128Mi"),
-										},
-									},
-									Command: []string{"/velero"},
+									Ports:           []corev1.ContainerPort{{Name: "metrics", ContainerPort: 8085}},
+									Resources:       corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m"), corev1.ResourceMemory: resource.MustParse("128Mi")}},
+									Command:         []string{"/velero"},
 									Args: []string{
 										"server",
 										defaultFileSystemBackupTimeout,
 										defaultRestoreResourcePriorities,
-										"--log-level",
-										logrus.InfoLevel.String(),
-										"--resource-timeout=5m",
 										defaultDisableInformerCache,
 									},
-									VolumeMounts: []corev1.VolumeMount{
-										{
-											Name:      "plugins",
-											MountPath: "/plugins",
-										},
-										{
-											Name:      "scratch",
-											MountPath: "/scratch",
-										},
-										{
-											Name:      "certs",
-											MountPath: "/etc/ssl/certs",
-										},
-									},
-									Env: []corev1.EnvVar{
-										{
-											Name:  common.VeleroScratchDirEnvKey,
-											Value: "/scratch",
-										},
-										{
-											Name: common.VeleroNamespaceEnvKey,
-											ValueFrom: &corev1.EnvVarSource{
-												FieldRef: &corev1.ObjectFieldSelector{
-													FieldPath: "metadata.namespace",
+									VolumeMounts: append(baseVolumeMounts, corev1.VolumeMount{
+										Name:      "bound-sa-token",
+										MountPath: "/var/run/secrets/openshift/serviceaccount",
+										ReadOnly:  true,
+									}),
+									Env: baseEnvVars,
+								},
+							},
+							Volumes: append(baseVolumes, corev1.Volume{
+								Name: "bound-sa-token",
+								VolumeSource: corev1.VolumeSource{
+									Projected: &corev1.ProjectedVolumeSource{
+										DefaultMode: common.DefaultModePtr(),
+										Sources: []corev1.VolumeProjection{
+											{
+												ServiceAccountToken: &corev1.ServiceAccountTokenProjection{
+													Audience:          "sts.amazonaws.com",
+													ExpirationSeconds: pointer.Int64(3600),
+													Path:              "token",
 												},
 											},
 										},
-										{
-											Name:  common.LDLibraryPathEnvKey,
-											Value: "/plugins",
-										},
-										{
-											Name:  "OPENSHIFT_IMAGESTREAM_BACKUP",
-											Value: "true",
-										},
-									},
-								},
-							},
-							Volumes: []corev1.Volume{
-								{
-									Name: "plugins",
-									VolumeSource: corev1.VolumeSource{
-										EmptyDir: &corev1.EmptyDirVolumeSource{},
-									},
-								},
-								{
-									Name: "scratch",
-									VolumeSource: corev1.VolumeSource{
-										EmptyDir: &corev1.EmptyDirVolumeSource{},
-									},
-								},
-								{
-									Name: "certs",
-									VolumeSource: corev1.VolumeSource{
-										EmptyDir: &corev1.EmptyDirVolumeSource{},
 									},
 								},
-							},
+							}),
 							InitContainers: []corev1.Container{},
 						},
 					},
@@ -2676,43 +2588,12 @@ func TestDPAReconciler_buildVeleroDeployment(t *testing.T) {
 			},
 		},
 		{
-			name: "given valid DPA CR and log level is defined incorrectly error is returned",
-			veleroDeployment: &appsv1.Deployment{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      "test-velero-deployment",
-					Namespace: "test-ns",
-				},
-				Spec: appsv1.DeploymentSpec{
-					Selector: &metav1.LabelSelector{MatchLabels: veleroDeploymentMatchLabels},
-				},
-			},
-			dpa: &oadpv1alpha1.DataProtectionApplication{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      "test-Velero-CR",
-					Namespace: "test-ns",
-				},
-				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
-					Configuration: &oadpv1alpha1.ApplicationConfig{
-						Velero: &oadpv1alpha1.VeleroConfig{
-							LogLevel: logrus.InfoLevel.String() + "typo",
-						},
-					},
-				},
-			},
-			wantErr:              true,
-			wantVeleroDeployment: nil,
-		},
-		{
-			name: "given valid DPA CR, velero deployment resource customization",
+			name: "given valid DPA CR and ServiceAccountTokenExpirationSeconds is set, projected service account token volume is built with the expiration",
 			veleroDeployment: &appsv1.Deployment{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "test-velero-deployment",
 					Namespace: "test-ns",
 				},
-				TypeMeta: metav1.TypeMeta{
-					Kind:       "Deployment",
-					APIVersion: appsv1.SchemeGroupVersion.String(),
-				},
 				Spec: appsv1.DeploymentSpec{
 					Selector: &metav1.LabelSelector{MatchLabels: veleroDeploymentMatchLabels},
 				},
@@ -2725,18 +2606,7 @@ func TestDPAReconciler_buildVeleroDeployment(t *testing.T) {
 				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
 					Configuration: &oadpv1alpha1.ApplicationConfig{
 						Velero: &oadpv1alpha1.VeleroConfig{
-							PodConfig: &oadpv1alpha1.PodConfig{
-								ResourceAllocations: corev1.ResourceRequirements{
-									Limits: corev1.ResourceList{
-										corev1.ResourceCPU:    resource.MustParse("2"),
-										corev1.ResourceMemory: resource.MustParse("700Mi"),
-									},
-									Requests: corev1.ResourceList{
-										corev1.ResourceCPU:    resource.MustParse("1"),
-										corev1.ResourceMemory: resource.MustParse("256Mi"),
-									},
-								},
-							},
+							ServiceAccountTokenExpirationSeconds: pointer.Int64(7200),
 						},
 					},
 				},
@@ -2766,28 +2636,39 @@ func TestDPAReconciler_buildVeleroDeployment(t *testing.T) {
 									Image:           common.VeleroImage,
 									ImagePullPolicy: corev1.PullAlways,
 									Ports:           []corev1.ContainerPort{{Name: "metrics", ContainerPort: 8085}},
-									Resources: corev1.ResourceRequirements{
-										Limits: corev1.ResourceList{
-											corev1.ResourceCPU:    resource.MustParse("2"),
-											corev1.ResourceMemory: resource.MustParse("700Mi"),
-										},
-										Requests: corev1.ResourceList{
-											corev1.ResourceCPU:    resource.MustParse("1"),
-											corev1.ResourceMemory: resource.MustParse("256Mi"),
-										},
-									},
-									Command: []string{"/velero"},
+									Resources:       corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m"), corev1.ResourceMemory: resource.MustParse("128Mi")}},
+									Command:         []string{"/velero"},
 									Args: []string{
 										"server",
 										defaultFileSystemBackupTimeout,
 										defaultRestoreResourcePriorities,
 										defaultDisableInformerCache,
 									},
-									VolumeMounts: baseVolumeMounts,
-									Env:          baseEnvVars,
+									VolumeMounts: append(baseVolumeMounts, corev1.VolumeMount{
+										Name:      "bound-sa-token",
+										MountPath: "/var/run/secrets/openshift/serviceaccount",
+										ReadOnly:  true,
+									}),
+									Env: baseEnvVars,
 								},
 							},
-							Volumes:        baseVolumes,
+							Volumes: append(baseVolumes, corev1.Volume{
+								Name: "bound-sa-token",
+								VolumeSource: corev1.VolumeSource{
+									Projected: &corev1.ProjectedVolumeSource{
+										DefaultMode: common.DefaultModePtr(),
+										Sources: []corev1.VolumeProjection{
+											{
+												ServiceAccountToken: &corev1.ServiceAccountTokenProjection{
+													Audience:          "openshift",
+													ExpirationSeconds: pointer.Int64(7200),
+													Path:              "token",
+												},
+											},
+										},
+									},
+								},
+							}),
 							InitContainers: []corev1.Container{},
 						},
 					},
@@ -2795,16 +2676,12 @@ func TestDPAReconciler_buildVeleroDeployment(t *testing.T) {
 			},
 		},
 		{
-			name: "given valid DPA CR, velero deployment resource customization only cpu limit",
+			name: "given valid DPA CR and RestoreOnlyMode is set to true, backup controllers are disabled",
 			veleroDeployment: &appsv1.Deployment{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "test-velero-deployment",
 					Namespace: "test-ns",
 				},
-				TypeMeta: metav1.TypeMeta{
-					Kind:       "Deployment",
-					APIVersion: appsv1.SchemeGroupVersion.String(),
-				},
 				Spec: appsv1.DeploymentSpec{
 					Selector: &metav1.LabelSelector{MatchLabels: veleroDeploymentMatchLabels},
 				},
@@ -2817,13 +2694,7 @@ func TestDPAReconciler_buildVeleroDeployment(t *testing.T) {
 				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
 					Configuration: &oadpv1alpha1.ApplicationConfig{
 						Velero: &oadpv1alpha1.VeleroConfig{
-							PodConfig: &oadpv1alpha1.PodConfig{
-								ResourceAllocations: corev1.ResourceRequirements{
-									Limits: corev1.ResourceList{
-										corev1.ResourceCPU: resource.MustParse("2"),
-									},
-								},
-							},
+							RestoreOnlyMode: pointer.Bool(true),
 						},
 					},
 				},
@@ -2853,21 +2724,14 @@ func TestDPAReconciler_buildVeleroDeployment(t *testing.T) {
 									Image:           common.VeleroImage,
 									ImagePullPolicy: corev1.PullAlways,
 									Ports:           []corev1.ContainerPort{{Name: "metrics", ContainerPort: 8085}},
-									Resources: corev1.ResourceRequirements{
-										Limits: corev1.ResourceList{
-											corev1.ResourceCPU: resource.MustParse("2"),
-										},
-										Requests: corev1.ResourceList{
-											corev1.ResourceCPU:    resource.MustParse("500m"),
-											corev1.ResourceMemory: resource.MustParse("128Mi"),
-										},
-									},
-									Command: []string{"/velero"},
+									Resources:       corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m"), corev1.ResourceMemory: resource.MustParse("128Mi")}},
+									Command:         []string{"/velero"},
 									Args: []string{
 										"server",
 										defaultFileSystemBackupTimeout,
 										defaultRestoreResourcePriorities,
 										defaultDisableInformerCache,
+										"--disable-controllers=backup,backup-deletion,backup-finalizer,backup-operations,gc,schedule",
 									},
 									VolumeMounts: baseVolumeMounts,
 									Env:          baseEnvVars,
@@ -2881,16 +2745,12 @@ func TestDPAReconciler_buildVeleroDeployment(t *testing.T) {
 			},
 		},
 		{
-			name: "given valid DPA CR, velero deployment resource customization only cpu request",
+			name: "given valid DPA CR and DisableGarbageCollection is set to true, garbage collection controller is disabled",
 			veleroDeployment: &appsv1.Deployment{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "test-velero-deployment",
 					Namespace: "test-ns",
 				},
-				TypeMeta: metav1.TypeMeta{
-					Kind:       "Deployment",
-					APIVersion: appsv1.SchemeGroupVersion.String(),
-				},
 				Spec: appsv1.DeploymentSpec{
 					Selector: &metav1.LabelSelector{MatchLabels: veleroDeploymentMatchLabels},
 				},
@@ -2903,13 +2763,7 @@ func TestDPAReconciler_buildVeleroDeployment(t *testing.T) {
 				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
 					Configuration: &oadpv1alpha1.ApplicationConfig{
 						Velero: &oadpv1alpha1.VeleroConfig{
-							PodConfig: &oadpv1alpha1.PodConfig{
-								ResourceAllocations: corev1.ResourceRequirements{
-									Requests: corev1.ResourceList{
-										corev1.ResourceCPU: resource.MustParse("2"),
-									},
-								},
-							},
+							DisableGarbageCollection: pointer.Bool(true),
 						},
 					},
 				},
@@ -2939,18 +2793,14 @@ func TestDPAReconciler_buildVeleroDeployment(t *testing.T) {
 									Image:           common.VeleroImage,
 									ImagePullPolicy: corev1.PullAlways,
 									Ports:           []corev1.ContainerPort{{Name: "metrics", ContainerPort: 8085}},
-									Resources: corev1.ResourceRequirements{
-										Requests: corev1.ResourceList{
-											corev1.ResourceCPU:    resource.MustParse("2"),
-											corev1.ResourceMemory: resource.MustParse("128Mi"),
-										},
-									},
-									Command: []string{"/velero"},
+									Resources:       corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m"), corev1.ResourceMemory: resource.MustParse("128Mi")}},
+									Command:         []string{"/velero"},
 									Args: []string{
 										"server",
 										defaultFileSystemBackupTimeout,
 										defaultRestoreResourcePriorities,
 										defaultDisableInformerCache,
+										"--disable-controllers=gc",
 									},
 									VolumeMounts: baseVolumeMounts,
 									Env:          baseEnvVars,
@@ -2964,35 +2814,36 @@ func TestDPAReconciler_buildVeleroDeployment(t *testing.T) {
 			},
 		},
 		{
-			name: "given valid DPA CR, velero deployment resource customization only memory request",
+			name: "given valid DPA CR and ResourceTimeout is defined correctly, ResourceTimeout is set",
 			veleroDeployment: &appsv1.Deployment{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "test-velero-deployment",
 					Namespace: "test-ns",
 				},
-				TypeMeta: metav1.TypeMeta{
-					Kind:       "Deployment",
-					APIVersion: appsv1.SchemeGroupVersion.String(),
-				},
 				Spec: appsv1.DeploymentSpec{
-					Selector: &metav1.LabelSelector{MatchLabels: veleroDeploymentMatchLabels},
-				},
-			},
-			dpa: &oadpv1alpha1.DataProtectionApplication{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      "test-Velero-CR",
-					Namespace: "test-ns",
+					Selector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{
+							"app.kubernetes.io/name":       common.Velero,
+							"app.kubernetes.io/instance":   "test-Velero-CR",
+							"app.kubernetes.io/managed-by": common.OADPOperator,
+							"app.kubernetes.io/component":  Server,
+							"component":                    "velero",
+							"deploy":                       "velero",
+							oadpv1alpha1.OadpOperatorLabel: "True",
+						},
+					},
+				},
+			},
+			dpa: &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-Velero-CR",
+					Namespace: "test-ns",
 				},
 				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
 					Configuration: &oadpv1alpha1.ApplicationConfig{
 						Velero: &oadpv1alpha1.VeleroConfig{
-							PodConfig: &oadpv1alpha1.PodConfig{
-								ResourceAllocations: corev1.ResourceRequirements{
-									Requests: corev1.ResourceList{
-										corev1.ResourceMemory: resource.MustParse("256Mi"),
-									},
-								},
-							},
+							LogLevel:        logrus.InfoLevel.String(),
+							ResourceTimeout: "5m",
 						},
 					},
 				},
@@ -3002,17 +2853,49 @@ func TestDPAReconciler_buildVeleroDeployment(t *testing.T) {
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "test-velero-deployment",
 					Namespace: "test-ns",
-					Labels:    veleroDeploymentLabel,
+					Labels: map[string]string{
+						"app.kubernetes.io/name":       common.Velero,
+						"app.kubernetes.io/instance":   "test-Velero-CR",
+						"app.kubernetes.io/managed-by": common.OADPOperator,
+						"app.kubernetes.io/component":  Server,
+						"component":                    "velero",
+						oadpv1alpha1.OadpOperatorLabel: "True",
+					},
 				},
 				TypeMeta: metav1.TypeMeta{
 					Kind:       "Deployment",
 					APIVersion: appsv1.SchemeGroupVersion.String(),
 				},
 				Spec: appsv1.DeploymentSpec{
-					Selector: &metav1.LabelSelector{MatchLabels: veleroDeploymentMatchLabels},
+					Selector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{
+							"app.kubernetes.io/name":       common.Velero,
+							"app.kubernetes.io/instance":   "test-Velero-CR",
+							"app.kubernetes.io/managed-by": common.OADPOperator,
+							"app.kubernetes.io/component":  Server,
+							"component":                    "velero",
+							"deploy":                       "velero",
+							oadpv1alpha1.OadpOperatorLabel: "True",
+						},
+					},
 					Replicas: pointer.Int32(1),
 					Template: corev1.PodTemplateSpec{
-						ObjectMeta: veleroPodObjectMeta,
+						ObjectMeta: metav1.ObjectMeta{
+							Labels: map[string]string{
+								"app.kubernetes.io/name":       common.Velero,
+								"app.kubernetes.io/instance":   "test-Velero-CR",
+								"app.kubernetes.io/managed-by": common.OADPOperator,
+								"app.kubernetes.io/component":  Server,
+								"component":                    "velero",
+								"deploy":                       "velero",
+								oadpv1alpha1.OadpOperatorLabel: "True",
+							},
+							Annotations: map[string]string{
+								"prometheus.io/scrape": "true",
+								"prometheus.io/port":   "8085",
+								"prometheus.io/path":   "/metrics",
+							},
+						},
 						Spec: corev1.PodSpec{
 							RestartPolicy:      corev1.RestartPolicyAlways,
 							ServiceAccountName: common.Velero,
@@ -3021,11 +2904,16 @@ func TestDPAReconciler_buildVeleroDeployment(t *testing.T) {
 									Name:            common.Velero,
 									Image:           common.VeleroImage,
 									ImagePullPolicy: corev1.PullAlways,
-									Ports:           []corev1.ContainerPort{{Name: "metrics", ContainerPort: 8085}},
+									Ports: []corev1.ContainerPort{
+										{
+											Name:          "metrics",
+											ContainerPort: 8085,
+										},
+									},
 									Resources: corev1.ResourceRequirements{
 										Requests: corev1.ResourceList{
 											corev1.ResourceCPU:    resource.MustParse("500m"),
-											corev1.ResourceMemory: resource.MustParse("256Mi"),
+											corev1.ResourceMemory: resource.MustParse("128Mi"),
 										},
 									},
 									Command: []string{"/velero"},
@@ -3033,13 +2921,69 @@ func TestDPAReconciler_buildVeleroDeployment(t *testing.T) {
 										"server",
 										defaultFileSystemBackupTimeout,
 										defaultRestoreResourcePriorities,
+										"--log-level",
+										logrus.InfoLevel.String(),
+										"--resource-timeout=5m",
 										defaultDisableInformerCache,
 									},
-									VolumeMounts: baseVolumeMounts,
-									Env:          baseEnvVars,
+									VolumeMounts: []corev1.VolumeMount{
+										{
+											Name:      "plugins",
+											MountPath: "/plugins",
+										},
+										{
+											Name:      "scratch",
+											MountPath: "/scratch",
+										},
+										{
+											Name:      "certs",
+											MountPath: "/etc/ssl/certs",
+										},
+									},
+									Env: []corev1.EnvVar{
+										{
+											Name:  common.VeleroScratchDirEnvKey,
+											Value: "/scratch",
+										},
+										{
+											Name: common.VeleroNamespaceEnvKey,
+											ValueFrom: &corev1.EnvVarSource{
+												FieldRef: &corev1.ObjectFieldSelector{
+													FieldPath: "metadata.namespace",
+												},
+											},
+										},
+										{
+											Name:  common.LDLibraryPathEnvKey,
+											Value: "/plugins",
+										},
+										{
+											Name:  "OPENSHIFT_IMAGESTREAM_BACKUP",
+											Value: "true",
+										},
+									},
+								},
+							},
+							Volumes: []corev1.Volume{
+								{
+									Name: "plugins",
+									VolumeSource: corev1.VolumeSource{
+										EmptyDir: &corev1.EmptyDirVolumeSource{},
+									},
+								},
+								{
+									Name: "scratch",
+									VolumeSource: corev1.VolumeSource{
+										EmptyDir: &corev1.EmptyDirVolumeSource{},
+									},
+								},
+								{
+									Name: "certs",
+									VolumeSource: corev1.VolumeSource{
+										EmptyDir: &corev1.EmptyDirVolumeSource{},
+									},
 								},
 							},
-							Volumes:        baseVolumes,
 							InitContainers: []corev1.Container{},
 						},
 					},
@@ -3047,7 +2991,34 @@ func TestDPAReconciler_buildVeleroDeployment(t *testing.T) {
 			},
 		},
 		{
-			name: "given valid DPA CR, velero deployment resource customization only memory limit",
+			name: "given valid DPA CR and log level is defined incorrectly error is returned",
+			veleroDeployment: &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-velero-deployment",
+					Namespace: "test-ns",
+				},
+				Spec: appsv1.DeploymentSpec{
+					Selector: &metav1.LabelSelector{MatchLabels: veleroDeploymentMatchLabels},
+				},
+			},
+			dpa: &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-Velero-CR",
+					Namespace: "test-ns",
+				},
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{
+							LogLevel: logrus.InfoLevel.String() + "typo",
+						},
+					},
+				},
+			},
+			wantErr:              true,
+			wantVeleroDeployment: nil,
+		},
+		{
+			name: "given valid DPA CR, velero deployment resource customization",
 			veleroDeployment: &appsv1.Deployment{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "test-velero-deployment",
@@ -3072,7 +3043,12 @@ func TestDPAReconciler_buildVeleroDeployment(t *testing.T) {
 							PodConfig: &oadpv1alpha1.PodConfig{
 								ResourceAllocations: corev1.ResourceRequirements{
 									Limits: corev1.ResourceList{
-										corev1.ResourceMemory: resource.MustParse("128Mi"),
+										corev1.ResourceCPU:    resource.MustParse("2"),
+										corev1.ResourceMemory: resource.MustParse("700Mi"),
+									},
+									Requests: corev1.ResourceList{
+										corev1.ResourceCPU:    resource.MustParse("1"),
+										corev1.ResourceMemory: resource.MustParse("256Mi"),
 									},
 								},
 							},
@@ -3107,11 +3083,12 @@ func TestDPAReconciler_buildVeleroDeployment(t *testing.T) {
 									Ports:           []corev1.ContainerPort{{Name: "metrics", ContainerPort: 8085}},
 									Resources: corev1.ResourceRequirements{
 										Limits: corev1.ResourceList{
-											corev1.ResourceMemory: resource.MustParse("128Mi"),
+											corev1.ResourceCPU:    resource.MustParse("2"),
+											corev1.ResourceMemory: resource.MustParse("700Mi"),
 										},
 										Requests: corev1.ResourceList{
-											corev1.ResourceCPU:    resource.MustParse("500m"),
-											corev1.ResourceMemory: resource.MustParse("128Mi"),
+											corev1.ResourceCPU:    resource.MustParse("1"),
+											corev1.ResourceMemory: resource.MustParse("256Mi"),
 										},
 									},
 									Command: []string{"/velero"},
@@ -3133,7 +3110,7 @@ func TestDPAReconciler_buildVeleroDeployment(t *testing.T) {
 			},
 		},
 		{
-			name: "given valid DPA CR, velero deployment tolerations",
+			name: "given valid DPA CR, velero deployment ephemeral storage resource customization",
 			veleroDeployment: &appsv1.Deployment{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "test-velero-deployment",
@@ -3158,20 +3135,14 @@ func TestDPAReconciler_buildVeleroDeployment(t *testing.T) {
 							PodConfig: &oadpv1alpha1.PodConfig{
 								ResourceAllocations: corev1.ResourceRequirements{
 									Limits: corev1.ResourceList{
-										corev1.ResourceCPU:    resource.MustParse("2"),
-										corev1.ResourceMemory: resource.MustParse("700Mi"),
+										corev1.ResourceCPU:              resource.MustParse("2"),
+										corev1.ResourceMemory:           resource.MustParse("700Mi"),
+										corev1.ResourceEphemeralStorage: resource.MustParse("4Gi"),
 									},
 									Requests: corev1.ResourceList{
-										corev1.ResourceCPU:    resource.MustParse("1"),
-										corev1.ResourceMemory: resource.MustParse("256Mi"),
-									},
-								},
-								Tolerations: []corev1.Toleration{
-									{
-										Key:      "key1",
-										Operator: "Equal",
-										Value:    "value1",
-										Effect:   "NoSchedule",
+										corev1.ResourceCPU:              resource.MustParse("1"),
+										corev1.ResourceMemory:           resource.MustParse("256Mi"),
+										corev1.ResourceEphemeralStorage: resource.MustParse("1Gi"),
 									},
 								},
 							},
@@ -3184,14 +3155,7 @@ func TestDPAReconciler_buildVeleroDeployment(t *testing.T) {
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "test-velero-deployment",
 					Namespace: "test-ns",
-					Labels: map[string]string{
-						"app.kubernetes.io/name":       common.Velero,
-						"app.kubernetes.io/instance":   "test-Velero-CR",
-						"app.kubernetes.io/managed-by": common.OADPOperator,
-						"app.kubernetes.io/component":  Server,
-						oadpv1alpha1.OadpOperatorLabel: "True",
-						"component":                    "velero",
-					},
+					Labels:    veleroDeploymentLabel,
 				},
 				TypeMeta: metav1.TypeMeta{
 					Kind:       "Deployment",
@@ -3201,21 +3165,10 @@ func TestDPAReconciler_buildVeleroDeployment(t *testing.T) {
 					Selector: &metav1.LabelSelector{MatchLabels: veleroDeploymentMatchLabels},
 					Replicas: pointer.Int32(1),
 					Template: corev1.PodTemplateSpec{
-						ObjectMeta: metav1.ObjectMeta{
-							Labels:      veleroDeploymentMatchLabels,
-							Annotations: veleroPodAnnotations,
-						},
+						ObjectMeta: veleroPodObjectMeta,
 						Spec: corev1.PodSpec{
 							RestartPolicy:      corev1.RestartPolicyAlways,
 							ServiceAccountName: common.Velero,
-							Tolerations: []corev1.Toleration{
-								{
-									Key:      "key1",
-									Operator: "Equal",
-									Value:    "value1",
-									Effect:   "NoSchedule",
-								},
-							},
 							Containers: []corev1.Container{
 								{
 									Name:            common.Velero,
@@ -3224,12 +3177,14 @@ func TestDPAReconciler_buildVeleroDeployment(t *testing.T) {
 									Ports:           []corev1.ContainerPort{{Name: "metrics", ContainerPort: 8085}},
 									Resources: corev1.ResourceRequirements{
 										Limits: corev1.ResourceList{
-											corev1.ResourceCPU:    resource.MustParse("2"),
-											corev1.ResourceMemory: resource.MustParse("700Mi"),
+											corev1.ResourceCPU:              resource.MustParse("2"),
+											corev1.ResourceMemory:           resource.MustParse("700Mi"),
+											corev1.ResourceEphemeralStorage: resource.MustParse("4Gi"),
 										},
 										Requests: corev1.ResourceList{
-											corev1.ResourceCPU:    resource.MustParse("1"),
-											corev1.ResourceMemory: resource.MustParse("256Mi"),
+											corev1.ResourceCPU:              resource.MustParse("1"),
+											corev1.ResourceMemory:           resource.MustParse("256Mi"),
+											corev1.ResourceEphemeralStorage: resource.MustParse("1Gi"),
 										},
 									},
 									Command: []string{"/velero"},
@@ -3251,7 +3206,7 @@ func TestDPAReconciler_buildVeleroDeployment(t *testing.T) {
 			},
 		},
 		{
-			name: "given valid DPA CR, velero deployment nodeselector",
+			name: "given valid DPA CR, velero deployment resource customization only cpu limit",
 			veleroDeployment: &appsv1.Deployment{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "test-velero-deployment",
@@ -3276,17 +3231,9 @@ func TestDPAReconciler_buildVeleroDeployment(t *testing.T) {
 							PodConfig: &oadpv1alpha1.PodConfig{
 								ResourceAllocations: corev1.ResourceRequirements{
 									Limits: corev1.ResourceList{
-										corev1.ResourceCPU:    resource.MustParse("2"),
-										corev1.ResourceMemory: resource.MustParse("700Mi"),
-									},
-									Requests: corev1.ResourceList{
-										corev1.ResourceCPU:    resource.MustParse("1"),
-										corev1.ResourceMemory: resource.MustParse("256Mi"),
+										corev1.ResourceCPU: resource.MustParse("2"),
 									},
 								},
-								NodeSelector: map[string]string{
-									"foo": "bar",
-								},
 							},
 						},
 					},
@@ -3297,14 +3244,7 @@ func TestDPAReconciler_buildVeleroDeployment(t *testing.T) {
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "test-velero-deployment",
 					Namespace: "test-ns",
-					Labels: map[string]string{
-						"app.kubernetes.io/name":       common.Velero,
-						"app.kubernetes.io/instance":   "test-Velero-CR",
-						"app.kubernetes.io/managed-by": common.OADPOperator,
-						"app.kubernetes.io/component":  Server,
-						oadpv1alpha1.OadpOperatorLabel: "True",
-						"component":                    "velero",
-					},
+					Labels:    veleroDeploymentLabel,
 				},
 				TypeMeta: metav1.TypeMeta{
 					Kind:       "Deployment",
@@ -3314,16 +3254,10 @@ func TestDPAReconciler_buildVeleroDeployment(t *testing.T) {
 					Selector: &metav1.LabelSelector{MatchLabels: veleroDeploymentMatchLabels},
 					Replicas: pointer.Int32(1),
 					Template: corev1.PodTemplateSpec{
-						ObjectMeta: metav1.ObjectMeta{
-							Labels:      veleroDeploymentMatchLabels,
-							Annotations: veleroPodAnnotations,
-						},
+						ObjectMeta: veleroPodObjectMeta,
 						Spec: corev1.PodSpec{
 							RestartPolicy:      corev1.RestartPolicyAlways,
 							ServiceAccountName: common.Velero,
-							NodeSelector: map[string]string{
-								"foo": "bar",
-							},
 							Containers: []corev1.Container{
 								{
 									Name:            common.Velero,
@@ -3332,12 +3266,11 @@ func TestDPAReconciler_buildVeleroDeployment(t *testing.T) {
 									Ports:           []corev1.ContainerPort{{Name: "metrics", ContainerPort: 8085}},
 									Resources: corev1.ResourceRequirements{
 										Limits: corev1.ResourceList{
-											corev1.ResourceCPU:    resource.MustParse("2"),
-											corev1.ResourceMemory: resource.MustParse("700Mi"),
+											corev1.ResourceCPU: resource.MustParse("2"),
 										},
 										Requests: corev1.ResourceList{
-											corev1.ResourceCPU:    resource.MustParse("1"),
-											corev1.ResourceMemory: resource.MustParse("256Mi"),
+											corev1.ResourceCPU:    resource.MustParse("500m"),
+											corev1.ResourceMemory: resource.MustParse("128Mi"),
 										},
 									},
 									Command: []string{"/velero"},
@@ -3359,13 +3292,17 @@ func TestDPAReconciler_buildVeleroDeployment(t *testing.T) {
 			},
 		},
 		{
-			name: "given valid DPA CR, appropriate velero deployment is build with aws plugin specific specs",
+			name: "given valid DPA CR, velero deployment resource customization only cpu request",
 			veleroDeployment: &appsv1.Deployment{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "test-velero-deployment",
 					Namespace: "test-ns",
 				},
-				Spec: appsv1.DeploymentSpec{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "Deployment",
+					APIVersion: appsv1.SchemeGroupVersion.String(),
+				},
+				Spec: appsv1.DeploymentSpec{
 					Selector: &metav1.LabelSelector{MatchLabels: veleroDeploymentMatchLabels},
 				},
 			},
@@ -3377,8 +3314,12 @@ func TestDPAReconciler_buildVeleroDeployment(t *testing.T) {
 				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
 					Configuration: &oadpv1alpha1.ApplicationConfig{
 						Velero: &oadpv1alpha1.VeleroConfig{
-							DefaultPlugins: []oadpv1alpha1.DefaultPlugin{
-								oadpv1alpha1.DefaultPluginAWS,
+							PodConfig: &oadpv1alpha1.PodConfig{
+								ResourceAllocations: corev1.ResourceRequirements{
+									Requests: corev1.ResourceList{
+										corev1.ResourceCPU: resource.MustParse("2"),
+									},
+								},
 							},
 						},
 					},
@@ -3409,45 +3350,41 @@ func TestDPAReconciler_buildVeleroDeployment(t *testing.T) {
 									Image:           common.VeleroImage,
 									ImagePullPolicy: corev1.PullAlways,
 									Ports:           []corev1.ContainerPort{{Name: "metrics", ContainerPort: 8085}},
-									Resources:       corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m"), corev1.ResourceMemory: resource.MustParse("128Mi")}},
-									Command:         []string{"/velero"},
+									Resources: corev1.ResourceRequirements{
+										Requests: corev1.ResourceList{
+											corev1.ResourceCPU:    resource.MustParse("2"),
+											corev1.ResourceMemory: resource.MustParse("128Mi"),
+										},
+									},
+									Command: []string{"/velero"},
 									Args: []string{
 										"server",
 										defaultFileSystemBackupTimeout,
 										defaultRestoreResourcePriorities,
 										defaultDisableInformerCache,
 									},
-									VolumeMounts: append(baseVolumeMounts, []corev1.VolumeMount{
-										{Name: "cloud-credentials", MountPath: "/credentials"},
-									}...),
-									Env: append(baseEnvVars, []corev1.EnvVar{
-										{Name: common.AWSSharedCredentialsFileEnvKey, Value: "/credentials/cloud"},
-									}...),
-								},
-							},
-							Volumes: append(baseVolumes, []corev1.Volume{{
-								Name: "cloud-credentials",
-								VolumeSource: corev1.VolumeSource{
-									Secret: &corev1.SecretVolumeSource{
-										SecretName: "cloud-credentials",
-									},
+									VolumeMounts: baseVolumeMounts,
+									Env:          baseEnvVars,
 								},
-							}}...),
-							InitContainers: []corev1.Container{
-								pluginContainer(common.VeleroPluginForAWS, common.AWSPluginImage),
 							},
+							Volumes:        baseVolumes,
+							InitContainers: []corev1.Container{},
 						},
 					},
 				},
 			},
 		},
 		{
-			name: "given valid DPA CR, appropriate velero deployment is build with aws and kubevirt plugin specific specs",
+			name: "given valid DPA CR, velero deployment resource customization only memory request",
 			veleroDeployment: &appsv1.Deployment{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "test-velero-deployment",
 					Namespace: "test-ns",
 				},
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "Deployment",
+					APIVersion: appsv1.SchemeGroupVersion.String(),
+				},
 				Spec: appsv1.DeploymentSpec{
 					Selector: &metav1.LabelSelector{MatchLabels: veleroDeploymentMatchLabels},
 				},
@@ -3460,9 +3397,12 @@ func TestDPAReconciler_buildVeleroDeployment(t *testing.T) {
 				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
 					Configuration: &oadpv1alpha1.ApplicationConfig{
 						Velero: &oadpv1alpha1.VeleroConfig{
-							DefaultPlugins: []oadpv1alpha1.DefaultPlugin{
-								oadpv1alpha1.DefaultPluginAWS,
-								oadpv1alpha1.DefaultPluginKubeVirt,
+							PodConfig: &oadpv1alpha1.PodConfig{
+								ResourceAllocations: corev1.ResourceRequirements{
+									Requests: corev1.ResourceList{
+										corev1.ResourceMemory: resource.MustParse("256Mi"),
+									},
+								},
 							},
 						},
 					},
@@ -3493,49 +3433,41 @@ func TestDPAReconciler_buildVeleroDeployment(t *testing.T) {
 									Image:           common.VeleroImage,
 									ImagePullPolicy: corev1.PullAlways,
 									Ports:           []corev1.ContainerPort{{Name: "metrics", ContainerPort: 8085}},
-									Resources:       corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m"), corev1.ResourceMemory: resource.MustParse("128Mi")}},
-									Command:         []string{"/velero"},
+									Resources: corev1.ResourceRequirements{
+										Requests: corev1.ResourceList{
+											corev1.ResourceCPU:    resource.MustParse("500m"),
+											corev1.ResourceMemory: resource.MustParse("256Mi"),
+										},
+									},
+									Command: []string{"/velero"},
 									Args: []string{
 										"server",
 										defaultFileSystemBackupTimeout,
 										defaultRestoreResourcePriorities,
 										defaultDisableInformerCache,
 									},
-									VolumeMounts: []corev1.VolumeMount{
-										{Name: "plugins", MountPath: "/plugins"},
-										{Name: "scratch", MountPath: "/scratch"},
-										{Name: "certs", MountPath: "/etc/ssl/certs"},
-										{Name: "cloud-credentials", MountPath: "/credentials"},
-									},
-									Env: append(baseEnvVars, []corev1.EnvVar{
-										{Name: common.AWSSharedCredentialsFileEnvKey, Value: "/credentials/cloud"},
-									}...),
-								},
-							},
-							Volumes: append(baseVolumes, []corev1.Volume{{
-								Name: "cloud-credentials",
-								VolumeSource: corev1.VolumeSource{
-									Secret: &corev1.SecretVolumeSource{
-										SecretName: "cloud-credentials",
-									},
+									VolumeMounts: baseVolumeMounts,
+									Env:          baseEnvVars,
 								},
-							}}...),
-							InitContainers: []corev1.Container{
-								pluginContainer(common.VeleroPluginForAWS, common.AWSPluginImage),
-								pluginContainer(common.KubeVirtPlugin, common.KubeVirtPluginImage),
 							},
+							Volumes:        baseVolumes,
+							InitContainers: []corev1.Container{},
 						},
 					},
 				},
 			},
 		},
 		{
-			name: "given valid DPA CR with annotations, appropriate velero deployment is build with aws plugin specific specs",
+			name: "given valid DPA CR, velero deployment resource customization only memory limit",
 			veleroDeployment: &appsv1.Deployment{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "test-velero-deployment",
 					Namespace: "test-ns",
 				},
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "Deployment",
+					APIVersion: appsv1.SchemeGroupVersion.String(),
+				},
 				Spec: appsv1.DeploymentSpec{
 					Selector: &metav1.LabelSelector{MatchLabels: veleroDeploymentMatchLabels},
 				},
@@ -3548,14 +3480,15 @@ func TestDPAReconciler_buildVeleroDeployment(t *testing.T) {
 				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
 					Configuration: &oadpv1alpha1.ApplicationConfig{
 						Velero: &oadpv1alpha1.VeleroConfig{
-							DefaultPlugins: []oadpv1alpha1.DefaultPlugin{
-								oadpv1alpha1.DefaultPluginAWS,
+							PodConfig: &oadpv1alpha1.PodConfig{
+								ResourceAllocations: corev1.ResourceRequirements{
+									Limits: corev1.ResourceList{
+										corev1.ResourceMemory: resource.MustParse("128Mi"),
+									},
+								},
 							},
 						},
 					},
-					PodAnnotations: map[string]string{
-						"test-annotation": "awesome annotation",
-					},
 				},
 			},
 			wantErr: false,
@@ -3573,14 +3506,7 @@ func TestDPAReconciler_buildVeleroDeployment(t *testing.T) {
 					Selector: &metav1.LabelSelector{MatchLabels: veleroDeploymentMatchLabels},
 					Replicas: pointer.Int32(1),
 					Template: corev1.PodTemplateSpec{
-						ObjectMeta: metav1.ObjectMeta{
-							Labels: veleroDeploymentMatchLabels,
-							Annotations: common.AppendTTMapAsCopy(veleroPodAnnotations,
-								map[string]string{
-									"test-annotation": "awesome annotation",
-								},
-							),
-						},
+						ObjectMeta: veleroPodObjectMeta,
 						Spec: corev1.PodSpec{
 							RestartPolicy:      corev1.RestartPolicyAlways,
 							ServiceAccountName: common.Velero,
@@ -3590,48 +3516,44 @@ func TestDPAReconciler_buildVeleroDeployment(t *testing.T) {
 									Image:           common.VeleroImage,
 									ImagePullPolicy: corev1.PullAlways,
 									Ports:           []corev1.ContainerPort{{Name: "metrics", ContainerPort: 8085}},
-									Resources:       corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m"), corev1.ResourceMemory: resource.MustParse("128Mi")}},
-									Command:         []string{"/velero"},
+									Resources: corev1.ResourceRequirements{
+										Limits: corev1.ResourceList{
+											corev1.ResourceMemory: resource.MustParse("128Mi"),
+										},
+										Requests: corev1.ResourceList{
+											corev1.ResourceCPU:    resource.MustParse("500m"),
+											corev1.ResourceMemory: resource.MustParse("128Mi"),
+										},
+									},
+									Command: []string{"/velero"},
 									Args: []string{
 										"server",
 										defaultFileSystemBackupTimeout,
 										defaultRestoreResourcePriorities,
 										defaultDisableInformerCache,
 									},
-									VolumeMounts: []corev1.VolumeMount{
-										{Name: "plugins", MountPath: "/plugins"},
-										{Name: "scratch", MountPath: "/scratch"},
-										{Name: "certs", MountPath: "/etc/ssl/certs"},
-										{Name: "cloud-credentials", MountPath: "/credentials"},
-									},
-									Env: append(baseEnvVars, []corev1.EnvVar{
-										{Name: common.AWSSharedCredentialsFileEnvKey, Value: "/credentials/cloud"},
-									}...),
-								},
-							},
-							Volumes: append(baseVolumes, []corev1.Volume{{
-								Name: "cloud-credentials",
-								VolumeSource: corev1.VolumeSource{
-									Secret: &corev1.SecretVolumeSource{
-										SecretName: "cloud-credentials",
-									},
+									VolumeMounts: baseVolumeMounts,
+									Env:          baseEnvVars,
 								},
-							}}...),
-							InitContainers: []corev1.Container{
-								pluginContainer(common.VeleroPluginForAWS, common.AWSPluginImage),
 							},
+							Volumes:        baseVolumes,
+							InitContainers: []corev1.Container{},
 						},
 					},
 				},
 			},
 		},
 		{
-			name: "given valid DPA CR with PodDNS Policy/Config, annotations, appropriate velero deployment is build with aws plugin specific specs",
+			name: "given valid DPA CR, velero deployment tolerations",
 			veleroDeployment: &appsv1.Deployment{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "test-velero-deployment",
 					Namespace: "test-ns",
 				},
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "Deployment",
+					APIVersion: appsv1.SchemeGroupVersion.String(),
+				},
 				Spec: appsv1.DeploymentSpec{
 					Selector: &metav1.LabelSelector{MatchLabels: veleroDeploymentMatchLabels},
 				},
@@ -3644,24 +3566,25 @@ func TestDPAReconciler_buildVeleroDeployment(t *testing.T) {
 				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
 					Configuration: &oadpv1alpha1.ApplicationConfig{
 						Velero: &oadpv1alpha1.VeleroConfig{
-							DefaultPlugins: []oadpv1alpha1.DefaultPlugin{
-								oadpv1alpha1.DefaultPluginAWS,
-							},
-						},
-					},
-					PodAnnotations: map[string]string{
-						"test-annotation": "awesome annotation",
-					},
-					PodDnsPolicy: "None",
-					PodDnsConfig: corev1.PodDNSConfig{
-						Nameservers: []string{
-							"1.1.1.1",
-							"8.8.8.8",
-						},
-						Options: []corev1.PodDNSConfigOption{
-							{Name: "ndots", Value: pointer.String("2")},
-							{
-								Name: "edns0",
+							PodConfig: &oadpv1alpha1.PodConfig{
+								ResourceAllocations: corev1.ResourceRequirements{
+									Limits: corev1.ResourceList{
+										corev1.ResourceCPU:    resource.MustParse("2"),
+										corev1.ResourceMemory: resource.MustParse("700Mi"),
+									},
+									Requests: corev1.ResourceList{
+										corev1.ResourceCPU:    resource.MustParse("1"),
+										corev1.ResourceMemory: resource.MustParse("256Mi"),
+									},
+								},
+								Tolerations: []corev1.Toleration{
+									{
+										Key:      "key1",
+										Operator: "Equal",
+										Value:    "value1",
+										Effect:   "NoSchedule",
+									},
+								},
 							},
 						},
 					},
@@ -3672,7 +3595,14 @@ func TestDPAReconciler_buildVeleroDeployment(t *testing.T) {
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "test-velero-deployment",
 					Namespace: "test-ns",
-					Labels:    veleroDeploymentLabel,
+					Labels: map[string]string{
+						"app.kubernetes.io/name":       common.Velero,
+						"app.kubernetes.io/instance":   "test-Velero-CR",
+						"app.kubernetes.io/managed-by": common.OADPOperator,
+						"app.kubernetes.io/component":  Server,
+						oadpv1alpha1.OadpOperatorLabel: "True",
+						"component":                    "velero",
+					},
 				},
 				TypeMeta: metav1.TypeMeta{
 					Kind:       "Deployment",
@@ -3683,24 +3613,18 @@ func TestDPAReconciler_buildVeleroDeployment(t *testing.T) {
 					Replicas: pointer.Int32(1),
 					Template: corev1.PodTemplateSpec{
 						ObjectMeta: metav1.ObjectMeta{
-							Labels: veleroDeploymentMatchLabels,
-							Annotations: common.AppendTTMapAsCopy(veleroPodAnnotations,
-								map[string]string{
-									"test-annotation": "awesome annotation",
-								},
-							),
+							Labels:      veleroDeploymentMatchLabels,
+							Annotations: veleroPodAnnotations,
 						},
 						Spec: corev1.PodSpec{
 							RestartPolicy:      corev1.RestartPolicyAlways,
 							ServiceAccountName: common.Velero,
-							DNSPolicy:          "None",
-							DNSConfig: &corev1.PodDNSConfig{
-								Nameservers: []string{"1.1.1.1", "8.8.8.8"},
-								Options: []corev1.PodDNSConfigOption{
-									{Name: "ndots", Value: pointer.String("2")},
-									{
-										Name: "edns0",
-									},
+							Tolerations: []corev1.Toleration{
+								{
+									Key:      "key1",
+									Operator: "Equal",
+									Value:    "value1",
+									Effect:   "NoSchedule",
 								},
 							},
 							Containers: []corev1.Container{
@@ -3709,48 +3633,45 @@ func TestDPAReconciler_buildVeleroDeployment(t *testing.T) {
 									Image:           common.VeleroImage,
 									ImagePullPolicy: corev1.PullAlways,
 									Ports:           []corev1.ContainerPort{{Name: "metrics", ContainerPort: 8085}},
-									Resources:       corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m"), corev1.ResourceMemory: resource.MustParse("128Mi")}},
-									Command:         []string{"/velero"},
+									Resources: corev1.ResourceRequirements{
+										Limits: corev1.ResourceList{
+											corev1.ResourceCPU:    resource.MustParse("2"),
+											corev1.ResourceMemory: resource.MustParse("700Mi"),
+										},
+										Requests: corev1.ResourceList{
+											corev1.ResourceCPU:    resource.MustParse("1"),
+											corev1.ResourceMemory: resource.MustParse("256Mi"),
+										},
+									},
+									Command: []string{"/velero"},
 									Args: []string{
 										"server",
 										defaultFileSystemBackupTimeout,
 										defaultRestoreResourcePriorities,
 										defaultDisableInformerCache,
 									},
-									VolumeMounts: []corev1.VolumeMount{
-										{Name: "plugins", MountPath: "/plugins"},
-										{Name: "scratch", MountPath: "/scratch"},
-										{Name: "certs", MountPath: "/etc/ssl/certs"},
-										{Name: "cloud-credentials", MountPath: "/credentials"},
-									},
-									Env: append(baseEnvVars, []corev1.EnvVar{
-										{Name: common.AWSSharedCredentialsFileEnvKey, Value: "/credentials/cloud"},
-									}...),
+									VolumeMounts: baseVolumeMounts,
+									Env:          baseEnvVars,
 								},
 							},
-							Volumes: append(baseVolumes, []corev1.Volume{{
-								Name: "cloud-credentials",
-								VolumeSource: corev1.VolumeSource{
-									Secret: &corev1.SecretVolumeSource{
-										SecretName: "cloud-credentials",
-									},
-								},
-							}}...),
-							InitContainers: []corev1.Container{
-								pluginContainer(common.VeleroPluginForAWS, common.AWSPluginImage),
-							},
+							Volumes:        baseVolumes,
+							InitContainers: []corev1.Container{},
 						},
 					},
 				},
 			},
 		},
 		{
-			name: "given valid Velero CR with with aws plugin from bucket",
+			name: "given valid DPA CR, velero deployment nodeselector",
 			veleroDeployment: &appsv1.Deployment{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "test-velero-deployment",
 					Namespace: "test-ns",
 				},
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "Deployment",
+					APIVersion: appsv1.SchemeGroupVersion.String(),
+				},
 				Spec: appsv1.DeploymentSpec{
 					Selector: &metav1.LabelSelector{MatchLabels: veleroDeploymentMatchLabels},
 				},
@@ -3763,26 +3684,20 @@ func TestDPAReconciler_buildVeleroDeployment(t *testing.T) {
 				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
 					Configuration: &oadpv1alpha1.ApplicationConfig{
 						Velero: &oadpv1alpha1.VeleroConfig{
-							DefaultPlugins: []oadpv1alpha1.DefaultPlugin{
-								oadpv1alpha1.DefaultPluginAWS,
-							},
-						},
-					},
-					BackupLocations: []oadpv1alpha1.BackupLocation{
-						{
-							CloudStorage: &oadpv1alpha1.CloudStorageLocation{
-								CloudStorageRef: corev1.LocalObjectReference{
-									Name: "bucket-123",
-								},
-								Config: map[string]string{},
-								Credential: &corev1.SecretKeySelector{
-									LocalObjectReference: corev1.LocalObjectReference{
-										Name: "cloud-credentials",
+							PodConfig: &oadpv1alpha1.PodConfig{
+								ResourceAllocations: corev1.ResourceRequirements{
+									Limits: corev1.ResourceList{
+										corev1.ResourceCPU:    resource.MustParse("2"),
+										corev1.ResourceMemory: resource.MustParse("700Mi"),
+									},
+									Requests: corev1.ResourceList{
+										corev1.ResourceCPU:    resource.MustParse("1"),
+										corev1.ResourceMemory: resource.MustParse("256Mi"),
 									},
-									Key: "creds",
 								},
-								Default:          false,
-								BackupSyncPeriod: &metav1.Duration{},
+								NodeSelector: map[string]string{
+									"foo": "bar",
+								},
 							},
 						},
 					},
@@ -3793,7 +3708,14 @@ func TestDPAReconciler_buildVeleroDeployment(t *testing.T) {
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "test-velero-deployment",
 					Namespace: "test-ns",
-					Labels:    veleroDeploymentLabel,
+					Labels: map[string]string{
+						"app.kubernetes.io/name":       common.Velero,
+						"app.kubernetes.io/instance":   "test-Velero-CR",
+						"app.kubernetes.io/managed-by": common.OADPOperator,
+						"app.kubernetes.io/component":  Server,
+						oadpv1alpha1.OadpOperatorLabel: "True",
+						"component":                    "velero",
+					},
 				},
 				TypeMeta: metav1.TypeMeta{
 					Kind:       "Deployment",
@@ -3803,76 +3725,52 @@ func TestDPAReconciler_buildVeleroDeployment(t *testing.T) {
 					Selector: &metav1.LabelSelector{MatchLabels: veleroDeploymentMatchLabels},
 					Replicas: pointer.Int32(1),
 					Template: corev1.PodTemplateSpec{
-						ObjectMeta: veleroPodObjectMeta,
+						ObjectMeta: metav1.ObjectMeta{
+							Labels:      veleroDeploymentMatchLabels,
+							Annotations: veleroPodAnnotations,
+						},
 						Spec: corev1.PodSpec{
 							RestartPolicy:      corev1.RestartPolicyAlways,
 							ServiceAccountName: common.Velero,
+							NodeSelector: map[string]string{
+								"foo": "bar",
+							},
 							Containers: []corev1.Container{
 								{
 									Name:            common.Velero,
 									Image:           common.VeleroImage,
 									ImagePullPolicy: corev1.PullAlways,
 									Ports:           []corev1.ContainerPort{{Name: "metrics", ContainerPort: 8085}},
-									Resources:       corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m"), corev1.ResourceMemory: resource.MustParse("128Mi")}},
-									Command:         []string{"/velero"},
+									Resources: corev1.ResourceRequirements{
+										Limits: corev1.ResourceList{
+											corev1.ResourceCPU:    resource.MustParse("2"),
+											corev1.ResourceMemory: resource.MustParse("700Mi"),
+										},
+										Requests: corev1.ResourceList{
+											corev1.ResourceCPU:    resource.MustParse("1"),
+											corev1.ResourceMemory: resource.MustParse("256Mi"),
+										},
+									},
+									Command: []string{"/velero"},
 									Args: []string{
 										"server",
 										defaultFileSystemBackupTimeout,
 										defaultRestoreResourcePriorities,
 										defaultDisableInformerCache,
 									},
-									VolumeMounts: []corev1.VolumeMount{
-										{Name: "plugins", MountPath: "/plugins"},
-										{Name: "scratch", MountPath: "/scratch"},
-										{Name: "certs", MountPath: "/etc/ssl/certs"},
-										{
-											Name:      "bound-sa-token",
-											MountPath: "/var/run/secrets/openshift/serviceaccount",
-											ReadOnly:  true,
-										},
-									},
-									Env: baseEnvVars,
+									VolumeMounts: baseVolumeMounts,
+									Env:          baseEnvVars,
 								},
 							},
-							Volumes: append(baseVolumes, []corev1.Volume{
-								{
-									Name: "bound-sa-token",
-									VolumeSource: corev1.VolumeSource{
-										Projected: &corev1.ProjectedVolumeSource{
-											DefaultMode: pointer.Int32(420),
-											Sources: []corev1.VolumeProjection{
-												{
-													ServiceAccountToken: &corev1.ServiceAccountTokenProjection{
-														Audience:          "openshift",
-														ExpirationSeconds: pointer.Int64(3600),
-														Path:              "token",
-													},
-												},
-											},
-										},
-									},
-								}}...),
-							InitContainers: []corev1.Container{
-								pluginContainer(common.VeleroPluginForAWS, common.AWSPluginImage),
-							},
+							Volumes:        baseVolumes,
+							InitContainers: []corev1.Container{},
 						},
 					},
 				},
 			},
-			clientObjects: []client.Object{
-				&oadpv1alpha1.CloudStorage{
-					ObjectMeta: metav1.ObjectMeta{
-						Name:      "bucket-123",
-						Namespace: "test-ns",
-					},
-					Spec: oadpv1alpha1.CloudStorageSpec{
-						EnableSharedConfig: &trueVal,
-					},
-				},
-			},
 		},
 		{
-			name: "velero with custom metrics address",
+			name: "given valid DPA CR, appropriate velero deployment is build with aws plugin specific specs",
 			veleroDeployment: &appsv1.Deployment{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "test-velero-deployment",
@@ -3890,15 +3788,14 @@ func TestDPAReconciler_buildVeleroDeployment(t *testing.T) {
 				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
 					Configuration: &oadpv1alpha1.ApplicationConfig{
 						Velero: &oadpv1alpha1.VeleroConfig{
-							Args: &server.Args{
-								ServerConfig: server.ServerConfig{
-									MetricsAddress: ":" + strconv.Itoa(int(argsMetricsPortTest)),
-								},
+							DefaultPlugins: []oadpv1alpha1.DefaultPlugin{
+								oadpv1alpha1.DefaultPluginAWS,
 							},
 						},
 					},
 				},
 			},
+			wantErr: false,
 			wantVeleroDeployment: &appsv1.Deployment{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "test-velero-deployment",
@@ -3913,12 +3810,7 @@ func TestDPAReconciler_buildVeleroDeployment(t *testing.T) {
 					Selector: &metav1.LabelSelector{MatchLabels: veleroDeploymentMatchLabels},
 					Replicas: pointer.Int32(1),
 					Template: corev1.PodTemplateSpec{
-						ObjectMeta: metav1.ObjectMeta{
-							Labels: veleroDeploymentMatchLabels,
-							Annotations: common.AppendTTMapAsCopy(veleroPodAnnotations, map[string]string{
-								"prometheus.io/port": strconv.Itoa(int(argsMetricsPortTest)),
-							}),
-						},
+						ObjectMeta: veleroPodObjectMeta,
 						Spec: corev1.PodSpec{
 							RestartPolicy:      corev1.RestartPolicyAlways,
 							ServiceAccountName: common.Velero,
@@ -3927,29 +3819,41 @@ func TestDPAReconciler_buildVeleroDeployment(t *testing.T) {
 									Name:            common.Velero,
 									Image:           common.VeleroImage,
 									ImagePullPolicy: corev1.PullAlways,
-									Ports:           []corev1.ContainerPort{{Name: "metrics", ContainerPort: argsMetricsPortTest}},
+									Ports:           []corev1.ContainerPort{{Name: "metrics", ContainerPort: 8085}},
 									Resources:       corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m"), corev1.ResourceMemory: resource.MustParse("128Mi")}},
 									Command:         []string{"/velero"},
 									Args: []string{
 										"server",
-										"--metrics-address=:" + strconv.Itoa(int(argsMetricsPortTest)),
-										"--fs-backup-timeout=4h0m0s",
+										defaultFileSystemBackupTimeout,
 										defaultRestoreResourcePriorities,
 										defaultDisableInformerCache,
 									},
-									VolumeMounts: baseVolumeMounts,
-									Env:          baseEnvVars,
+									VolumeMounts: append(baseVolumeMounts, []corev1.VolumeMount{
+										{Name: "cloud-credentials", MountPath: "/credentials"},
+									}...),
+									Env: append(baseEnvVars, []corev1.EnvVar{
+										{Name: common.AWSSharedCredentialsFileEnvKey, Value: "/credentials/cloud"},
+									}...),
 								},
 							},
-							Volumes:        baseVolumes,
-							InitContainers: []corev1.Container{},
+							Volumes: append(baseVolumes, []corev1.Volume{{
+								Name: "cloud-credentials",
+								VolumeSource: corev1.VolumeSource{
+									Secret: &corev1.SecretVolumeSource{
+										SecretName: "cloud-credentials",
+									},
+								},
+							}}...),
+							InitContainers: []corev1.Container{
+								pluginContainer(common.VeleroPluginForAWS, common.AWSPluginImage),
+							},
 						},
 					},
 				},
 			},
 		},
 		{
-			name: "Override restore resource priorities",
+			name: "given valid DPA CR with BSL credentialMountPath, credential secret is mounted at custom path",
 			veleroDeployment: &appsv1.Deployment{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "test-velero-deployment",
@@ -3966,16 +3870,25 @@ func TestDPAReconciler_buildVeleroDeployment(t *testing.T) {
 				},
 				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
 					Configuration: &oadpv1alpha1.ApplicationConfig{
-						Velero: &oadpv1alpha1.VeleroConfig{
-							Args: &server.Args{
-								ServerConfig: server.ServerConfig{
-									RestoreResourcePriorities: "securitycontextconstraints,test",
+						Velero: &oadpv1alpha1.VeleroConfig{},
+					},
+					BackupLocations: []oadpv1alpha1.BackupLocation{
+						{
+							Velero: &velerov1.BackupStorageLocationSpec{
+								Provider: "aws",
+								Credential: &corev1.SecretKeySelector{
+									LocalObjectReference: corev1.LocalObjectReference{
+										Name: "custom-plugin-credentials",
+									},
+									Key: "cloud",
 								},
 							},
+							CredentialMountPath: "/opt/custom-plugin/credentials",
 						},
 					},
 				},
 			},
+			wantErr: false,
 			wantVeleroDeployment: &appsv1.Deployment{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "test-velero-deployment",
@@ -4004,15 +3917,24 @@ func TestDPAReconciler_buildVeleroDeployment(t *testing.T) {
 									Command:         []string{"/velero"},
 									Args: []string{
 										"server",
-										"--fs-backup-timeout=4h0m0s",
-										"--restore-resource-priorities=securitycontextconstraints,test",
+										defaultFileSystemBackupTimeout,
+										defaultRestoreResourcePriorities,
 										defaultDisableInformerCache,
 									},
-									VolumeMounts: baseVolumeMounts,
-									Env:          baseEnvVars,
+									VolumeMounts: append(baseVolumeMounts, []corev1.VolumeMount{
+										{Name: "bsl-credentials-1", MountPath: "/opt/custom-plugin/credentials", ReadOnly: true},
+									}...),
+									Env: baseEnvVars,
 								},
 							},
-							Volumes:        baseVolumes,
+							Volumes: append(baseVolumes, []corev1.Volume{{
+								Name: "bsl-credentials-1",
+								VolumeSource: corev1.VolumeSource{
+									Secret: &corev1.SecretVolumeSource{
+										SecretName: "custom-plugin-credentials",
+									},
+								},
+							}}...),
 							InitContainers: []corev1.Container{},
 						},
 					},
@@ -4020,7 +3942,7 @@ func TestDPAReconciler_buildVeleroDeployment(t *testing.T) {
 			},
 		},
 		{
-			name: "Check values of time fields in Velero args",
+			name: "given valid DPA CR with plugin config file, configmap is mounted at configured path",
 			veleroDeployment: &appsv1.Deployment{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "test-velero-deployment",
@@ -4038,24 +3960,14 @@ func TestDPAReconciler_buildVeleroDeployment(t *testing.T) {
 				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
 					Configuration: &oadpv1alpha1.ApplicationConfig{
 						Velero: &oadpv1alpha1.VeleroConfig{
-							Args: &server.Args{
-								ServerConfig: server.ServerConfig{
-									BackupSyncPeriod:            pointer.Duration(1),
-									PodVolumeOperationTimeout:   pointer.Duration(1),
-									ResourceTerminatingTimeout:  pointer.Duration(1),
-									DefaultBackupTTL:            pointer.Duration(1),
-									StoreValidationFrequency:    pointer.Duration(1),
-									ItemOperationSyncFrequency:  pointer.Duration(1),
-									RepoMaintenanceFrequency:    pointer.Duration(1),
-									GarbageCollectionFrequency:  pointer.Duration(1),
-									DefaultItemOperationTimeout: pointer.Duration(1),
-									ResourceTimeout:             pointer.Duration(1),
-								},
+							PluginConfigFiles: []oadpv1alpha1.PluginConfigFile{
+								{ConfigMap: "custom-plugin-config", MountPath: "/opt/custom-plugin/config/"},
 							},
 						},
 					},
 				},
 			},
+			wantErr: false,
 			wantVeleroDeployment: &appsv1.Deployment{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "test-velero-deployment",
@@ -4084,253 +3996,2812 @@ func TestDPAReconciler_buildVeleroDeployment(t *testing.T) {
 									Command:         []string{"/velero"},
 									Args: []string{
 										"server",
-										"--backup-sync-period=1ns",
-										"--default-backup-ttl=1ns",
-										"--default-item-operation-timeout=1ns",
-										"--resource-timeout=1ns",
-										"--default-repo-maintain-frequency=1ns",
-										"--garbage-collection-frequency=1ns",
-										"--fs-backup-timeout=1ns",
-										"--item-operation-sync-frequency=1ns",
+										defaultFileSystemBackupTimeout,
 										defaultRestoreResourcePriorities,
-										"--store-validation-frequency=1ns",
-										"--terminating-resource-timeout=1ns",
 										defaultDisableInformerCache,
 									},
-									VolumeMounts: baseVolumeMounts,
-									Env:          baseEnvVars,
+									VolumeMounts: append(baseVolumeMounts, []corev1.VolumeMount{
+										{Name: "plugin-config-1", MountPath: "/opt/custom-plugin/config", ReadOnly: true},
+									}...),
+									Env: baseEnvVars,
 								},
 							},
-							Volumes:        baseVolumes,
+							Volumes: append(baseVolumes, []corev1.Volume{{
+								Name: "plugin-config-1",
+								VolumeSource: corev1.VolumeSource{
+									ConfigMap: &corev1.ConfigMapVolumeSource{
+										LocalObjectReference: corev1.LocalObjectReference{Name: "custom-plugin-config"},
+									},
+								},
+							}}...),
 							InitContainers: []corev1.Container{},
 						},
 					},
 				},
 			},
 		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			fakeClient, err := getFakeClientFromObjects(tt.clientObjects...)
-			if err != nil {
-				t.Errorf("error in creating fake client, likely programmer error")
-			}
-			r := DPAReconciler{
-				Client: fakeClient,
-			}
-			oadpclient.SetClient(fakeClient)
-			if tt.testProxy {
-				os.Setenv(proxyEnvKey, proxyEnvValue)
-				defer os.Unsetenv(proxyEnvKey)
-			}
-			if err := r.buildVeleroDeployment(tt.veleroDeployment, tt.dpa); err != nil {
-				if !tt.wantErr {
-					t.Errorf("buildVeleroDeployment() error = %v, wantErr %v", err, tt.wantErr)
-				}
-				if tt.wantErr && tt.wantVeleroDeployment == nil {
-					// if we expect an error and we got one, and wantVeleroDeployment is not defined, we don't need to compare further.
-					t.Skip()
-				}
-			}
-			if tt.dpa != nil {
-				setPodTemplateSpecDefaults(&tt.wantVeleroDeployment.Spec.Template)
+		{
+			name: "given valid DPA CR, appropriate velero deployment is build with aws and kubevirt plugin specific specs",
+			veleroDeployment: &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-velero-deployment",
+					Namespace: "test-ns",
+				},
+				Spec: appsv1.DeploymentSpec{
+					Selector: &metav1.LabelSelector{MatchLabels: veleroDeploymentMatchLabels},
+				},
+			},
+			dpa: &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-Velero-CR",
+					Namespace: "test-ns",
+				},
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{
+							DefaultPlugins: []oadpv1alpha1.DefaultPlugin{
+								oadpv1alpha1.DefaultPluginAWS,
+								oadpv1alpha1.DefaultPluginKubeVirt,
+							},
+						},
+					},
+				},
+			},
+			wantErr: false,
+			wantVeleroDeployment: &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-velero-deployment",
+					Namespace: "test-ns",
+					Labels:    veleroDeploymentLabel,
+				},
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "Deployment",
+					APIVersion: appsv1.SchemeGroupVersion.String(),
+				},
+				Spec: appsv1.DeploymentSpec{
+					Selector: &metav1.LabelSelector{MatchLabels: veleroDeploymentMatchLabels},
+					Replicas: pointer.Int32(1),
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: veleroPodObjectMeta,
+						Spec: corev1.PodSpec{
+							RestartPolicy:      corev1.RestartPolicyAlways,
+							ServiceAccountName: common.Velero,
+							Containers: []corev1.Container{
+								{
+									Name:            common.Velero,
+									Image:           common.VeleroImage,
+									ImagePullPolicy: corev1.PullAlways,
+									Ports:           []corev1.ContainerPort{{Name: "metrics", ContainerPort: 8085}},
+									Resources:       corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m"), corev1.ResourceMemory: resource.MustParse("128Mi")}},
+									Command:         []string{"/velero"},
+									Args: []string{
+										"server",
+										defaultFileSystemBackupTimeout,
+										defaultRestoreResourcePriorities,
+										defaultDisableInformerCache,
+									},
+									VolumeMounts: []corev1.VolumeMount{
+										{Name: "plugins", MountPath: "/plugins"},
+										{Name: "scratch", MountPath: "/scratch"},
+										{Name: "certs", MountPath: "/etc/ssl/certs"},
+										{Name: "cloud-credentials", MountPath: "/credentials"},
+									},
+									Env: append(baseEnvVars, []corev1.EnvVar{
+										{Name: common.AWSSharedCredentialsFileEnvKey, Value: "/credentials/cloud"},
+									}...),
+								},
+							},
+							Volumes: append(baseVolumes, []corev1.Volume{{
+								Name: "cloud-credentials",
+								VolumeSource: corev1.VolumeSource{
+									Secret: &corev1.SecretVolumeSource{
+										SecretName: "cloud-credentials",
+									},
+								},
+							}}...),
+							InitContainers: []corev1.Container{
+								pluginContainer(common.VeleroPluginForAWS, common.AWSPluginImage),
+								pluginContainer(common.KubeVirtPlugin, common.KubeVirtPluginImage),
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "given valid DPA CR with annotations, appropriate velero deployment is build with aws plugin specific specs",
+			veleroDeployment: &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-velero-deployment",
+					Namespace: "test-ns",
+				},
+				Spec: appsv1.DeploymentSpec{
+					Selector: &metav1.LabelSelector{MatchLabels: veleroDeploymentMatchLabels},
+				},
+			},
+			dpa: &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-Velero-CR",
+					Namespace: "test-ns",
+				},
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{
+							DefaultPlugins: []oadpv1alpha1.DefaultPlugin{
+								oadpv1alpha1.DefaultPluginAWS,
+							},
+						},
+					},
+					PodAnnotations: map[string]string{
+						"test-annotation": "awesome annotation",
+					},
+				},
+			},
+			wantErr: false,
+			wantVeleroDeployment: &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-velero-deployment",
+					Namespace: "test-ns",
+					Labels:    veleroDeploymentLabel,
+				},
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "Deployment",
+					APIVersion: appsv1.SchemeGroupVersion.String(),
+				},
+				Spec: appsv1.DeploymentSpec{
+					Selector: &metav1.LabelSelector{MatchLabels: veleroDeploymentMatchLabels},
+					Replicas: pointer.Int32(1),
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{
+							Labels: veleroDeploymentMatchLabels,
+							Annotations: common.AppendTTMapAsCopy(veleroPodAnnotations,
+								map[string]string{
+									"test-annotation": "awesome annotation",
+								},
+							),
+						},
+						Spec: corev1.PodSpec{
+							RestartPolicy:      corev1.RestartPolicyAlways,
+							ServiceAccountName: common.Velero,
+							Containers: []corev1.Container{
+								{
+									Name:            common.Velero,
+									Image:           common.VeleroImage,
+									ImagePullPolicy: corev1.PullAlways,
+									Ports:           []corev1.ContainerPort{{Name: "metrics", ContainerPort: 8085}},
+									Resources:       corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m"), corev1.ResourceMemory: resource.MustParse("128Mi")}},
+									Command:         []string{"/velero"},
+									Args: []string{
+										"server",
+										defaultFileSystemBackupTimeout,
+										defaultRestoreResourcePriorities,
+										defaultDisableInformerCache,
+									},
+									VolumeMounts: []corev1.VolumeMount{
+										{Name: "plugins", MountPath: "/plugins"},
+										{Name: "scratch", MountPath: "/scratch"},
+										{Name: "certs", MountPath: "/etc/ssl/certs"},
+										{Name: "cloud-credentials", MountPath: "/credentials"},
+									},
+									Env: append(baseEnvVars, []corev1.EnvVar{
+										{Name: common.AWSSharedCredentialsFileEnvKey, Value: "/credentials/cloud"},
+									}...),
+								},
+							},
+							Volumes: append(baseVolumes, []corev1.Volume{{
+								Name: "cloud-credentials",
+								VolumeSource: corev1.VolumeSource{
+									Secret: &corev1.SecretVolumeSource{
+										SecretName: "cloud-credentials",
+									},
+								},
+							}}...),
+							InitContainers: []corev1.Container{
+								pluginContainer(common.VeleroPluginForAWS, common.AWSPluginImage),
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "given valid DPA CR with PodDNS Policy/Config, annotations, appropriate velero deployment is build with aws plugin specific specs",
+			veleroDeployment: &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-velero-deployment",
+					Namespace: "test-ns",
+				},
+				Spec: appsv1.DeploymentSpec{
+					Selector: &metav1.LabelSelector{MatchLabels: veleroDeploymentMatchLabels},
+				},
+			},
+			dpa: &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-Velero-CR",
+					Namespace: "test-ns",
+				},
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{
+							DefaultPlugins: []oadpv1alpha1.DefaultPlugin{
+								oadpv1alpha1.DefaultPluginAWS,
+							},
+						},
+					},
+					PodAnnotations: map[string]string{
+						"test-annotation": "awesome annotation",
+					},
+					PodDnsPolicy: "None",
+					PodDnsConfig: corev1.PodDNSConfig{
+						Nameservers: []string{
+							"1.1.1.1",
+							"8.8.8.8",
+						},
+						Options: []corev1.PodDNSConfigOption{
+							{Name: "ndots", Value: pointer.String("2")},
+							{
+								Name: "edns0",
+							},
+						},
+					},
+				},
+			},
+			wantErr: false,
+			wantVeleroDeployment: &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-velero-deployment",
+					Namespace: "test-ns",
+					Labels:    veleroDeploymentLabel,
+				},
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "Deployment",
+					APIVersion: appsv1.SchemeGroupVersion.String(),
+				},
+				Spec: appsv1.DeploymentSpec{
+					Selector: &metav1.LabelSelector{MatchLabels: veleroDeploymentMatchLabels},
+					Replicas: pointer.Int32(1),
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{
+							Labels: veleroDeploymentMatchLabels,
+							Annotations: common.AppendTTMapAsCopy(veleroPodAnnotations,
+								map[string]string{
+									"test-annotation": "awesome annotation",
+								},
+							),
+						},
+						Spec: corev1.PodSpec{
+							RestartPolicy:      corev1.RestartPolicyAlways,
+							ServiceAccountName: common.Velero,
+							DNSPolicy:          "None",
+							DNSConfig: &corev1.PodDNSConfig{
+								Nameservers: []string{"1.1.1.1", "8.8.8.8"},
+								Options: []corev1.PodDNSConfigOption{
+									{Name: "ndots", Value: pointer.String("2")},
+									{
+										Name: "edns0",
+									},
+								},
+							},
+							Containers: []corev1.Container{
+								{
+									Name:            common.Velero,
+									Image:           common.VeleroImage,
+									ImagePullPolicy: corev1.PullAlways,
+									Ports:           []corev1.ContainerPort{{Name: "metrics", ContainerPort: 8085}},
+									Resources:       corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m"), corev1.ResourceMemory: resource.MustParse("128Mi")}},
+									Command:         []string{"/velero"},
+									Args: []string{
+										"server",
+										defaultFileSystemBackupTimeout,
+										defaultRestoreResourcePriorities,
+										defaultDisableInformerCache,
+									},
+									VolumeMounts: []corev1.VolumeMount{
+										{Name: "plugins", MountPath: "/plugins"},
+										{Name: "scratch", MountPath: "/scratch"},
+										{Name: "certs", MountPath: "/etc/ssl/certs"},
+										{Name: "cloud-credentials", MountPath: "/credentials"},
+									},
+									Env: append(baseEnvVars, []corev1.EnvVar{
+										{Name: common.AWSSharedCredentialsFileEnvKey, Value: "/credentials/cloud"},
+									}...),
+								},
+							},
+							Volumes: append(baseVolumes, []corev1.Volume{{
+								Name: "cloud-credentials",
+								VolumeSource: corev1.VolumeSource{
+									Secret: &corev1.SecretVolumeSource{
+										SecretName: "cloud-credentials",
+									},
+								},
+							}}...),
+							InitContainers: []corev1.Container{
+								pluginContainer(common.VeleroPluginForAWS, common.AWSPluginImage),
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "given valid Velero CR with with aws plugin from bucket",
+			veleroDeployment: &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-velero-deployment",
+					Namespace: "test-ns",
+				},
+				Spec: appsv1.DeploymentSpec{
+					Selector: &metav1.LabelSelector{MatchLabels: veleroDeploymentMatchLabels},
+				},
+			},
+			dpa: &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-Velero-CR",
+					Namespace: "test-ns",
+				},
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{
+							DefaultPlugins: []oadpv1alpha1.DefaultPlugin{
+								oadpv1alpha1.DefaultPluginAWS,
+							},
+						},
+					},
+					BackupLocations: []oadpv1alpha1.BackupLocation{
+						{
+							CloudStorage: &oadpv1alpha1.CloudStorageLocation{
+								CloudStorageRef: corev1.LocalObjectReference{
+									Name: "bucket-123",
+								},
+								Config: map[string]string{},
+								Credential: &corev1.SecretKeySelector{
+									LocalObjectReference: corev1.LocalObjectReference{
+										Name: "cloud-credentials",
+									},
+									Key: "creds",
+								},
+								Default:          false,
+								BackupSyncPeriod: &metav1.Duration{},
+							},
+						},
+					},
+				},
+			},
+			wantErr: false,
+			wantVeleroDeployment: &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-velero-deployment",
+					Namespace: "test-ns",
+					Labels:    veleroDeploymentLabel,
+				},
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "Deployment",
+					APIVersion: appsv1.SchemeGroupVersion.String(),
+				},
+				Spec: appsv1.DeploymentSpec{
+					Selector: &metav1.LabelSelector{MatchLabels: veleroDeploymentMatchLabels},
+					Replicas: pointer.Int32(1),
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: veleroPodObjectMeta,
+						Spec: corev1.PodSpec{
+							RestartPolicy:      corev1.RestartPolicyAlways,
+							ServiceAccountName: common.Velero,
+							Containers: []corev1.Container{
+								{
+									Name:            common.Velero,
+									Image:           common.VeleroImage,
+									ImagePullPolicy: corev1.PullAlways,
+									Ports:           []corev1.ContainerPort{{Name: "metrics", ContainerPort: 8085}},
+									Resources:       corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m"), corev1.ResourceMemory: resource.MustParse("128Mi")}},
+									Command:         []string{"/velero"},
+									Args: []string{
+										"server",
+										defaultFileSystemBackupTimeout,
+										defaultRestoreResourcePriorities,
+										defaultDisableInformerCache,
+									},
+									VolumeMounts: []corev1.VolumeMount{
+										{Name: "plugins", MountPath: "/plugins"},
+										{Name: "scratch", MountPath: "/scratch"},
+										{Name: "certs", MountPath: "/etc/ssl/certs"},
+										{
+											Name:      "bound-sa-token",
+											MountPath: "/var/run/secrets/openshift/serviceaccount",
+											ReadOnly:  true,
+										},
+									},
+									Env: baseEnvVars,
+								},
+							},
+							Volumes: append(baseVolumes, []corev1.Volume{
+								{
+									Name: "bound-sa-token",
+									VolumeSource: corev1.VolumeSource{
+										Projected: &corev1.ProjectedVolumeSource{
+											DefaultMode: pointer.Int32(420),
+											Sources: []corev1.VolumeProjection{
+												{
+													ServiceAccountToken: &corev1.ServiceAccountTokenProjection{
+														Audience:          "openshift",
+														ExpirationSeconds: pointer.Int64(3600),
+														Path:              "token",
+													},
+												},
+											},
+										},
+									},
+								}}...),
+							InitContainers: []corev1.Container{
+								pluginContainer(common.VeleroPluginForAWS, common.AWSPluginImage),
+							},
+						},
+					},
+				},
+			},
+			clientObjects: []client.Object{
+				&oadpv1alpha1.CloudStorage{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "bucket-123",
+						Namespace: "test-ns",
+					},
+					Spec: oadpv1alpha1.CloudStorageSpec{
+						EnableSharedConfig: &trueVal,
+					},
+				},
+			},
+		},
+		{
+			name: "velero with custom metrics address",
+			veleroDeployment: &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-velero-deployment",
+					Namespace: "test-ns",
+				},
+				Spec: appsv1.DeploymentSpec{
+					Selector: &metav1.LabelSelector{MatchLabels: veleroDeploymentMatchLabels},
+				},
+			},
+			dpa: &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-Velero-CR",
+					Namespace: "test-ns",
+				},
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{
+							Args: &server.Args{
+								ServerConfig: server.ServerConfig{
+									MetricsAddress: ":" + strconv.Itoa(int(argsMetricsPortTest)),
+								},
+							},
+						},
+					},
+				},
+			},
+			wantVeleroDeployment: &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-velero-deployment",
+					Namespace: "test-ns",
+					Labels:    veleroDeploymentLabel,
+				},
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "Deployment",
+					APIVersion: appsv1.SchemeGroupVersion.String(),
+				},
+				Spec: appsv1.DeploymentSpec{
+					Selector: &metav1.LabelSelector{MatchLabels: veleroDeploymentMatchLabels},
+					Replicas: pointer.Int32(1),
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{
+							Labels: veleroDeploymentMatchLabels,
+							Annotations: common.AppendTTMapAsCopy(veleroPodAnnotations, map[string]string{
+								"prometheus.io/port": strconv.Itoa(int(argsMetricsPortTest)),
+							}),
+						},
+						Spec: corev1.PodSpec{
+							RestartPolicy:      corev1.RestartPolicyAlways,
+							ServiceAccountName: common.Velero,
+							Containers: []corev1.Container{
+								{
+									Name:            common.Velero,
+									Image:           common.VeleroImage,
+									ImagePullPolicy: corev1.PullAlways,
+									Ports:           []corev1.ContainerPort{{Name: "metrics", ContainerPort: argsMetricsPortTest}},
+									Resources:       corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m"), corev1.ResourceMemory: resource.MustParse("128Mi")}},
+									Command:         []string{"/velero"},
+									Args: []string{
+										"server",
+										"--metrics-address=:" + strconv.Itoa(int(argsMetricsPortTest)),
+										"--fs-backup-timeout=4h0m0s",
+										defaultRestoreResourcePriorities,
+										defaultDisableInformerCache,
+									},
+									VolumeMounts: baseVolumeMounts,
+									Env:          baseEnvVars,
+								},
+							},
+							Volumes:        baseVolumes,
+							InitContainers: []corev1.Container{},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "velero with custom client page size",
+			veleroDeployment: &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-velero-deployment",
+					Namespace: "test-ns",
+				},
+				Spec: appsv1.DeploymentSpec{
+					Selector: &metav1.LabelSelector{MatchLabels: veleroDeploymentMatchLabels},
+				},
+			},
+			dpa: &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-Velero-CR",
+					Namespace: "test-ns",
+				},
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{
+							Args: &server.Args{
+								ServerConfig: server.ServerConfig{
+									ClientPageSize: pointer.Int(250),
+								},
+							},
+						},
+					},
+				},
+			},
+			wantVeleroDeployment: &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-velero-deployment",
+					Namespace: "test-ns",
+					Labels:    veleroDeploymentLabel,
+				},
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "Deployment",
+					APIVersion: appsv1.SchemeGroupVersion.String(),
+				},
+				Spec: appsv1.DeploymentSpec{
+					Selector: &metav1.LabelSelector{MatchLabels: veleroDeploymentMatchLabels},
+					Replicas: pointer.Int32(1),
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{
+							Labels:      veleroDeploymentMatchLabels,
+							Annotations: veleroPodAnnotations,
+						},
+						Spec: corev1.PodSpec{
+							RestartPolicy:      corev1.RestartPolicyAlways,
+							ServiceAccountName: common.Velero,
+							Containers: []corev1.Container{
+								{
+									Name:            common.Velero,
+									Image:           common.VeleroImage,
+									ImagePullPolicy: corev1.PullAlways,
+									Ports:           []corev1.ContainerPort{{Name: "metrics", ContainerPort: 8085}},
+									Resources:       corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m"), corev1.ResourceMemory: resource.MustParse("128Mi")}},
+									Command:         []string{"/velero"},
+									Args: []string{
+										"server",
+										"--client-page-size=250",
+										"--fs-backup-timeout=4h0m0s",
+										defaultRestoreResourcePriorities,
+										defaultDisableInformerCache,
+									},
+									VolumeMounts: baseVolumeMounts,
+									Env:          baseEnvVars,
+								},
+							},
+							Volumes:        baseVolumes,
+							InitContainers: []corev1.Container{},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "velero with command override",
+			veleroDeployment: &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-velero-deployment",
+					Namespace: "test-ns",
+				},
+				Spec: appsv1.DeploymentSpec{
+					Selector: &metav1.LabelSelector{MatchLabels: veleroDeploymentMatchLabels},
+				},
+			},
+			dpa: &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-Velero-CR",
+					Namespace: "test-ns",
+				},
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{
+							Command: []string{"/usr/local/bin/entrypoint.sh", "/velero"},
+						},
+					},
+				},
+			},
+			wantVeleroDeployment: &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-velero-deployment",
+					Namespace: "test-ns",
+					Labels:    veleroDeploymentLabel,
+				},
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "Deployment",
+					APIVersion: appsv1.SchemeGroupVersion.String(),
+				},
+				Spec: appsv1.DeploymentSpec{
+					Selector: &metav1.LabelSelector{MatchLabels: veleroDeploymentMatchLabels},
+					Replicas: pointer.Int32(1),
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{
+							Labels:      veleroDeploymentMatchLabels,
+							Annotations: veleroPodAnnotations,
+						},
+						Spec: corev1.PodSpec{
+							RestartPolicy:      corev1.RestartPolicyAlways,
+							ServiceAccountName: common.Velero,
+							Containers: []corev1.Container{
+								{
+									Name:            common.Velero,
+									Image:           common.VeleroImage,
+									ImagePullPolicy: corev1.PullAlways,
+									Ports:           []corev1.ContainerPort{{Name: "metrics", ContainerPort: 8085}},
+									Resources:       corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m"), corev1.ResourceMemory: resource.MustParse("128Mi")}},
+									Command:         []string{"/usr/local/bin/entrypoint.sh", "/velero"},
+									Args: []string{
+										"server",
+										defaultFileSystemBackupTimeout,
+										defaultRestoreResourcePriorities,
+										defaultDisableInformerCache,
+									},
+									VolumeMounts: baseVolumeMounts,
+									Env:          baseEnvVars,
+								},
+							},
+							Volumes:        baseVolumes,
+							InitContainers: []corev1.Container{},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Override restore resource priorities",
+			veleroDeployment: &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-velero-deployment",
+					Namespace: "test-ns",
+				},
+				Spec: appsv1.DeploymentSpec{
+					Selector: &metav1.LabelSelector{MatchLabels: veleroDeploymentMatchLabels},
+				},
+			},
+			dpa: &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-Velero-CR",
+					Namespace: "test-ns",
+				},
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{
+							Args: &server.Args{
+								ServerConfig: server.ServerConfig{
+									RestoreResourcePriorities: "securitycontextconstraints,test",
+								},
+							},
+						},
+					},
+				},
+			},
+			wantVeleroDeployment: &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-velero-deployment",
+					Namespace: "test-ns",
+					Labels:    veleroDeploymentLabel,
+				},
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "Deployment",
+					APIVersion: appsv1.SchemeGroupVersion.String(),
+				},
+				Spec: appsv1.DeploymentSpec{
+					Selector: &metav1.LabelSelector{MatchLabels: veleroDeploymentMatchLabels},
+					Replicas: pointer.Int32(1),
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: veleroPodObjectMeta,
+						Spec: corev1.PodSpec{
+							RestartPolicy:      corev1.RestartPolicyAlways,
+							ServiceAccountName: common.Velero,
+							Containers: []corev1.Container{
+								{
+									Name:            common.Velero,
+									Image:           common.VeleroImage,
+									ImagePullPolicy: corev1.PullAlways,
+									Ports:           []corev1.ContainerPort{{Name: "metrics", ContainerPort: 8085}},
+									Resources:       corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m"), corev1.ResourceMemory: resource.MustParse("128Mi")}},
+									Command:         []string{"/velero"},
+									Args: []string{
+										"server",
+										"--fs-backup-timeout=4h0m0s",
+										"--restore-resource-priorities=securitycontextconstraints,test",
+										defaultDisableInformerCache,
+									},
+									VolumeMounts: baseVolumeMounts,
+									Env:          baseEnvVars,
+								},
+							},
+							Volumes:        baseVolumes,
+							InitContainers: []corev1.Container{},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Restore resource priorities from ConfigMap",
+			veleroDeployment: &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-velero-deployment",
+					Namespace: "test-ns",
+				},
+				Spec: appsv1.DeploymentSpec{
+					Selector: &metav1.LabelSelector{MatchLabels: veleroDeploymentMatchLabels},
+				},
+			},
+			dpa: &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-Velero-CR",
+					Namespace: "test-ns",
+				},
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{
+							RestoreResourcePrioritiesConfigMap: "restore-priorities",
+						},
+					},
+				},
+			},
+			clientObjects: []client.Object{
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "restore-priorities",
+						Namespace: "test-ns",
+					},
+					Data: map[string]string{
+						"restoreResourcePriorities": "securitycontextconstraints\ncustomresourcedefinitions,namespaces\n-\nclusters.cluster.x-k8s.io\n",
+					},
+				},
+			},
+			wantVeleroDeployment: &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-velero-deployment",
+					Namespace: "test-ns",
+					Labels:    veleroDeploymentLabel,
+				},
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "Deployment",
+					APIVersion: appsv1.SchemeGroupVersion.String(),
+				},
+				Spec: appsv1.DeploymentSpec{
+					Selector: &metav1.LabelSelector{MatchLabels: veleroDeploymentMatchLabels},
+					Replicas: pointer.Int32(1),
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: veleroPodObjectMeta,
+						Spec: corev1.PodSpec{
+							RestartPolicy:      corev1.RestartPolicyAlways,
+							ServiceAccountName: common.Velero,
+							Containers: []corev1.Container{
+								{
+									Name:            common.Velero,
+									Image:           common.VeleroImage,
+									ImagePullPolicy: corev1.PullAlways,
+									Ports:           []corev1.ContainerPort{{Name: "metrics", ContainerPort: 8085}},
+									Resources:       corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m"), corev1.ResourceMemory: resource.MustParse("128Mi")}},
+									Command:         []string{"/velero"},
+									Args: []string{
+										"server",
+										defaultFileSystemBackupTimeout,
+										"--restore-resource-priorities=securitycontextconstraints,customresourcedefinitions,namespaces,-,clusters.cluster.x-k8s.io",
+										defaultDisableInformerCache,
+									},
+									VolumeMounts: baseVolumeMounts,
+									Env:          baseEnvVars,
+								},
+							},
+							Volumes:        baseVolumes,
+							InitContainers: []corev1.Container{},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Restore resource priorities ConfigMap does not exist",
+			veleroDeployment: &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-velero-deployment",
+					Namespace: "test-ns",
+				},
+				Spec: appsv1.DeploymentSpec{
+					Selector: &metav1.LabelSelector{MatchLabels: veleroDeploymentMatchLabels},
+				},
+			},
+			dpa: &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-Velero-CR",
+					Namespace: "test-ns",
+				},
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{
+							RestoreResourcePrioritiesConfigMap: "restore-priorities",
+						},
+					},
+				},
+			},
+			wantErr:              true,
+			wantVeleroDeployment: nil,
+		},
+		{
+			name: "Check values of time fields in Velero args",
+			veleroDeployment: &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-velero-deployment",
+					Namespace: "test-ns",
+				},
+				Spec: appsv1.DeploymentSpec{
+					Selector: &metav1.LabelSelector{MatchLabels: veleroDeploymentMatchLabels},
+				},
+			},
+			dpa: &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-Velero-CR",
+					Namespace: "test-ns",
+				},
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{
+							Args: &server.Args{
+								ServerConfig: server.ServerConfig{
+									BackupSyncPeriod:            pointer.Duration(1),
+									PodVolumeOperationTimeout:   pointer.Duration(1),
+									ResourceTerminatingTimeout:  pointer.Duration(1),
+									DefaultBackupTTL:            pointer.Duration(1),
+									StoreValidationFrequency:    pointer.Duration(1),
+									ItemOperationSyncFrequency:  pointer.Duration(1),
+									RepoMaintenanceFrequency:    pointer.Duration(1),
+									GarbageCollectionFrequency:  pointer.Duration(1),
+									DefaultItemOperationTimeout: pointer.Duration(1),
+									ResourceTimeout:             pointer.Duration(1),
+								},
+							},
+						},
+					},
+				},
+			},
+			wantVeleroDeployment: &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-velero-deployment",
+					Namespace: "test-ns",
+					Labels:    veleroDeploymentLabel,
+				},
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "Deployment",
+					APIVersion: appsv1.SchemeGroupVersion.String(),
+				},
+				Spec: appsv1.DeploymentSpec{
+					Selector: &metav1.LabelSelector{MatchLabels: veleroDeploymentMatchLabels},
+					Replicas: pointer.Int32(1),
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: veleroPodObjectMeta,
+						Spec: corev1.PodSpec{
+							RestartPolicy:      corev1.RestartPolicyAlways,
+							ServiceAccountName: common.Velero,
+							Containers: []corev1.Container{
+								{
+									Name:            common.Velero,
+									Image:           common.VeleroImage,
+									ImagePullPolicy: corev1.PullAlways,
+									Ports:           []corev1.ContainerPort{{Name: "metrics", ContainerPort: 8085}},
+									Resources:       corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m"), corev1.ResourceMemory: resource.MustParse("128Mi")}},
+									Command:         []string{"/velero"},
+									Args: []string{
+										"server",
+										"--backup-sync-period=1ns",
+										"--default-backup-ttl=1ns",
+										"--default-item-operation-timeout=1ns",
+										"--resource-timeout=1ns",
+										"--default-repo-maintain-frequency=1ns",
+										"--garbage-collection-frequency=1ns",
+										"--fs-backup-timeout=1ns",
+										"--item-operation-sync-frequency=1ns",
+										defaultRestoreResourcePriorities,
+										"--store-validation-frequency=1ns",
+										"--terminating-resource-timeout=1ns",
+										defaultDisableInformerCache,
+									},
+									VolumeMounts: baseVolumeMounts,
+									Env:          baseEnvVars,
+								},
+							},
+							Volumes:        baseVolumes,
+							InitContainers: []corev1.Container{},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Check disabled repo maintenance in Velero args",
+			veleroDeployment: &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-velero-deployment",
+					Namespace: "test-ns",
+				},
+				Spec: appsv1.DeploymentSpec{
+					Selector: &metav1.LabelSelector{MatchLabels: veleroDeploymentMatchLabels},
+				},
+			},
+			dpa: &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-Velero-CR",
+					Namespace: "test-ns",
+				},
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{
+							Args: &server.Args{
+								ServerConfig: server.ServerConfig{
+									RepoMaintenanceFrequency: pointer.Duration(0),
+								},
+							},
+						},
+					},
+				},
+			},
+			wantVeleroDeployment: &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-velero-deployment",
+					Namespace: "test-ns",
+					Labels:    veleroDeploymentLabel,
+				},
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "Deployment",
+					APIVersion: appsv1.SchemeGroupVersion.String(),
+				},
+				Spec: appsv1.DeploymentSpec{
+					Selector: &metav1.LabelSelector{MatchLabels: veleroDeploymentMatchLabels},
+					Replicas: pointer.Int32(1),
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: veleroPodObjectMeta,
+						Spec: corev1.PodSpec{
+							RestartPolicy:      corev1.RestartPolicyAlways,
+							ServiceAccountName: common.Velero,
+							Containers: []corev1.Container{
+								{
+									Name:            common.Velero,
+									Image:           common.VeleroImage,
+									ImagePullPolicy: corev1.PullAlways,
+									Ports:           []corev1.ContainerPort{{Name: "metrics", ContainerPort: 8085}},
+									Resources:       corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m"), corev1.ResourceMemory: resource.MustParse("128Mi")}},
+									Command:         []string{"/velero"},
+									Args: []string{
+										"server",
+										"--default-repo-maintain-frequency=87600h0m0s",
+										"--fs-backup-timeout=4h0m0s",
+										defaultRestoreResourcePriorities,
+										defaultDisableInformerCache,
+									},
+									VolumeMounts: baseVolumeMounts,
+									Env:          baseEnvVars,
+								},
+							},
+							Volumes:        baseVolumes,
+							InitContainers: []corev1.Container{},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "given valid DPA CR with sysctls, velero pod has sysctls",
+			veleroDeployment: &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-velero-deployment",
+					Namespace: "test-ns",
+				},
+				Spec: appsv1.DeploymentSpec{
+					Selector: &metav1.LabelSelector{MatchLabels: veleroDeploymentMatchLabels},
+				},
+			},
+			dpa: &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-Velero-CR",
+					Namespace: "test-ns",
+				},
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{
+							Sysctls: []corev1.Sysctl{
+								{Name: "net.ipv4.ip_local_port_range", Value: "1024 65535"},
+							},
+						},
+					},
+				},
+			},
+			wantVeleroDeployment: &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-velero-deployment",
+					Namespace: "test-ns",
+					Labels:    veleroDeploymentLabel,
+				},
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "Deployment",
+					APIVersion: appsv1.SchemeGroupVersion.String(),
+				},
+				Spec: appsv1.DeploymentSpec{
+					Selector: &metav1.LabelSelector{MatchLabels: veleroDeploymentMatchLabels},
+					Replicas: pointer.Int32(1),
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: veleroPodObjectMeta,
+						Spec: corev1.PodSpec{
+							RestartPolicy:      corev1.RestartPolicyAlways,
+							ServiceAccountName: common.Velero,
+							Containers: []corev1.Container{
+								{
+									Name:            common.Velero,
+									Image:           common.VeleroImage,
+									ImagePullPolicy: corev1.PullAlways,
+									Ports:           []corev1.ContainerPort{{Name: "metrics", ContainerPort: 8085}},
+									Resources:       corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m"), corev1.ResourceMemory: resource.MustParse("128Mi")}},
+									Command:         []string{"/velero"},
+									Args: []string{
+										"server",
+										defaultFileSystemBackupTimeout,
+										defaultRestoreResourcePriorities,
+										defaultDisableInformerCache,
+									},
+									VolumeMounts: baseVolumeMounts,
+									Env:          baseEnvVars,
+								},
+							},
+							Volumes:        baseVolumes,
+							InitContainers: []corev1.Container{},
+							SecurityContext: &corev1.PodSecurityContext{
+								Sysctls: []corev1.Sysctl{
+									{Name: "net.ipv4.ip_local_port_range", Value: "1024 65535"},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient, err := getFakeClientFromObjects(tt.clientObjects...)
+			if err != nil {
+				t.Errorf("error in creating fake client, likely programmer error")
+			}
+			r := DPAReconciler{
+				Client: fakeClient,
+			}
+			oadpclient.SetClient(fakeClient)
+			if tt.testProxy {
+				os.Setenv(proxyEnvKey, proxyEnvValue)
+				defer os.Unsetenv(proxyEnvKey)
+			}
+			if err := r.buildVeleroDeployment(tt.veleroDeployment, tt.dpa); err != nil {
+				if !tt.wantErr {
+					t.Errorf("buildVeleroDeployment() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				if tt.wantErr && tt.wantVeleroDeployment == nil {
+					// if we expect an error and we got one, and wantVeleroDeployment is not defined, we don't need to compare further.
+					t.Skip()
+				}
+			}
+			if tt.dpa != nil {
+				setPodTemplateSpecDefaults(&tt.wantVeleroDeployment.Spec.Template)
 				if len(tt.wantVeleroDeployment.Spec.Template.Spec.Containers) > 0 {
 					setContainerDefaults(&tt.wantVeleroDeployment.Spec.Template.Spec.Containers[0])
 				}
-				if tt.wantVeleroDeployment.Spec.Strategy.Type == "" {
-					tt.wantVeleroDeployment.Spec.Strategy.Type = appsv1.RollingUpdateDeploymentStrategyType
+				if tt.wantVeleroDeployment.Spec.Strategy.Type == "" {
+					tt.wantVeleroDeployment.Spec.Strategy.Type = appsv1.RollingUpdateDeploymentStrategyType
+				}
+				if tt.wantVeleroDeployment.Spec.Strategy.RollingUpdate == nil {
+					tt.wantVeleroDeployment.Spec.Strategy.RollingUpdate = &appsv1.RollingUpdateDeployment{
+						MaxUnavailable: &intstr.IntOrString{Type: intstr.String, StrVal: "25%"},
+						MaxSurge:       &intstr.IntOrString{Type: intstr.String, StrVal: "25%"},
+					}
+				}
+				if tt.wantVeleroDeployment.Spec.RevisionHistoryLimit == nil {
+					tt.wantVeleroDeployment.Spec.RevisionHistoryLimit = pointer.Int32(10)
+				}
+				if tt.wantVeleroDeployment.Spec.ProgressDeadlineSeconds == nil {
+					tt.wantVeleroDeployment.Spec.ProgressDeadlineSeconds = pointer.Int32(600)
+				}
+			}
+			if !reflect.DeepEqual(tt.wantVeleroDeployment, tt.veleroDeployment) {
+				t.Errorf("expected velero deployment spec to be \n%#v, got \n%#v\nDIFF:%v", tt.wantVeleroDeployment, tt.veleroDeployment, cmp.Diff(tt.wantVeleroDeployment, tt.veleroDeployment))
+			}
+		})
+	}
+}
+
+func TestDPAReconciler_getVeleroImage(t *testing.T) {
+	tests := []struct {
+		name       string
+		DpaCR      *oadpv1alpha1.DataProtectionApplication
+		pluginName string
+		wantImage  string
+		setEnvVars map[string]string
+	}{
+		{
+			name: "given Velero image override, custom Velero image should be returned",
+			DpaCR: &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-Velero-CR",
+					Namespace: "test-ns",
+				},
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					UnsupportedOverrides: map[oadpv1alpha1.UnsupportedImageKey]string{
+						oadpv1alpha1.VeleroImageKey: "test-image",
+					},
+				},
+			},
+			pluginName: common.Velero,
+			wantImage:  "test-image",
+			setEnvVars: make(map[string]string),
+		},
+		{
+			name: "given default DPA CR with no env var, default velero image should be returned",
+			DpaCR: &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-Velero-CR",
+					Namespace: "test-ns",
+				},
+			},
+			pluginName: common.Velero,
+			wantImage:  common.VeleroImage,
+			setEnvVars: make(map[string]string),
+		},
+		{
+			name: "given default DPA CR with env var set, image should be built via env vars",
+			DpaCR: &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-Velero-CR",
+					Namespace: "test-ns",
+				},
+			},
+			pluginName: common.Velero,
+			wantImage:  "quay.io/konveyor/velero:latest",
+			setEnvVars: map[string]string{
+				"REGISTRY":    "quay.io",
+				"PROJECT":     "konveyor",
+				"VELERO_REPO": "velero",
+				"VELERO_TAG":  "latest",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for key, value := range tt.setEnvVars {
+				os.Setenv(key, value)
+				defer os.Unsetenv(key)
+			}
+			gotImage := getVeleroImage(tt.DpaCR)
+			if gotImage != tt.wantImage {
+				t.Errorf("Expected plugin image %v did not match %v", tt.wantImage, gotImage)
+			}
+		})
+	}
+}
+func Test_validateResourceLimits(t *testing.T) {
+	tests := []struct {
+		name           string
+		resourceReqs   corev1.ResourceRequirements
+		wantErrMessage string
+	}{
+		{
+			name: "requests without limits",
+			resourceReqs: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m"), corev1.ResourceMemory: resource.MustParse("128Mi")},
+			},
+		},
+		{
+			name: "limits equal to requests",
+			resourceReqs: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1"), corev1.ResourceMemory: resource.MustParse("1Gi")},
+				Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1000m"), corev1.ResourceMemory: resource.MustParse("1024Mi")},
+			},
+		},
+		{
+			name: "cpu limit below request",
+			resourceReqs: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+				Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m")},
+			},
+			wantErrMessage: "cpu limit (500m) is less than request (1)",
+		},
+		{
+			name: "memory limit below request",
+			resourceReqs: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m"), corev1.ResourceMemory: resource.MustParse("512Mi")},
+				Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1"), corev1.ResourceMemory: resource.MustParse("256Mi")},
+			},
+			wantErrMessage: "memory limit (256Mi) is less than request (512Mi)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateResourceLimits(tt.resourceReqs)
+			if tt.wantErrMessage == "" {
+				if err != nil {
+					t.Errorf("validateResourceLimits() unexpected error = %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErrMessage {
+				t.Errorf("validateResourceLimits() error = %v, want %v", err, tt.wantErrMessage)
+			}
+		})
+	}
+}
+
+func Test_removeDuplicateValues(t *testing.T) {
+	type args struct {
+		slice []string
+	}
+	tests := []struct {
+		name string
+		args args
+		want []string
+	}{
+		{
+			name: "nill slice",
+			args: args{slice: nil},
+			want: nil,
+		},
+		{
+			name: "empty slice",
+			args: args{slice: []string{}},
+			want: []string{},
+		},
+		{
+			name: "one item in slice",
+			args: args{slice: []string{"yo"}},
+			want: []string{"yo"},
+		},
+		{
+			name: "duplicate item in slice",
+			args: args{slice: []string{"ice", "ice", "baby"}},
+			want: []string{"ice", "baby"},
+		},
+		{
+			name: "maintain order of first appearance in slice",
+			args: args{slice: []string{"ice", "ice", "baby", "ice"}},
+			want: []string{"ice", "baby"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := common.RemoveDuplicateValues(tt.args.slice); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("removeDuplicateValues() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_validateVeleroPlugins(t *testing.T) {
+	tests := []struct {
+		name    string
+		dpa     *oadpv1alpha1.DataProtectionApplication
+		secret  *corev1.Secret
+		wantErr bool
+		want    bool
+	}{
+
+		{
+			name: "given valid Velero default plugin, default secret gets mounted as volume mounts",
+			dpa: &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-Velero-CR",
+					Namespace: "test-ns",
+				},
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{
+							DefaultPlugins: []oadpv1alpha1.DefaultPlugin{
+								oadpv1alpha1.DefaultPluginAWS,
+							},
+						},
+					},
+				},
+			},
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "cloud-credentials",
+					Namespace: "test-ns",
+				},
+			},
+			wantErr: false,
+			want:    true,
+		},
+		{
+			name: "given valid Velero default plugin that is not a cloud provider, no secrets get mounted",
+			dpa: &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-Velero-CR",
+					Namespace: "test-ns",
+				},
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{
+							DefaultPlugins: []oadpv1alpha1.DefaultPlugin{
+								oadpv1alpha1.DefaultPluginOpenShift,
+							},
+						},
+					},
+				},
+			},
+			secret:  &corev1.Secret{},
+			wantErr: false,
+			want:    true,
+		},
+		{
+			name: "given valid multiple Velero default plugins, default secrets gets mounted for each plugin if applicable",
+			dpa: &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-Velero-CR",
+					Namespace: "test-ns",
+				},
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{
+							DefaultPlugins: []oadpv1alpha1.DefaultPlugin{
+								oadpv1alpha1.DefaultPluginAWS,
+								oadpv1alpha1.DefaultPluginOpenShift,
+							},
+						},
+					},
+				},
+			},
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "cloud-credentials",
+					Namespace: "test-ns",
+				},
+			},
+			wantErr: false,
+			want:    true,
+		},
+		{
+			name: "given aws default plugin without bsl, the valid plugin check passes",
+			dpa: &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-Velero-CR",
+					Namespace: "test-ns",
+				},
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{
+							DefaultPlugins: []oadpv1alpha1.DefaultPlugin{
+								oadpv1alpha1.DefaultPluginAWS,
+							},
+						},
+					},
+				},
+			},
+			secret:  &corev1.Secret{},
+			wantErr: false,
+			want:    true,
+		},
+		{
+			name: "given azure backup location with service principal credentials, the valid plugin check passes",
+			dpa: &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-Velero-CR",
+					Namespace: "test-ns",
+				},
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{
+							DefaultPlugins: []oadpv1alpha1.DefaultPlugin{
+								oadpv1alpha1.DefaultPluginMicrosoftAzure,
+							},
+						},
+					},
+					BackupLocations: []oadpv1alpha1.BackupLocation{
+						{
+							Velero: &velerov1.BackupStorageLocationSpec{
+								Provider: AzureProvider,
+							},
+						},
+					},
+				},
+			},
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "cloud-credentials-azure",
+					Namespace: "test-ns",
+				},
+				Data: map[string][]byte{"cloud": []byte("AZURE_SUBSCRIPTION_ID=sub\nAZURE_TENANT_ID=tenant\nAZURE_CLIENT_ID=client\nAZURE_CLIENT_SECRET=secret\n")},
+			},
+			wantErr: false,
+			want:    true,
+		},
+		{
+			name: "given azure backup location with incomplete service principal credentials, the valid plugin check fails",
+			dpa: &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-Velero-CR",
+					Namespace: "test-ns",
+				},
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{
+							DefaultPlugins: []oadpv1alpha1.DefaultPlugin{
+								oadpv1alpha1.DefaultPluginMicrosoftAzure,
+							},
+						},
+					},
+					BackupLocations: []oadpv1alpha1.BackupLocation{
+						{
+							Velero: &velerov1.BackupStorageLocationSpec{
+								Provider: AzureProvider,
+							},
+						},
+					},
+				},
+			},
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "cloud-credentials-azure",
+					Namespace: "test-ns",
+				},
+				Data: map[string][]byte{"cloud": []byte("AZURE_SUBSCRIPTION_ID=sub\nAZURE_TENANT_ID=tenant\nAZURE_CLIENT_SECRET=secret\n")},
+			},
+			wantErr: true,
+			want:    false,
+		},
+	}
+	for _, tt := range tests {
+		fakeClient, err := getFakeClientFromObjects(tt.dpa, tt.secret)
+		if err != nil {
+			t.Errorf("error in creating fake client, likely programmer error")
+		}
+		r := &DPAReconciler{
+			Client:  fakeClient,
+			Scheme:  fakeClient.Scheme(),
+			Log:     logr.Discard(),
+			Context: newContextForTest(tt.name),
+			NamespacedName: types.NamespacedName{
+				Namespace: tt.dpa.Namespace,
+				Name:      tt.dpa.Name,
+			},
+			EventRecorder: record.NewFakeRecorder(10),
+		}
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := r.ValidateVeleroPlugins(r.Log)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateVeleroPlugins() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(result, tt.want) {
+				t.Errorf("ValidateVeleroPlugins() = %v, want %v", result, tt.want)
+			}
+		})
+	}
+}
+
+func TestDPAReconciler_ValidateVeleroPluginsLocationCredentials(t *testing.T) {
+	awsSecret := func(name string) *corev1.Secret {
+		return &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test-ns"}}
+	}
+	tests := []struct {
+		name           string
+		secrets        []client.Object
+		wantErrMessage string
+	}{
+		{
+			name:    "three aws locations with three existing secrets",
+			secrets: []client.Object{awsSecret("bsl-credentials-1"), awsSecret("bsl-credentials-2"), awsSecret("vsl-credentials")},
+		},
+		{
+			name:           "three aws locations with two missing secrets",
+			secrets:        []client.Object{awsSecret("bsl-credentials-2")},
+			wantErrMessage: "secrets \"bsl-credentials-1\" not found\nsecrets \"vsl-credentials\" not found",
+		},
+		{
+			name:           "three aws locations with no secrets",
+			wantErrMessage: "secrets \"bsl-credentials-1\" not found\nsecrets \"bsl-credentials-2\" not found\nsecrets \"vsl-credentials\" not found",
+		},
+		{
+			name:           "default secret does not stand in for a missing location secret",
+			secrets:        []client.Object{awsSecret("cloud-credentials"), awsSecret("bsl-credentials-2"), awsSecret("vsl-credentials")},
+			wantErrMessage: "secrets \"bsl-credentials-1\" not found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dpa := &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-Velero-CR", Namespace: "test-ns"},
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{
+							DefaultPlugins: []oadpv1alpha1.DefaultPlugin{oadpv1alpha1.DefaultPluginAWS},
+						},
+					},
+					BackupLocations: []oadpv1alpha1.BackupLocation{
+						{
+							Velero: &velerov1.BackupStorageLocationSpec{
+								Provider:   AWSProvider,
+								Credential: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "bsl-credentials-1"}, Key: "cloud"},
+							},
+						},
+						{
+							Velero: &velerov1.BackupStorageLocationSpec{
+								Provider:   AWSProvider,
+								Credential: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "bsl-credentials-2"}, Key: "cloud"},
+							},
+						},
+					},
+					SnapshotLocations: []oadpv1alpha1.SnapshotLocation{
+						{
+							Velero: &velerov1.VolumeSnapshotLocationSpec{
+								Provider:   AWSProvider,
+								Credential: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "vsl-credentials"}, Key: "cloud"},
+							},
+						},
+					},
+				},
+			}
+			fakeClient, err := getFakeClientFromObjects(append(tt.secrets, dpa)...)
+			if err != nil {
+				t.Errorf("error in creating fake client, likely programmer error")
+			}
+			r := &DPAReconciler{
+				Client:  fakeClient,
+				Scheme:  fakeClient.Scheme(),
+				Log:     logr.Discard(),
+				Context: newContextForTest(tt.name),
+				NamespacedName: types.NamespacedName{
+					Namespace: dpa.Namespace,
+					Name:      dpa.Name,
+				},
+				EventRecorder: record.NewFakeRecorder(10),
+			}
+			_, err = r.ValidateVeleroPlugins(r.Log)
+			if tt.wantErrMessage == "" {
+				if err != nil {
+					t.Errorf("ValidateVeleroPlugins() unexpected error = %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErrMessage {
+				t.Errorf("ValidateVeleroPlugins() error = %v, want %v", err, tt.wantErrMessage)
+			}
+		})
+	}
+}
+
+var allDefaultPluginsList = []oadpv1alpha1.DefaultPlugin{
+	oadpv1alpha1.DefaultPluginAWS,
+	oadpv1alpha1.DefaultPluginGCP,
+	oadpv1alpha1.DefaultPluginMicrosoftAzure,
+	oadpv1alpha1.DefaultPluginKubeVirt,
+	oadpv1alpha1.DefaultPluginOpenShift,
+	oadpv1alpha1.DefaultPluginCSI,
+}
+
+func TestDPAReconciler_noDefaultCredentials(t *testing.T) {
+	type args struct {
+		dpa oadpv1alpha1.DataProtectionApplication
+	}
+	tests := []struct {
+		name                string
+		args                args
+		want                map[string]bool
+		wantHasCloudStorage bool
+		wantErr             bool
+	}{
+		{
+			name: "dpa with all plugins but with noDefualtBackupLocation should not require default credentials",
+			args: args{
+				dpa: oadpv1alpha1.DataProtectionApplication{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "test-Velero-CR",
+						Namespace: "test-ns",
+					},
+					Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+						Configuration: &oadpv1alpha1.ApplicationConfig{
+							Velero: &oadpv1alpha1.VeleroConfig{
+								DefaultPlugins:          allDefaultPluginsList,
+								NoDefaultBackupLocation: true,
+							},
+						},
+					},
+				},
+			},
+			want: map[string]bool{
+				"velero-plugin-for-aws":             false,
+				"velero-plugin-for-gcp":             false,
+				"velero-plugin-for-microsoft-azure": false,
+			},
+			wantHasCloudStorage: false,
+			wantErr:             false,
+		},
+		{
+			name: "dpa no default cloudprovider plugins should not require default credentials",
+			args: args{
+				dpa: oadpv1alpha1.DataProtectionApplication{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "test-Velero-CR",
+						Namespace: "test-ns",
+					},
+					Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+						Configuration: &oadpv1alpha1.ApplicationConfig{
+							Velero: &oadpv1alpha1.VeleroConfig{
+								DefaultPlugins:          []oadpv1alpha1.DefaultPlugin{oadpv1alpha1.DefaultPluginOpenShift},
+								NoDefaultBackupLocation: true,
+							},
+						},
+					},
+				},
+			},
+			want:                map[string]bool{},
+			wantHasCloudStorage: false,
+			wantErr:             false,
+		},
+		{
+			name: "dpa with gcp backup location using workload identity should not require default credentials",
+			args: args{
+				dpa: oadpv1alpha1.DataProtectionApplication{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "test-Velero-CR",
+						Namespace: "test-ns",
+					},
+					Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+						Configuration: &oadpv1alpha1.ApplicationConfig{
+							Velero: &oadpv1alpha1.VeleroConfig{
+								DefaultPlugins: []oadpv1alpha1.DefaultPlugin{oadpv1alpha1.DefaultPluginGCP},
+							},
+						},
+						BackupLocations: []oadpv1alpha1.BackupLocation{
+							{
+								Velero: &velerov1.BackupStorageLocationSpec{
+									Provider: "gcp",
+									Config:   map[string]string{GCPUseWorkloadIdentity: "true", GCPServiceAccount: "velero@project.iam.gserviceaccount.com"},
+								},
+							},
+						},
+					},
+				},
+			},
+			want:                map[string]bool{"gcp": false},
+			wantHasCloudStorage: false,
+			wantErr:             false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient, err := getFakeClientFromObjects(&tt.args.dpa)
+			if err != nil {
+				t.Errorf("error in creating fake client, likely programmer error")
+			}
+			r := DPAReconciler{
+				Client: fakeClient,
+			}
+			got, got1, err := r.noDefaultCredentials(tt.args.dpa)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DPAReconciler.noDefaultCredentials() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DPAReconciler.noDefaultCredentials() got = \n%v, \nwant \n%v", got, tt.want)
+			}
+			if got1 != tt.wantHasCloudStorage {
+				t.Errorf("DPAReconciler.noDefaultCredentials() got1 = %v, want %v", got1, tt.wantHasCloudStorage)
+			}
+		})
+	}
+}
+
+func TestDPAReconciler_VeleroDebugEnvironment(t *testing.T) {
+	tests := []struct {
+		name     string
+		replicas *int
+		wantErr  bool
+	}{
+		{
+			name:     "debug replica override not set",
+			replicas: nil,
+			wantErr:  false,
+		},
+		{
+			name:     "debug replica override set to 1",
+			replicas: pointer.Int(1),
+			wantErr:  false,
+		},
+		{
+			name:     "debug replica override set to 0",
+			replicas: pointer.Int(0),
+			wantErr:  false,
+		},
+	}
+	for _, tt := range tests {
+		var err error
+		if tt.replicas != nil {
+			err = os.Setenv(VeleroReplicaOverride, strconv.Itoa(*tt.replicas))
+		} else {
+			err = os.Unsetenv(VeleroReplicaOverride)
+		}
+		if err != nil {
+			t.Errorf("DPAReconciler.VeleroDebugEnvironment failed to set debug override: %v", err)
+			return
+		}
+
+		dpa := &oadpv1alpha1.DataProtectionApplication{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-Velero-CR",
+				Namespace: "test-ns",
+			},
+			Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+				Configuration: &oadpv1alpha1.ApplicationConfig{
+					Velero: &oadpv1alpha1.VeleroConfig{
+						DefaultPlugins:          allDefaultPluginsList,
+						NoDefaultBackupLocation: true,
+					},
+				},
+			},
+		}
+
+		deployment := &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      common.Velero,
+				Namespace: dpa.Namespace,
+			},
+		}
+
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient, err := getFakeClientFromObjects(dpa)
+			if err != nil {
+				t.Errorf("error in creating fake client, likely programmer error")
+			}
+			r := DPAReconciler{
+				Client: fakeClient,
+			}
+			err = r.buildVeleroDeployment(deployment, dpa)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DPAReconciler.VeleroDebugEnvironment error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if deployment.Spec.Replicas == nil {
+				t.Error("deployment replicas not set")
+				return
+			}
+			if tt.replicas == nil {
+				if *deployment.Spec.Replicas != 1 {
+					t.Errorf("unexpected deployment replica count: %d", *deployment.Spec.Replicas)
+					return
+				}
+			} else {
+				if *deployment.Spec.Replicas != int32(*tt.replicas) {
+					t.Error("debug replica override did not apply")
+					return
+				}
+			}
+		})
+	}
+}
+
+func Test_customizePluginsVolume(t *testing.T) {
+	sizeLimit := resource.MustParse("2Gi")
+	tests := []struct {
+		name             string
+		pluginsVolume    *oadpv1alpha1.PluginsVolumeConfig
+		wantVolumeSource corev1.VolumeSource
+		wantErr          bool
+		wantErrMessage   string
+	}{
+		{
+			name:             "pluginsVolume not set keeps default emptyDir",
+			wantVolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+		},
+		{
+			name:             "pluginsVolume sizeLimit sets emptyDir size",
+			pluginsVolume:    &oadpv1alpha1.PluginsVolumeConfig{SizeLimit: "2Gi"},
+			wantVolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{SizeLimit: &sizeLimit}},
+		},
+		{
+			name:          "pluginsVolume persistentVolumeClaim backs plugins volume",
+			pluginsVolume: &oadpv1alpha1.PluginsVolumeConfig{PersistentVolumeClaim: "velero-plugins"},
+			wantVolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "velero-plugins"},
+			},
+		},
+		{
+			name:           "pluginsVolume invalid sizeLimit",
+			pluginsVolume:  &oadpv1alpha1.PluginsVolumeConfig{SizeLimit: "lots"},
+			wantErr:        true,
+			wantErrMessage: "pluginsVolume sizeLimit \"lots\" is invalid: quantities must match the regular expression '^([+-]?[0-9.]+)([eEinumkKMGTP]*[-+]?[0-9]*)$'",
+		},
+		{
+			name:           "pluginsVolume zero sizeLimit",
+			pluginsVolume:  &oadpv1alpha1.PluginsVolumeConfig{SizeLimit: "0"},
+			wantErr:        true,
+			wantErrMessage: "pluginsVolume sizeLimit \"0\" must be greater than zero",
+		},
+		{
+			name:           "pluginsVolume sizeLimit and persistentVolumeClaim both set",
+			pluginsVolume:  &oadpv1alpha1.PluginsVolumeConfig{SizeLimit: "2Gi", PersistentVolumeClaim: "velero-plugins"},
+			wantErr:        true,
+			wantErrMessage: "pluginsVolume sizeLimit and persistentVolumeClaim cannot both be set",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dpa := &oadpv1alpha1.DataProtectionApplication{
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{
+							PluginsVolume: tt.pluginsVolume,
+						},
+					},
+				},
+			}
+			veleroDeployment := &appsv1.Deployment{
+				Spec: appsv1.DeploymentSpec{
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							Volumes: []corev1.Volume{
+								{
+									Name:         "plugins",
+									VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+								},
+								{
+									Name:         "scratch",
+									VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+								},
+							},
+						},
+					},
+				},
+			}
+			err := customizePluginsVolume(dpa, veleroDeployment)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("customizePluginsVolume() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				if err.Error() != tt.wantErrMessage {
+					t.Errorf("customizePluginsVolume() error = %v, want %v", err, tt.wantErrMessage)
+				}
+				return
+			}
+			if !reflect.DeepEqual(veleroDeployment.Spec.Template.Spec.Volumes[0].VolumeSource, tt.wantVolumeSource) {
+				t.Errorf("customizePluginsVolume() plugins volume = %v, want %v", veleroDeployment.Spec.Template.Spec.Volumes[0].VolumeSource, tt.wantVolumeSource)
+			}
+			if !reflect.DeepEqual(veleroDeployment.Spec.Template.Spec.Volumes[1].VolumeSource, corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}) {
+				t.Errorf("customizePluginsVolume() changed scratch volume")
+			}
+		})
+	}
+}
+
+func Test_validateRemovedFeatureFlags(t *testing.T) {
+	// no recognized flag has been removed from velero, test with a flag removed from a future version
+	removedFlags := removedVeleroFeatureFlags
+	removedVeleroFeatureFlags = map[string][]string{"1.14": {"EnableLegacyFeature"}}
+	defer func() { removedVeleroFeatureFlags = removedFlags }()
+	tests := []struct {
+		name           string
+		image          string
+		featureFlags   []string
+		wantErrMessage string
+	}{
+		{
+			name:         "flag supported by the velero version",
+			image:        "quay.io/konveyor/velero:v1.12.1",
+			featureFlags: []string{"EnableLegacyFeature"},
+		},
+		{
+			name:           "flag removed in the velero version",
+			image:          "quay.io/konveyor/velero:v1.14.0",
+			featureFlags:   []string{"EnableLegacyFeature"},
+			wantErrMessage: "velero feature flag EnableLegacyFeature was removed in velero 1.14 and is not supported by velero image quay.io/konveyor/velero:v1.14.0",
+		},
+		{
+			name:           "flag removed before the velero version",
+			image:          "registry.example.com:5000/velero:1.15",
+			featureFlags:   []string{"EnableLegacyFeature"},
+			wantErrMessage: "velero feature flag EnableLegacyFeature was removed in velero 1.14 and is not supported by velero image registry.example.com:5000/velero:1.15",
+		},
+		{
+			name:         "removed flag not set",
+			image:        "quay.io/konveyor/velero:v1.14.0",
+			featureFlags: []string{enableCSIFeatureFlag, "EnableAPIGroupVersions"},
+		},
+		{
+			name:         "image not tagged with a version",
+			image:        "quay.io/konveyor/velero:latest",
+			featureFlags: []string{"EnableLegacyFeature"},
+		},
+		{
+			name:         "image referenced by digest",
+			image:        "quay.io/konveyor/velero@sha256:0000000000000000000000000000000000000000000000000000000000000000",
+			featureFlags: []string{"EnableLegacyFeature"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dpa := &oadpv1alpha1.DataProtectionApplication{
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{
+							FeatureFlags: tt.featureFlags,
+						},
+					},
+					UnsupportedOverrides: map[oadpv1alpha1.UnsupportedImageKey]string{
+						oadpv1alpha1.VeleroImageKey: tt.image,
+					},
+				},
+			}
+			err := validateRemovedFeatureFlags(dpa)
+			if tt.wantErrMessage == "" {
+				if err != nil {
+					t.Errorf("validateRemovedFeatureFlags() unexpected error = %v", err)
 				}
-				if tt.wantVeleroDeployment.Spec.Strategy.RollingUpdate == nil {
-					tt.wantVeleroDeployment.Spec.Strategy.RollingUpdate = &appsv1.RollingUpdateDeployment{
-						MaxUnavailable: &intstr.IntOrString{Type: intstr.String, StrVal: "25%"},
-						MaxSurge:       &intstr.IntOrString{Type: intstr.String, StrVal: "25%"},
+				return
+			}
+			if err == nil || err.Error() != tt.wantErrMessage {
+				t.Errorf("validateRemovedFeatureFlags() error = %v, want %v", err, tt.wantErrMessage)
+			}
+		})
+	}
+}
+
+func Test_validateResourceTimeout(t *testing.T) {
+	tests := []struct {
+		name                        string
+		resourceTimeout             string
+		defaultItemOperationTimeout string
+		wantErrMessage              string
+	}{
+		{
+			name:                        "resourceTimeout not set",
+			defaultItemOperationTimeout: "1h",
+		},
+		{
+			name:            "only resourceTimeout set",
+			resourceTimeout: "10m",
+		},
+		{
+			name:                        "resourceTimeout equal to defaultItemOperationTimeout",
+			resourceTimeout:             "60m",
+			defaultItemOperationTimeout: "1h",
+		},
+		{
+			name:                        "resourceTimeout lower than defaultItemOperationTimeout",
+			resourceTimeout:             "10m",
+			defaultItemOperationTimeout: "1h",
+			wantErrMessage:              "resourceTimeout \"10m\" must not be lower than defaultItemOperationTimeout \"1h\"",
+		},
+		{
+			name:            "invalid resourceTimeout",
+			resourceTimeout: "10",
+			wantErrMessage:  "resourceTimeout \"10\" is not a valid duration: time: missing unit in duration \"10\"",
+		},
+		{
+			name:            "negative resourceTimeout",
+			resourceTimeout: "-10m",
+			wantErrMessage:  "resourceTimeout \"-10m\" must be greater than zero",
+		},
+		{
+			name:                        "invalid defaultItemOperationTimeout",
+			resourceTimeout:             "10m",
+			defaultItemOperationTimeout: "1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dpa := &oadpv1alpha1.DataProtectionApplication{
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{
+							ResourceTimeout:             tt.resourceTimeout,
+							DefaultItemOperationTimeout: tt.defaultItemOperationTimeout,
+						},
+					},
+				},
+			}
+			err := validateResourceTimeout(dpa)
+			if tt.wantErrMessage == "" {
+				if err != nil {
+					t.Errorf("validateResourceTimeout() unexpected error = %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErrMessage {
+				t.Errorf("validateResourceTimeout() error = %v, want %v", err, tt.wantErrMessage)
+			}
+		})
+	}
+}
+
+func Test_validateVeleroImageOverride(t *testing.T) {
+	tests := []struct {
+		name           string
+		image          string
+		wantErrMessage string
+	}{
+		{
+			name: "override not set",
+		},
+		{
+			name:  "tagged image",
+			image: "registry.example.com:5000/mirror/velero:v1.12.1",
+		},
+		{
+			name:  "image referenced by digest",
+			image: "registry.example.com/velero@sha256:0000000000000000000000000000000000000000000000000000000000000000",
+		},
+		{
+			name:  "tagged image with digest",
+			image: "quay.io/konveyor/velero:oadp-1.3@sha256:0000000000000000000000000000000000000000000000000000000000000000",
+		},
+		{
+			name:           "image without tag or digest",
+			image:          "registry.example.com:5000/mirror/velero",
+			wantErrMessage: "unsupportedOverrides veleroImageFqin \"registry.example.com:5000/mirror/velero\" must have a tag or digest, images without one are pulled with the latest tag",
+		},
+		{
+			name:           "uppercase repository",
+			image:          "registry.example.com/Velero:v1.12.1",
+			wantErrMessage: "unsupportedOverrides veleroImageFqin \"registry.example.com/Velero:v1.12.1\" is not a valid image reference",
+		},
+		{
+			name:           "short digest",
+			image:          "registry.example.com/velero@sha256:abc",
+			wantErrMessage: "unsupportedOverrides veleroImageFqin \"registry.example.com/velero@sha256:abc\" is not a valid image reference",
+		},
+		{
+			name:           "image with spaces",
+			image:          "registry.example.com/velero :v1.12.1",
+			wantErrMessage: "unsupportedOverrides veleroImageFqin \"registry.example.com/velero :v1.12.1\" is not a valid image reference",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dpa := &oadpv1alpha1.DataProtectionApplication{
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					UnsupportedOverrides: map[oadpv1alpha1.UnsupportedImageKey]string{
+						oadpv1alpha1.VeleroImageKey: tt.image,
+					},
+				},
+			}
+			err := validateVeleroImageOverride(dpa)
+			if tt.wantErrMessage == "" {
+				if err != nil {
+					t.Errorf("validateVeleroImageOverride() unexpected error = %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErrMessage {
+				t.Errorf("validateVeleroImageOverride() error = %v, want %v", err, tt.wantErrMessage)
+			}
+		})
+	}
+}
+
+func Test_divergentPluginImageTags(t *testing.T) {
+	tests := []struct {
+		name          string
+		veleroImage   string
+		customPlugins []oadpv1alpha1.CustomPlugin
+		want          []string
+	}{
+		{
+			name:        "plugin tagged like velero",
+			veleroImage: "quay.io/konveyor/velero:oadp-1.3",
+			customPlugins: []oadpv1alpha1.CustomPlugin{
+				{Name: "custom", Image: "registry.example.com:5000/custom-velero-plugin:oadp-1.3"},
+			},
+			want: []string{},
+		},
+		{
+			name:        "divergent plugin tags",
+			veleroImage: "quay.io/konveyor/velero:oadp-1.3",
+			customPlugins: []oadpv1alpha1.CustomPlugin{
+				{Name: "custom", Image: "quay.io/example/custom-velero-plugin:oadp-1.3"},
+				{Name: "older", Image: "registry.example.com:5000/older-velero-plugin:oadp-1.2"},
+				{Name: "newest", Image: "quay.io/example/newest-velero-plugin:latest"},
+			},
+			want: []string{
+				"custom plugin older image registry.example.com:5000/older-velero-plugin:oadp-1.2 is tagged oadp-1.2, but velero image quay.io/konveyor/velero:oadp-1.3 is tagged oadp-1.3, plugins from another release may not be compatible with velero",
+				"custom plugin newest image quay.io/example/newest-velero-plugin:latest is tagged latest, but velero image quay.io/konveyor/velero:oadp-1.3 is tagged oadp-1.3, plugins from another release may not be compatible with velero",
+			},
+		},
+		{
+			name:        "plugin referenced by digest",
+			veleroImage: "quay.io/konveyor/velero:oadp-1.3",
+			customPlugins: []oadpv1alpha1.CustomPlugin{
+				{Name: "custom", Image: "quay.io/example/custom-velero-plugin@sha256:0000000000000000000000000000000000000000000000000000000000000000"},
+			},
+			want: []string{},
+		},
+		{
+			name:        "velero referenced by digest",
+			veleroImage: "quay.io/konveyor/velero@sha256:0000000000000000000000000000000000000000000000000000000000000000",
+			customPlugins: []oadpv1alpha1.CustomPlugin{
+				{Name: "custom", Image: "quay.io/example/custom-velero-plugin:oadp-1.2"},
+			},
+			want: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dpa := &oadpv1alpha1.DataProtectionApplication{
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{
+							CustomPlugins: tt.customPlugins,
+						},
+					},
+					UnsupportedOverrides: map[oadpv1alpha1.UnsupportedImageKey]string{
+						oadpv1alpha1.VeleroImageKey: tt.veleroImage,
+					},
+				},
+			}
+			if got := divergentPluginImageTags(dpa); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("divergentPluginImageTags() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDPAReconciler_buildVeleroDeploymentProfilerAddress(t *testing.T) {
+	tests := []struct {
+		name            string
+		annotations     map[string]string
+		profilerAddress string
+		wantArg         bool
+	}{
+		{
+			name:            "profiler address with opt-in annotation",
+			annotations:     map[string]string{oadpEnableVeleroProfilerAnnotation: "true"},
+			profilerAddress: ":6060",
+			wantArg:         true,
+		},
+		{
+			name:            "profiler address without opt-in annotation",
+			profilerAddress: ":6060",
+		},
+		{
+			name:            "profiler address with opt-in annotation set to false",
+			annotations:     map[string]string{oadpEnableVeleroProfilerAnnotation: "false"},
+			profilerAddress: ":6060",
+		},
+		{
+			name:        "opt-in annotation without profiler address",
+			annotations: map[string]string{oadpEnableVeleroProfilerAnnotation: "true"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dpa := &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "test-Velero-CR",
+					Namespace:   "test-ns",
+					Annotations: tt.annotations,
+				},
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{
+							NoDefaultBackupLocation: true,
+							ProfilerAddress:         tt.profilerAddress,
+						},
+					},
+				},
+			}
+			deployment := &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      common.Velero,
+					Namespace: dpa.Namespace,
+				},
+			}
+			fakeClient, err := getFakeClientFromObjects(dpa)
+			if err != nil {
+				t.Errorf("error in creating fake client, likely programmer error")
+			}
+			r := DPAReconciler{
+				Client: fakeClient,
+			}
+			if err := r.buildVeleroDeployment(deployment, dpa); err != nil {
+				t.Errorf("buildVeleroDeployment() unexpected error = %v", err)
+				return
+			}
+			gotArg := false
+			for _, arg := range deployment.Spec.Template.Spec.Containers[0].Args {
+				if strings.HasPrefix(arg, "--profiler-address") {
+					gotArg = true
+					if arg != "--profiler-address="+tt.profilerAddress {
+						t.Errorf("buildVeleroDeployment() profiler arg = %s, want --profiler-address=%s", arg, tt.profilerAddress)
 					}
 				}
-				if tt.wantVeleroDeployment.Spec.RevisionHistoryLimit == nil {
-					tt.wantVeleroDeployment.Spec.RevisionHistoryLimit = pointer.Int32(10)
+			}
+			if gotArg != tt.wantArg {
+				t.Errorf("buildVeleroDeployment() profiler arg set = %v, want %v", gotArg, tt.wantArg)
+			}
+		})
+	}
+}
+
+func TestDPAReconciler_buildVeleroDeploymentReadOnlyRootFilesystem(t *testing.T) {
+	tests := []struct {
+		name                   string
+		readOnlyRootFilesystem *bool
+		podEnv                 []corev1.EnvVar
+		wantReadOnly           bool
+		wantHome               string
+	}{
+		{
+			name:                   "read-only root filesystem enabled",
+			readOnlyRootFilesystem: pointer.Bool(true),
+			wantReadOnly:           true,
+			wantHome:               "/tmp",
+		},
+		{
+			name:                   "read-only root filesystem enabled with HOME set in podConfig",
+			readOnlyRootFilesystem: pointer.Bool(true),
+			podEnv:                 []corev1.EnvVar{{Name: "HOME", Value: "/scratch"}},
+			wantReadOnly:           true,
+			wantHome:               "/scratch",
+		},
+		{
+			name:                   "read-only root filesystem disabled",
+			readOnlyRootFilesystem: pointer.Bool(false),
+		},
+		{
+			name: "read-only root filesystem not set",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dpa := &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-Velero-CR",
+					Namespace: "test-ns",
+				},
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{
+							NoDefaultBackupLocation: true,
+							ReadOnlyRootFilesystem:  tt.readOnlyRootFilesystem,
+							PodConfig:               &oadpv1alpha1.PodConfig{Env: tt.podEnv},
+						},
+					},
+				},
+			}
+			deployment := &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      common.Velero,
+					Namespace: dpa.Namespace,
+				},
+			}
+			fakeClient, err := getFakeClientFromObjects(dpa)
+			if err != nil {
+				t.Errorf("error in creating fake client, likely programmer error")
+			}
+			r := DPAReconciler{
+				Client: fakeClient,
+			}
+			if err := r.buildVeleroDeployment(deployment, dpa); err != nil {
+				t.Errorf("buildVeleroDeployment() unexpected error = %v", err)
+				return
+			}
+			container := deployment.Spec.Template.Spec.Containers[0]
+			gotReadOnly := container.SecurityContext != nil && reflect.DeepEqual(container.SecurityContext.ReadOnlyRootFilesystem, pointer.Bool(true))
+			if gotReadOnly != tt.wantReadOnly {
+				t.Errorf("buildVeleroDeployment() readOnlyRootFilesystem = %v, want %v", gotReadOnly, tt.wantReadOnly)
+			}
+			emptyDirVolumes := map[string]bool{}
+			for _, volume := range deployment.Spec.Template.Spec.Volumes {
+				emptyDirVolumes[volume.Name] = volume.EmptyDir != nil
+			}
+			mountedPaths := map[string]bool{}
+			for _, mount := range container.VolumeMounts {
+				mountedPaths[mount.MountPath] = emptyDirVolumes[mount.Name] && !mount.ReadOnly
+			}
+			// directories velero writes to must be backed by writable emptyDir volumes
+			writablePaths := []string{"/scratch", "/plugins", "/etc/ssl/certs"}
+			if tt.wantReadOnly {
+				writablePaths = append(writablePaths, "/tmp")
+			} else if _, found := mountedPaths["/tmp"]; found {
+				t.Errorf("buildVeleroDeployment() unexpected /tmp volume mount")
+			}
+			for _, writablePath := range writablePaths {
+				if !mountedPaths[writablePath] {
+					t.Errorf("buildVeleroDeployment() %s is not mounted from a writable emptyDir volume", writablePath)
+				}
+			}
+			gotHome := ""
+			for _, env := range container.Env {
+				if env.Name == "HOME" {
+					gotHome = env.Value
+				}
+			}
+			if gotHome != tt.wantHome {
+				t.Errorf("buildVeleroDeployment() HOME = %q, want %q", gotHome, tt.wantHome)
+			}
+		})
+	}
+}
+
+func TestDPAReconciler_buildVeleroDeploymentLogFile(t *testing.T) {
+	sizeLimit := resource.MustParse("1Gi")
+	smallSizeLimit := resource.MustParse("200Mi")
+	tests := []struct {
+		name             string
+		logFile          *oadpv1alpha1.LogFileConfig
+		command          []string
+		wantCommand      []string
+		wantVolumeSource *corev1.VolumeSource
+		wantMaxSize      string
+	}{
+		{
+			name:        "log file not set",
+			wantCommand: []string{"/velero"},
+		},
+		{
+			name:             "log file on emptyDir",
+			logFile:          &oadpv1alpha1.LogFileConfig{MountPath: "/var/log/velero/", SizeLimit: "1Gi"},
+			wantCommand:      []string{"/bin/bash", "-c", veleroLogFileScript, "/velero"},
+			wantVolumeSource: &corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{SizeLimit: &sizeLimit}},
+			wantMaxSize:      "104857600",
+		},
+		{
+			name:             "log file on emptyDir with a sizeLimit below four times the maximum size",
+			logFile:          &oadpv1alpha1.LogFileConfig{MountPath: "/var/log/velero", SizeLimit: "200Mi"},
+			wantCommand:      []string{"/bin/bash", "-c", veleroLogFileScript, "/velero"},
+			wantVolumeSource: &corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{SizeLimit: &smallSizeLimit}},
+			wantMaxSize:      "52428800",
+		},
+		{
+			name:        "log file on persistentVolumeClaim with command override",
+			logFile:     &oadpv1alpha1.LogFileConfig{MountPath: "/var/log/velero", PersistentVolumeClaim: "velero-logs"},
+			command:     []string{"/wrapper", "/velero"},
+			wantCommand: []string{"/bin/bash", "-c", veleroLogFileScript, "/wrapper", "/velero"},
+			wantVolumeSource: &corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "velero-logs"},
+			},
+			wantMaxSize: "104857600",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dpa := &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-Velero-CR",
+					Namespace: "test-ns",
+				},
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{
+							NoDefaultBackupLocation: true,
+							LogFile:                 tt.logFile,
+							Command:                 tt.command,
+						},
+					},
+				},
+			}
+			deployment := &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      common.Velero,
+					Namespace: dpa.Namespace,
+				},
+			}
+			fakeClient, err := getFakeClientFromObjects(dpa)
+			if err != nil {
+				t.Errorf("error in creating fake client, likely programmer error")
+			}
+			r := DPAReconciler{
+				Client: fakeClient,
+			}
+			if err := r.buildVeleroDeployment(deployment, dpa); err != nil {
+				t.Errorf("buildVeleroDeployment() unexpected error = %v", err)
+				return
+			}
+			container := deployment.Spec.Template.Spec.Containers[0]
+			if !reflect.DeepEqual(container.Command, tt.wantCommand) {
+				t.Errorf("buildVeleroDeployment() command = %v, want %v", container.Command, tt.wantCommand)
+			}
+			var gotVolumeSource *corev1.VolumeSource
+			for _, volume := range deployment.Spec.Template.Spec.Volumes {
+				if volume.Name == veleroLogsVolumeName {
+					gotVolumeSource = volume.VolumeSource.DeepCopy()
+				}
+			}
+			if !reflect.DeepEqual(gotVolumeSource, tt.wantVolumeSource) {
+				t.Errorf("buildVeleroDeployment() log volume = %v, want %v", gotVolumeSource, tt.wantVolumeSource)
+			}
+			gotMountPath := ""
+			for _, mount := range container.VolumeMounts {
+				if mount.Name == veleroLogsVolumeName {
+					gotMountPath = mount.MountPath
 				}
-				if tt.wantVeleroDeployment.Spec.ProgressDeadlineSeconds == nil {
-					tt.wantVeleroDeployment.Spec.ProgressDeadlineSeconds = pointer.Int32(600)
+			}
+			gotLogFile, gotMaxSize := "", ""
+			for _, env := range container.Env {
+				switch env.Name {
+				case veleroLogFileEnvKey:
+					gotLogFile = env.Value
+				case veleroLogFileMaxSizeEnvKey:
+					gotMaxSize = env.Value
 				}
 			}
-			if !reflect.DeepEqual(tt.wantVeleroDeployment, tt.veleroDeployment) {
-				t.Errorf("expected velero deployment spec to be \n%#v, got \n%#v\nDIFF:%v", tt.wantVeleroDeployment, tt.veleroDeployment, cmp.Diff(tt.wantVeleroDeployment, tt.veleroDeployment))
+			wantMountPath, wantLogFile := "", ""
+			if tt.logFile != nil {
+				wantMountPath, wantLogFile = "/var/log/velero", "/var/log/velero/velero.log"
+			}
+			if gotMountPath != wantMountPath || gotLogFile != wantLogFile {
+				t.Errorf("buildVeleroDeployment() log mount path = %q, log file = %q, want %q, %q", gotMountPath, gotLogFile, wantMountPath, wantLogFile)
+			}
+			if gotMaxSize != tt.wantMaxSize {
+				t.Errorf("buildVeleroDeployment() log file max size = %q, want %q", gotMaxSize, tt.wantMaxSize)
 			}
 		})
 	}
 }
 
-func TestDPAReconciler_getVeleroImage(t *testing.T) {
+func Test_validateLogFile(t *testing.T) {
 	tests := []struct {
-		name       string
-		DpaCR      *oadpv1alpha1.DataProtectionApplication
-		pluginName string
-		wantImage  string
-		setEnvVars map[string]string
+		name              string
+		logFile           *oadpv1alpha1.LogFileConfig
+		pluginConfigFiles []oadpv1alpha1.PluginConfigFile
+		wantErrMessage    string
 	}{
 		{
-			name: "given Velero image override, custom Velero image should be returned",
-			DpaCR: &oadpv1alpha1.DataProtectionApplication{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      "test-Velero-CR",
-					Namespace: "test-ns",
-				},
+			name: "log file not set",
+		},
+		{
+			name:    "log file with sizeLimit",
+			logFile: &oadpv1alpha1.LogFileConfig{MountPath: "/var/log/velero", SizeLimit: "1Gi"},
+		},
+		{
+			name:           "relative mountPath",
+			logFile:        &oadpv1alpha1.LogFileConfig{MountPath: "logs"},
+			wantErrMessage: "logFile mountPath \"logs\" must be an absolute path",
+		},
+		{
+			name:           "root mountPath",
+			logFile:        &oadpv1alpha1.LogFileConfig{MountPath: "/"},
+			wantErrMessage: "logFile mountPath cannot be the root directory",
+		},
+		{
+			name:           "mountPath collides with scratch volume",
+			logFile:        &oadpv1alpha1.LogFileConfig{MountPath: "/scratch/logs"},
+			wantErrMessage: "logFile mountPath /scratch/logs collides with scratch volume mounted at /scratch",
+		},
+		{
+			name:              "mountPath collides with plugin config file",
+			logFile:           &oadpv1alpha1.LogFileConfig{MountPath: "/etc/plugin"},
+			pluginConfigFiles: []oadpv1alpha1.PluginConfigFile{{ConfigMap: "plugin-config", MountPath: "/etc/plugin/"}},
+			wantErrMessage:    "logFile mountPath /etc/plugin collides with pluginConfigFiles[0] mounted at /etc/plugin",
+		},
+		{
+			name:           "sizeLimit and persistentVolumeClaim both set",
+			logFile:        &oadpv1alpha1.LogFileConfig{MountPath: "/var/log/velero", SizeLimit: "1Gi", PersistentVolumeClaim: "velero-logs"},
+			wantErrMessage: "logFile sizeLimit and persistentVolumeClaim cannot both be set",
+		},
+		{
+			name:           "zero sizeLimit",
+			logFile:        &oadpv1alpha1.LogFileConfig{MountPath: "/var/log/velero", SizeLimit: "0"},
+			wantErrMessage: "logFile sizeLimit \"0\" must be greater than zero",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dpa := &oadpv1alpha1.DataProtectionApplication{
 				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
-					UnsupportedOverrides: map[oadpv1alpha1.UnsupportedImageKey]string{
-						oadpv1alpha1.VeleroImageKey: "test-image",
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{
+							LogFile:           tt.logFile,
+							PluginConfigFiles: tt.pluginConfigFiles,
+						},
 					},
 				},
-			},
-			pluginName: common.Velero,
-			wantImage:  "test-image",
-			setEnvVars: make(map[string]string),
+			}
+			err := validateLogFile(dpa)
+			if tt.wantErrMessage == "" {
+				if err != nil {
+					t.Errorf("validateLogFile() unexpected error = %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErrMessage {
+				t.Errorf("validateLogFile() error = %v, want %v", err, tt.wantErrMessage)
+			}
+		})
+	}
+}
+
+func Test_validateProfilerAddress(t *testing.T) {
+	optIn := map[string]string{oadpEnableVeleroProfilerAnnotation: "true"}
+	tests := []struct {
+		name            string
+		annotations     map[string]string
+		profilerAddress string
+		wantErrMessage  string
+	}{
+		{
+			name: "profiler address not set",
 		},
 		{
-			name: "given default DPA CR with no env var, default velero image should be returned",
-			DpaCR: &oadpv1alpha1.DataProtectionApplication{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      "test-Velero-CR",
-					Namespace: "test-ns",
-				},
-			},
-			pluginName: common.Velero,
-			wantImage:  common.VeleroImage,
-			setEnvVars: make(map[string]string),
+			name:            "profiler address with opt-in annotation",
+			annotations:     optIn,
+			profilerAddress: "0.0.0.0:6060",
 		},
 		{
-			name: "given default DPA CR with env var set, image should be built via env vars",
-			DpaCR: &oadpv1alpha1.DataProtectionApplication{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      "test-Velero-CR",
-					Namespace: "test-ns",
-				},
-			},
-			pluginName: common.Velero,
-			wantImage:  "quay.io/konveyor/velero:latest",
-			setEnvVars: map[string]string{
-				"REGISTRY":    "quay.io",
-				"PROJECT":     "konveyor",
-				"VELERO_REPO": "velero",
-				"VELERO_TAG":  "latest",
-			},
+			name:            "profiler address without opt-in annotation",
+			profilerAddress: ":6060",
+			wantErrMessage:  "velero profilerAddress requires the oadp.openshift.io/enable-velero-profiler annotation set to true on the DPA",
+		},
+		{
+			name:            "profiler address without port",
+			annotations:     optIn,
+			profilerAddress: "localhost",
+			wantErrMessage:  "velero profilerAddress \"localhost\" must be a host:port address, for example :6060",
+		},
+		{
+			name:            "profiler address with port out of range",
+			annotations:     optIn,
+			profilerAddress: ":70000",
+			wantErrMessage:  "velero profilerAddress \":70000\" port must be between 1 and 65535",
+		},
+		{
+			name:            "profiler address with named port",
+			annotations:     optIn,
+			profilerAddress: ":pprof",
+			wantErrMessage:  "velero profilerAddress \":pprof\" port must be between 1 and 65535",
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			for key, value := range tt.setEnvVars {
-				os.Setenv(key, value)
-				defer os.Unsetenv(key)
+			dpa := &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{Annotations: tt.annotations},
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{ProfilerAddress: tt.profilerAddress},
+					},
+				},
 			}
-			gotImage := getVeleroImage(tt.DpaCR)
-			if gotImage != tt.wantImage {
-				t.Errorf("Expected plugin image %v did not match %v", tt.wantImage, gotImage)
+			err := validateProfilerAddress(dpa)
+			if tt.wantErrMessage == "" {
+				if err != nil {
+					t.Errorf("validateProfilerAddress() unexpected error = %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErrMessage {
+				t.Errorf("validateProfilerAddress() error = %v, want %v", err, tt.wantErrMessage)
 			}
 		})
 	}
 }
-func Test_removeDuplicateValues(t *testing.T) {
-	type args struct {
-		slice []string
-	}
+
+func Test_validateVeleroListenPorts(t *testing.T) {
+	optIn := map[string]string{oadpEnableVeleroProfilerAnnotation: "true"}
 	tests := []struct {
-		name string
-		args args
-		want []string
+		name            string
+		annotations     map[string]string
+		serverConfig    *server.ServerConfig
+		profilerAddress string
+		wantErrMessage  string
 	}{
 		{
-			name: "nill slice",
-			args: args{slice: nil},
-			want: nil,
+			name: "default addresses",
 		},
 		{
-			name: "empty slice",
-			args: args{slice: []string{}},
-			want: []string{},
+			name:         "custom metrics port",
+			serverConfig: &server.ServerConfig{MetricsAddress: ":9090"},
 		},
 		{
-			name: "one item in slice",
-			args: args{slice: []string{"yo"}},
-			want: []string{"yo"},
+			name:           "metrics port colliding with the default profiler port",
+			serverConfig:   &server.ServerConfig{MetricsAddress: ":6060"},
+			wantErrMessage: "velero metrics address \":6060\" and profiler address \"localhost:6060\" use the same port 6060, set a different port for one of them",
 		},
 		{
-			name: "duplicate item in slice",
-			args: args{slice: []string{"ice", "ice", "baby"}},
-			want: []string{"ice", "baby"},
+			name:            "profiler address colliding with the default metrics port",
+			annotations:     optIn,
+			profilerAddress: "0.0.0.0:8085",
+			wantErrMessage:  "velero metrics address \":8085\" and profiler address \"0.0.0.0:8085\" use the same port 8085, set a different port for one of them",
 		},
 		{
-			name: "maintain order of first appearance in slice",
-			args: args{slice: []string{"ice", "ice", "baby", "ice"}},
-			want: []string{"ice", "baby"},
+			name:            "profiler address overrides the profiler arg",
+			annotations:     optIn,
+			serverConfig:    &server.ServerConfig{MetricsAddress: ":9090", ProfilerAddress: "localhost:9090"},
+			profilerAddress: ":6060",
+		},
+		{
+			name:            "profiler address without opt-in annotation is not used",
+			serverConfig:    &server.ServerConfig{MetricsAddress: ":9090"},
+			profilerAddress: ":9090",
+		},
+		{
+			name:         "metrics address without port",
+			serverConfig: &server.ServerConfig{MetricsAddress: "localhost"},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := common.RemoveDuplicateValues(tt.args.slice); !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("removeDuplicateValues() = %v, want %v", got, tt.want)
+			dpa := &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{Annotations: tt.annotations},
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{ProfilerAddress: tt.profilerAddress},
+					},
+				},
+			}
+			if tt.serverConfig != nil {
+				dpa.Spec.Configuration.Velero.Args = &server.Args{ServerConfig: *tt.serverConfig}
+			}
+			err := validateVeleroListenPorts(dpa)
+			if tt.wantErrMessage == "" {
+				if err != nil {
+					t.Errorf("validateVeleroListenPorts() unexpected error = %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErrMessage {
+				t.Errorf("validateVeleroListenPorts() error = %v, want %v", err, tt.wantErrMessage)
 			}
 		})
 	}
 }
 
-func Test_validateVeleroPlugins(t *testing.T) {
+func Test_unrecognizedFeatureFlags(t *testing.T) {
 	tests := []struct {
-		name    string
-		dpa     *oadpv1alpha1.DataProtectionApplication
-		secret  *corev1.Secret
-		wantErr bool
-		want    bool
+		name         string
+		featureFlags []string
+		want         []string
 	}{
-
 		{
-			name: "given valid Velero default plugin, default secret gets mounted as volume mounts",
-			dpa: &oadpv1alpha1.DataProtectionApplication{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      "test-Velero-CR",
-					Namespace: "test-ns",
-				},
+			name: "no feature flags",
+			want: []string{},
+		},
+		{
+			name:         "velero and OADP feature flags",
+			featureFlags: []string{"EnableCSI", "EnableAPIGroupVersions", "no-secret"},
+			want:         []string{},
+		},
+		{
+			name:         "feature flag differing by case",
+			featureFlags: []string{"enablecsi"},
+			want:         []string{"feature flag \"enablecsi\" is not recognized and is ignored, did you mean EnableCSI?"},
+		},
+		{
+			name:         "unknown feature flag",
+			featureFlags: []string{"EnableCSI", "csi-snapshot"},
+			want:         []string{"feature flag \"csi-snapshot\" is not recognized and is ignored"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dpa := &oadpv1alpha1.DataProtectionApplication{
 				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
 					Configuration: &oadpv1alpha1.ApplicationConfig{
-						Velero: &oadpv1alpha1.VeleroConfig{
-							DefaultPlugins: []oadpv1alpha1.DefaultPlugin{
-								oadpv1alpha1.DefaultPluginAWS,
-							},
-						},
+						Velero: &oadpv1alpha1.VeleroConfig{FeatureFlags: tt.featureFlags},
 					},
 				},
-			},
-			secret: &corev1.Secret{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      "cloud-credentials",
-					Namespace: "test-ns",
-				},
-			},
-			wantErr: false,
-			want:    true,
+			}
+			if got := unrecognizedFeatureFlags(dpa); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("unrecognizedFeatureFlags() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_validateNoSecretFeatureFlag(t *testing.T) {
+	credential := &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "cloud-credentials"}, Key: "cloud"}
+	tests := []struct {
+		name              string
+		featureFlags      []string
+		backupLocations   []oadpv1alpha1.BackupLocation
+		snapshotLocations []oadpv1alpha1.SnapshotLocation
+		wantErrMessage    string
+	}{
+		{
+			name:            "no-secret not set",
+			backupLocations: []oadpv1alpha1.BackupLocation{{Velero: &velerov1.BackupStorageLocationSpec{Provider: AWSProvider, Credential: credential}}},
 		},
 		{
-			name: "given valid Velero default plugin that is not a cloud provider, no secrets get mounted",
-			dpa: &oadpv1alpha1.DataProtectionApplication{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      "test-Velero-CR",
-					Namespace: "test-ns",
-				},
+			name:            "no-secret with locations without credentials",
+			featureFlags:    []string{"no-secret"},
+			backupLocations: []oadpv1alpha1.BackupLocation{{Velero: &velerov1.BackupStorageLocationSpec{Provider: AWSProvider}}},
+		},
+		{
+			name:         "no-secret with cloudStorage credential",
+			featureFlags: []string{"no-secret"},
+			backupLocations: []oadpv1alpha1.BackupLocation{{CloudStorage: &oadpv1alpha1.CloudStorageLocation{
+				CloudStorageRef: corev1.LocalObjectReference{Name: "bucket"},
+				Credential:      credential,
+			}}},
+		},
+		{
+			name:            "no-secret with backup location credential",
+			featureFlags:    []string{"no-secret"},
+			backupLocations: []oadpv1alpha1.BackupLocation{{Velero: &velerov1.BackupStorageLocationSpec{Provider: AWSProvider, Credential: credential}}},
+			wantErrMessage:  "feature flag no-secret cannot be set while backupLocations[0] sets credential cloud-credentials, remove the flag or the credential",
+		},
+		{
+			name:              "no-secret with snapshot location credential",
+			featureFlags:      []string{"no-secret"},
+			snapshotLocations: []oadpv1alpha1.SnapshotLocation{{Velero: &velerov1.VolumeSnapshotLocationSpec{Provider: AWSProvider, Credential: credential}}},
+			wantErrMessage:    "feature flag no-secret cannot be set while snapshotLocations[0] sets credential cloud-credentials, remove the flag or the credential",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dpa := &oadpv1alpha1.DataProtectionApplication{
 				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
 					Configuration: &oadpv1alpha1.ApplicationConfig{
-						Velero: &oadpv1alpha1.VeleroConfig{
-							DefaultPlugins: []oadpv1alpha1.DefaultPlugin{
-								oadpv1alpha1.DefaultPluginOpenShift,
-							},
-						},
+						Velero: &oadpv1alpha1.VeleroConfig{FeatureFlags: tt.featureFlags},
 					},
+					BackupLocations:   tt.backupLocations,
+					SnapshotLocations: tt.snapshotLocations,
 				},
-			},
-			secret:  &corev1.Secret{},
-			wantErr: false,
-			want:    true,
+			}
+			err := validateNoSecretFeatureFlag(dpa)
+			if tt.wantErrMessage == "" {
+				if err != nil {
+					t.Errorf("validateNoSecretFeatureFlag() unexpected error = %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErrMessage {
+				t.Errorf("validateNoSecretFeatureFlag() error = %v, want %v", err, tt.wantErrMessage)
+			}
+		})
+	}
+}
+
+func TestDPAReconciler_buildVeleroDeploymentShareProcessNamespace(t *testing.T) {
+	tests := []struct {
+		name                      string
+		podConfig                 *oadpv1alpha1.PodConfig
+		existing                  *bool
+		wantShareProcessNamespace *bool
+	}{
+		{
+			name:                      "shareProcessNamespace enabled",
+			podConfig:                 &oadpv1alpha1.PodConfig{ShareProcessNamespace: pointer.Bool(true)},
+			wantShareProcessNamespace: pointer.Bool(true),
 		},
 		{
-			name: "given valid multiple Velero default plugins, default secrets gets mounted for each plugin if applicable",
-			dpa: &oadpv1alpha1.DataProtectionApplication{
+			name:                      "shareProcessNamespace disabled",
+			podConfig:                 &oadpv1alpha1.PodConfig{ShareProcessNamespace: pointer.Bool(false)},
+			wantShareProcessNamespace: pointer.Bool(false),
+		},
+		{
+			name:      "shareProcessNamespace removed from podConfig",
+			podConfig: &oadpv1alpha1.PodConfig{},
+			existing:  pointer.Bool(true),
+		},
+		{
+			name:     "podConfig removed",
+			existing: pointer.Bool(true),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dpa := &oadpv1alpha1.DataProtectionApplication{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "test-Velero-CR",
 					Namespace: "test-ns",
@@ -4338,249 +6809,255 @@ func Test_validateVeleroPlugins(t *testing.T) {
 				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
 					Configuration: &oadpv1alpha1.ApplicationConfig{
 						Velero: &oadpv1alpha1.VeleroConfig{
-							DefaultPlugins: []oadpv1alpha1.DefaultPlugin{
-								oadpv1alpha1.DefaultPluginAWS,
-								oadpv1alpha1.DefaultPluginOpenShift,
-							},
+							NoDefaultBackupLocation: true,
+							PodConfig:               tt.podConfig,
 						},
 					},
 				},
-			},
-			secret: &corev1.Secret{
+			}
+			deployment := &appsv1.Deployment{
 				ObjectMeta: metav1.ObjectMeta{
-					Name:      "cloud-credentials",
-					Namespace: "test-ns",
+					Name:      common.Velero,
+					Namespace: dpa.Namespace,
 				},
-			},
-			wantErr: false,
-			want:    true,
+			}
+			deployment.Spec.Template.Spec.ShareProcessNamespace = tt.existing
+			fakeClient, err := getFakeClientFromObjects(dpa)
+			if err != nil {
+				t.Errorf("error in creating fake client, likely programmer error")
+			}
+			r := DPAReconciler{
+				Client: fakeClient,
+			}
+			if err := r.buildVeleroDeployment(deployment, dpa); err != nil {
+				t.Errorf("buildVeleroDeployment() unexpected error = %v", err)
+				return
+			}
+			if got := deployment.Spec.Template.Spec.ShareProcessNamespace; !reflect.DeepEqual(got, tt.wantShareProcessNamespace) {
+				t.Errorf("buildVeleroDeployment() shareProcessNamespace = %v, want %v", got, tt.wantShareProcessNamespace)
+			}
+		})
+	}
+}
+
+func TestDPAReconciler_buildVeleroDeploymentPodAnnotations(t *testing.T) {
+	dpa := &oadpv1alpha1.DataProtectionApplication{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-Velero-CR",
+			Namespace: "test-ns",
 		},
-		{
-			name: "given aws default plugin without bsl, the valid plugin check passes",
-			dpa: &oadpv1alpha1.DataProtectionApplication{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      "test-Velero-CR",
-					Namespace: "test-ns",
-				},
-				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
-					Configuration: &oadpv1alpha1.ApplicationConfig{
-						Velero: &oadpv1alpha1.VeleroConfig{
-							DefaultPlugins: []oadpv1alpha1.DefaultPlugin{
-								oadpv1alpha1.DefaultPluginAWS,
-							},
-						},
+		Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+			Configuration: &oadpv1alpha1.ApplicationConfig{
+				Velero: &oadpv1alpha1.VeleroConfig{
+					NoDefaultBackupLocation: true,
+					PodConfig: &oadpv1alpha1.PodConfig{
+						Annotations: map[string]string{"sidecar.istio.io/inject": "false"},
 					},
 				},
 			},
-			secret:  &corev1.Secret{},
-			wantErr: false,
-			want:    true,
+			PodAnnotations: map[string]string{"team": "backup"},
 		},
 	}
-	for _, tt := range tests {
-		fakeClient, err := getFakeClientFromObjects(tt.dpa, tt.secret)
-		if err != nil {
-			t.Errorf("error in creating fake client, likely programmer error")
-		}
-		r := &DPAReconciler{
-			Client:  fakeClient,
-			Scheme:  fakeClient.Scheme(),
-			Log:     logr.Discard(),
-			Context: newContextForTest(tt.name),
-			NamespacedName: types.NamespacedName{
-				Namespace: tt.dpa.Namespace,
-				Name:      tt.dpa.Name,
-			},
-			EventRecorder: record.NewFakeRecorder(10),
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      common.Velero,
+			Namespace: dpa.Namespace,
+		},
+	}
+	fakeClient, err := getFakeClientFromObjects(dpa)
+	if err != nil {
+		t.Errorf("error in creating fake client, likely programmer error")
+	}
+	r := DPAReconciler{
+		Client: fakeClient,
+	}
+	if err := r.buildVeleroDeployment(deployment, dpa); err != nil {
+		t.Errorf("buildVeleroDeployment() unexpected error = %v", err)
+		return
+	}
+	for key, want := range map[string]string{"sidecar.istio.io/inject": "false", "team": "backup"} {
+		if got := deployment.Spec.Template.Annotations[key]; got != want {
+			t.Errorf("buildVeleroDeployment() pod annotation %s = %q, want %q", key, got, want)
 		}
-		t.Run(tt.name, func(t *testing.T) {
-			result, err := r.ValidateVeleroPlugins(r.Log)
-			if (err != nil) != tt.wantErr {
-				t.Errorf("ValidateVeleroPlugins() error = %v, wantErr %v", err, tt.wantErr)
-			}
-			if !reflect.DeepEqual(result, tt.want) {
-				t.Errorf("ValidateVeleroPlugins() = %v, want %v", result, tt.want)
-			}
-		})
 	}
 }
 
-var allDefaultPluginsList = []oadpv1alpha1.DefaultPlugin{
-	oadpv1alpha1.DefaultPluginAWS,
-	oadpv1alpha1.DefaultPluginGCP,
-	oadpv1alpha1.DefaultPluginMicrosoftAzure,
-	oadpv1alpha1.DefaultPluginKubeVirt,
-	oadpv1alpha1.DefaultPluginOpenShift,
-	oadpv1alpha1.DefaultPluginCSI,
-}
-
-func TestDPAReconciler_noDefaultCredentials(t *testing.T) {
-	type args struct {
-		dpa oadpv1alpha1.DataProtectionApplication
-	}
+func TestDPAReconciler_buildVeleroServerArgs(t *testing.T) {
+	restoreResourcePriorities := "--restore-resource-priorities=" + common.DefaultRestoreResourcePriorities.String()
 	tests := []struct {
-		name                string
-		args                args
-		want                map[string]bool
-		wantHasCloudStorage bool
-		wantErr             bool
+		name   string
+		velero *oadpv1alpha1.VeleroConfig
+		want   []string
 	}{
 		{
-			name: "dpa with all plugins but with noDefualtBackupLocation should not require default credentials",
-			args: args{
-				dpa: oadpv1alpha1.DataProtectionApplication{
-					ObjectMeta: metav1.ObjectMeta{
-						Name:      "test-Velero-CR",
-						Namespace: "test-ns",
-					},
-					Spec: oadpv1alpha1.DataProtectionApplicationSpec{
-						Configuration: &oadpv1alpha1.ApplicationConfig{
-							Velero: &oadpv1alpha1.VeleroConfig{
-								DefaultPlugins:          allDefaultPluginsList,
-								NoDefaultBackupLocation: true,
-							},
-						},
-					},
-				},
+			name: "default args",
+			velero: &oadpv1alpha1.VeleroConfig{
+				NoDefaultBackupLocation: true,
 			},
-			want: map[string]bool{
-				"velero-plugin-for-aws":             false,
-				"velero-plugin-for-gcp":             false,
-				"velero-plugin-for-microsoft-azure": false,
+			want: []string{
+				"server",
+				"--disable-informer-cache=false",
+				"--fs-backup-timeout=4h",
+				restoreResourcePriorities,
 			},
-			wantHasCloudStorage: false,
-			wantErr:             false,
 		},
 		{
-			name: "dpa no default cloudprovider plugins should not require default credentials",
-			args: args{
-				dpa: oadpv1alpha1.DataProtectionApplication{
-					ObjectMeta: metav1.ObjectMeta{
-						Name:      "test-Velero-CR",
-						Namespace: "test-ns",
-					},
-					Spec: oadpv1alpha1.DataProtectionApplicationSpec{
-						Configuration: &oadpv1alpha1.ApplicationConfig{
-							Velero: &oadpv1alpha1.VeleroConfig{
-								DefaultPlugins:          []oadpv1alpha1.DefaultPlugin{oadpv1alpha1.DefaultPluginOpenShift},
-								NoDefaultBackupLocation: true,
-							},
-						},
-					},
-				},
+			name: "feature flags and log level",
+			velero: &oadpv1alpha1.VeleroConfig{
+				NoDefaultBackupLocation: true,
+				FeatureFlags:            []string{"EnableCSI"},
+				DefaultPlugins:          []oadpv1alpha1.DefaultPlugin{oadpv1alpha1.DefaultPluginCSI},
+				LogLevel:                "debug",
+			},
+			want: []string{
+				"server",
+				"--disable-informer-cache=false",
+				"--features=EnableCSI",
+				"--fs-backup-timeout=4h",
+				"--log-level=debug",
+				restoreResourcePriorities,
 			},
-			want:                map[string]bool{},
-			wantHasCloudStorage: false,
-			wantErr:             false,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			fakeClient, err := getFakeClientFromObjects(&tt.args.dpa)
+			dpa := &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-Velero-CR",
+					Namespace: "test-ns",
+				},
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: tt.velero,
+					},
+				},
+			}
+			fakeClient, err := getFakeClientFromObjects(dpa)
 			if err != nil {
 				t.Errorf("error in creating fake client, likely programmer error")
 			}
 			r := DPAReconciler{
 				Client: fakeClient,
 			}
-			got, got1, err := r.noDefaultCredentials(tt.args.dpa)
-			if (err != nil) != tt.wantErr {
-				t.Errorf("DPAReconciler.noDefaultCredentials() error = %v, wantErr %v", err, tt.wantErr)
+			got, err := r.buildVeleroServerArgs(dpa)
+			if err != nil {
+				t.Errorf("buildVeleroServerArgs() unexpected error = %v", err)
 				return
 			}
 			if !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("DPAReconciler.noDefaultCredentials() got = \n%v, \nwant \n%v", got, tt.want)
-			}
-			if got1 != tt.wantHasCloudStorage {
-				t.Errorf("DPAReconciler.noDefaultCredentials() got1 = %v, want %v", got1, tt.wantHasCloudStorage)
+				t.Errorf("buildVeleroServerArgs() = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
 
-func TestDPAReconciler_VeleroDebugEnvironment(t *testing.T) {
+func TestDPAReconciler_installedPlugins(t *testing.T) {
+	dpa := &oadpv1alpha1.DataProtectionApplication{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-Velero-CR", Namespace: "test-ns"},
+		Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+			Configuration: &oadpv1alpha1.ApplicationConfig{
+				Velero: &oadpv1alpha1.VeleroConfig{
+					NoDefaultBackupLocation: true,
+					DefaultPlugins: []oadpv1alpha1.DefaultPlugin{
+						oadpv1alpha1.DefaultPluginOpenShift,
+						oadpv1alpha1.DefaultPluginCSI,
+						oadpv1alpha1.DefaultPluginAWS,
+					},
+					CustomPlugins: []oadpv1alpha1.CustomPlugin{{Name: "my-plugin", Image: "quay.io/example/my-plugin:v1.0.0"}},
+				},
+			},
+		},
+	}
+	veleroDeployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      common.Velero,
+			Namespace: dpa.Namespace,
+		},
+	}
+	fakeClient, err := getFakeClientFromObjects(dpa)
+	if err != nil {
+		t.Errorf("error in creating fake client, likely programmer error")
+	}
+	r := &DPAReconciler{
+		Client:  fakeClient,
+		Scheme:  fakeClient.Scheme(),
+		Log:     logr.Discard(),
+		Context: newContextForTest("installed plugins"),
+	}
+	if err := r.buildVeleroDeployment(veleroDeployment, dpa); err != nil {
+		t.Errorf("buildVeleroDeployment() unexpected error = %v", err)
+		return
+	}
+	// an init container not copying a plugin is not listed
+	veleroDeployment.Spec.Template.Spec.InitContainers = append(veleroDeployment.Spec.Template.Spec.InitContainers, corev1.Container{Name: "setup"})
+	if err := r.Create(r.Context, veleroDeployment); err != nil {
+		t.Errorf("unable to create velero deployment: %v", err)
+		return
+	}
+	got, err := r.installedPlugins(dpa)
+	if err != nil {
+		t.Errorf("installedPlugins() unexpected error = %v", err)
+		return
+	}
+	want := []string{"openshift", "csi", "aws", "my-plugin"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("installedPlugins() = %v, want %v", got, want)
+	}
+}
+
+func Test_sortedServerArgs(t *testing.T) {
+	got := sortedServerArgs([]string{"server", "--uploader-type=kopia", "--log-level", "debug", "--features=EnableCSI", "--restore-only"})
+	want := []string{"server", "--features=EnableCSI", "--log-level=debug", "--restore-only", "--uploader-type=kopia"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("sortedServerArgs() = %v, want %v", got, want)
+	}
+}
+
+func Test_validateShareProcessNamespace(t *testing.T) {
 	tests := []struct {
-		name     string
-		replicas *int
-		wantErr  bool
+		name           string
+		veleroPod      *oadpv1alpha1.PodConfig
+		nodeAgentPod   *oadpv1alpha1.PodConfig
+		wantErrMessage string
 	}{
 		{
-			name:     "debug replica override not set",
-			replicas: nil,
-			wantErr:  false,
+			name: "shareProcessNamespace not set",
 		},
 		{
-			name:     "debug replica override set to 1",
-			replicas: pointer.Int(1),
-			wantErr:  false,
+			name:      "shareProcessNamespace on velero",
+			veleroPod: &oadpv1alpha1.PodConfig{ShareProcessNamespace: pointer.Bool(true)},
 		},
 		{
-			name:     "debug replica override set to 0",
-			replicas: pointer.Int(0),
-			wantErr:  false,
+			name:         "shareProcessNamespace disabled on node agent",
+			nodeAgentPod: &oadpv1alpha1.PodConfig{ShareProcessNamespace: pointer.Bool(false)},
+		},
+		{
+			name:           "shareProcessNamespace on node agent",
+			nodeAgentPod:   &oadpv1alpha1.PodConfig{ShareProcessNamespace: pointer.Bool(true)},
+			wantErrMessage: "node agent podConfig shareProcessNamespace cannot be set, the node agent container is privileged",
 		},
 	}
 	for _, tt := range tests {
-		var err error
-		if tt.replicas != nil {
-			err = os.Setenv(VeleroReplicaOverride, strconv.Itoa(*tt.replicas))
-		} else {
-			err = os.Unsetenv(VeleroReplicaOverride)
-		}
-		if err != nil {
-			t.Errorf("DPAReconciler.VeleroDebugEnvironment failed to set debug override: %v", err)
-			return
-		}
-
-		dpa := &oadpv1alpha1.DataProtectionApplication{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      "test-Velero-CR",
-				Namespace: "test-ns",
-			},
-			Spec: oadpv1alpha1.DataProtectionApplicationSpec{
-				Configuration: &oadpv1alpha1.ApplicationConfig{
-					Velero: &oadpv1alpha1.VeleroConfig{
-						DefaultPlugins:          allDefaultPluginsList,
-						NoDefaultBackupLocation: true,
+		t.Run(tt.name, func(t *testing.T) {
+			dpa := &oadpv1alpha1.DataProtectionApplication{
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{PodConfig: tt.veleroPod},
+						NodeAgent: &oadpv1alpha1.NodeAgentConfig{
+							NodeAgentCommonFields: oadpv1alpha1.NodeAgentCommonFields{PodConfig: tt.nodeAgentPod},
+						},
 					},
 				},
-			},
-		}
-
-		deployment := &appsv1.Deployment{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      common.Velero,
-				Namespace: dpa.Namespace,
-			},
-		}
-
-		t.Run(tt.name, func(t *testing.T) {
-			fakeClient, err := getFakeClientFromObjects(dpa)
-			if err != nil {
-				t.Errorf("error in creating fake client, likely programmer error")
-			}
-			r := DPAReconciler{
-				Client: fakeClient,
-			}
-			err = r.buildVeleroDeployment(deployment, dpa)
-			if (err != nil) != tt.wantErr {
-				t.Errorf("DPAReconciler.VeleroDebugEnvironment error = %v, wantErr %v", err, tt.wantErr)
-				return
 			}
-			if deployment.Spec.Replicas == nil {
-				t.Error("deployment replicas not set")
+			err := validateShareProcessNamespace(dpa)
+			if tt.wantErrMessage == "" {
+				if err != nil {
+					t.Errorf("validateShareProcessNamespace() unexpected error = %v", err)
+				}
 				return
 			}
-			if tt.replicas == nil {
-				if *deployment.Spec.Replicas != 1 {
-					t.Errorf("unexpected deployment replica count: %d", *deployment.Spec.Replicas)
-					return
-				}
-			} else {
-				if *deployment.Spec.Replicas != int32(*tt.replicas) {
-					t.Error("debug replica override did not apply")
-					return
-				}
+			if err == nil || err.Error() != tt.wantErrMessage {
+				t.Errorf("validateShareProcessNamespace() error = %v, want %v", err, tt.wantErrMessage)
 			}
 		})
 	}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/go-logr/logr"
 	velerov1 "github.com/vmware-tanzu/velero/pkg/apis/velero/v1"
+	"github.com/vmware-tanzu/velero/pkg/util/boolptr"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -23,6 +24,7 @@ const (
 	EnableSharedConfigKey = "enableSharedConfig"
 	GCPSnapshotLocation   = "snapshotLocation"
 	GCPProject            = "project"
+	GCPServiceAccount     = "serviceAccount"
 	AzureApiTimeout       = "apiTimeout"
 	AzureSubscriptionId   = "subscriptionId"
 	AzureIncremental      = "incremental"
@@ -119,6 +121,10 @@ func (r *DPAReconciler) ValidateVolumeSnapshotLocations(dpa oadpv1alpha1.DataPro
 			Spec: *vslSpec.Velero,
 		}
 
+		if err := validateSnapshotLocationCredential(&dpa, i, vslSpec.Velero); err != nil {
+			return false, err
+		}
+
 		// check for valid provider
 		if vslSpec.Velero.Provider != AWSProvider && vslSpec.Velero.Provider != GCPProvider &&
 			vslSpec.Velero.Provider != Azure {
@@ -145,16 +151,6 @@ func (r *DPAReconciler) ValidateVolumeSnapshotLocations(dpa oadpv1alpha1.DataPro
 					return false, fmt.Errorf("%s is not a valid AWS config value", key)
 				}
 			}
-			//checking the aws plugin, if not present, throw warning message
-			if !containsPlugin(dpa.Spec.Configuration.Velero.DefaultPlugins, AWSProvider) {
-				r.Log.Info("VSL for AWS specified, but AWS plugin not present, might be a misconfiguration")
-
-				r.EventRecorder.Event(&vsl,
-					corev1.EventTypeWarning,
-					"VolumeSnapshotLocation is invalid",
-					fmt.Sprintf("could not validate vsl for AWS plugin on: %s/%s", vsl.Namespace, vsl.Name),
-				)
-			}
 		}
 
 		//GCP
@@ -167,16 +163,6 @@ func (r *DPAReconciler) ValidateVolumeSnapshotLocations(dpa oadpv1alpha1.DataPro
 					return false, fmt.Errorf("%s is not a valid GCP config value", key)
 				}
 			}
-			//checking the gcp plugin, if not present, throw warning message
-			if !containsPlugin(dpa.Spec.Configuration.Velero.DefaultPlugins, "gcp") {
-				r.Log.Info("VSL for GCP specified, but GCP plugin not present, might be a misconfiguration")
-
-				r.EventRecorder.Event(&vsl,
-					corev1.EventTypeWarning,
-					"VolumeSnapshotLocation is invalid",
-					fmt.Sprintf("could not validate vsl for GCP plugin on: %s/%s", vsl.Namespace, vsl.Name),
-				)
-			}
 		}
 
 		//Azure
@@ -189,21 +175,42 @@ func (r *DPAReconciler) ValidateVolumeSnapshotLocations(dpa oadpv1alpha1.DataPro
 					return false, fmt.Errorf("%s is not a valid Azure config value", key)
 				}
 			}
-			//checking the azure plugin, if not present, throw warning message
-			if !containsPlugin(dpa.Spec.Configuration.Velero.DefaultPlugins, "azure") {
-				r.Log.Info("VSL for Azure specified, but Azure plugin not present, might be a misconfiguration")
-
-				r.EventRecorder.Event(&vsl,
-					corev1.EventTypeWarning,
-					"VolumeSnapshotLocation is invalid",
-					fmt.Sprintf("could not validate vsl for Azure plugin on: %s/%s", vsl.Namespace, vsl.Name),
-				)
-			}
+		}
+
+		// a VSL is unusable without the plugin that provides its provider
+		if plugin := snapshotLocationPlugin(vslSpec.Velero.Provider); plugin != "" && !containsPlugin(dpa.Spec.Configuration.Velero.DefaultPlugins, string(plugin)) {
+			return false, fmt.Errorf("snapshotLocations[%d] provider %s requires the %s default plugin to be enabled", i, vslSpec.Velero.Provider, plugin)
 		}
 	}
 	return true, nil
 }
 
+// validateSnapshotLocationCredential checks the name and key of the credential of a snapshot location are set, the
+// secret itself is checked by ValidateVeleroPlugins
+func validateSnapshotLocationCredential(dpa *oadpv1alpha1.DataProtectionApplication, i int, vslSpec *velerov1.VolumeSnapshotLocationSpec) error {
+	if vslSpec.Credential == nil || dpa.Spec.Configuration.Velero.HasFeatureFlag(noSecretFeatureFlag) {
+		return nil
+	}
+	if vslSpec.Credential.Key == "" {
+		return fmt.Errorf("Secret key specified in snapshotLocations[%d] cannot be empty", i)
+	}
+	if vslSpec.Credential.Name == "" {
+		return fmt.Errorf("Secret name specified in snapshotLocations[%d] cannot be empty", i)
+	}
+	return nil
+}
+
+// snapshotLocationPlugin returns the default plugin providing a snapshot location provider, including its deprecated
+// velero.io/ alias, or an empty plugin for other providers. Custom plugins cannot provide the providers of default
+// plugins, as custom plugins overlapping a default plugin are rejected.
+func snapshotLocationPlugin(provider string) oadpv1alpha1.DefaultPlugin {
+	switch provider := strings.TrimPrefix(provider, veleroIOPrefix); provider {
+	case AWSProvider, GCPProvider, AzureProvider:
+		return oadpv1alpha1.DefaultPlugin(provider)
+	}
+	return ""
+}
+
 func (r *DPAReconciler) ReconcileVolumeSnapshotLocations(log logr.Logger) (bool, error) {
 	dpa := oadpv1alpha1.DataProtectionApplication{}
 	if err := r.Get(r.Context, r.NamespacedName, &dpa); err != nil {
@@ -262,3 +269,103 @@ func containsPlugin(d []oadpv1alpha1.DefaultPlugin, value string) bool {
 	}
 	return false
 }
+
+// warnSnapshotMoveRegionMismatch emits a warning for every snapshot location whose region differs from a
+// backup location of the same provider while snapshot data is moved to backup storage
+func (r *DPAReconciler) warnSnapshotMoveRegionMismatch(log logr.Logger, dpa *oadpv1alpha1.DataProtectionApplication) {
+	for _, msg := range snapshotMoveRegionMismatches(dpa) {
+		r.warnEvent(log, dpa, "SnapshotMoveRegionMismatch", msg)
+	}
+}
+
+// snapshotMoveRegionMismatches returns a message for each snapshot location and backup location pair of the same
+// provider configured with different regions, if defaultSnapshotMoveData is enabled
+func snapshotMoveRegionMismatches(dpa *oadpv1alpha1.DataProtectionApplication) []string {
+	mismatches := []string{}
+	if dpa.Spec.Configuration == nil || dpa.Spec.Configuration.Velero == nil ||
+		!boolptr.IsSetToTrue(dpa.Spec.Configuration.Velero.DefaultSnapshotMoveData) {
+		return mismatches
+	}
+	for i, vslSpec := range dpa.Spec.SnapshotLocations {
+		if vslSpec.Velero == nil || len(vslSpec.Velero.Config[AWSRegion]) == 0 {
+			continue
+		}
+		vslProvider := strings.TrimPrefix(vslSpec.Velero.Provider, veleroIOPrefix)
+		for j, bslSpec := range dpa.Spec.BackupLocations {
+			if bslSpec.Velero == nil || len(bslSpec.Velero.Config[Region]) == 0 ||
+				strings.TrimPrefix(bslSpec.Velero.Provider, veleroIOPrefix) != vslProvider {
+				continue
+			}
+			if bslSpec.Velero.Config[Region] != vslSpec.Velero.Config[AWSRegion] {
+				mismatches = append(mismatches, fmt.Sprintf(
+					"snapshotLocations[%d] region %s differs from backupLocations[%d] region %s, moving snapshot data with defaultSnapshotMoveData transfers it across regions",
+					i, vslSpec.Velero.Config[AWSRegion], j, bslSpec.Velero.Config[Region]))
+			}
+		}
+	}
+	return mismatches
+}
+
+// warnMissingSnapshotLocation emits a warning when default plugins able to take native volume snapshots are enabled
+// without any snapshot location, while volumes are not backed up by other means by default
+func (r *DPAReconciler) warnMissingSnapshotLocation(log logr.Logger, dpa *oadpv1alpha1.DataProtectionApplication) {
+	if msg := missingSnapshotLocationMessage(dpa); msg != "" {
+		r.warnEvent(log, dpa, "MissingSnapshotLocation", msg)
+	}
+}
+
+// missingSnapshotLocationMessage returns a message naming the snapshot capable default plugins if there is no
+// snapshot location, and neither CSI snapshots nor file system backup are used by default, or an empty string
+func missingSnapshotLocationMessage(dpa *oadpv1alpha1.DataProtectionApplication) string {
+	if dpa.Spec.Configuration == nil || dpa.Spec.Configuration.Velero == nil || len(dpa.Spec.SnapshotLocations) > 0 {
+		return ""
+	}
+	veleroConfig := dpa.Spec.Configuration.Velero
+	if containsPlugin(veleroConfig.DefaultPlugins, string(oadpv1alpha1.DefaultPluginCSI)) ||
+		boolptr.IsSetToTrue(veleroConfig.DefaultVolumesToFSBackup) || boolptr.IsSetToTrue(veleroConfig.DefaultSnapshotMoveData) {
+		return ""
+	}
+	snapshotPlugins := []string{}
+	for _, plugin := range veleroConfig.DefaultPlugins {
+		if snapshotLocationPlugin(string(plugin)) != "" {
+			snapshotPlugins = append(snapshotPlugins, string(plugin))
+		}
+	}
+	if len(snapshotPlugins) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%s default plugins can take native volume snapshots but no snapshotLocations are configured, persistent volumes will not be snapshotted; add a snapshotLocation, enable the csi default plugin or set defaultVolumesToFSBackup", strings.Join(snapshotPlugins, ", "))
+}
+
+// warnSharedSnapshotLocationCredentials emits a warning for every snapshot location using the same credential
+// as a snapshot location of another provider
+func (r *DPAReconciler) warnSharedSnapshotLocationCredentials(log logr.Logger, dpa *oadpv1alpha1.DataProtectionApplication) {
+	for _, msg := range sharedSnapshotLocationCredentials(dpa) {
+		r.warnEvent(log, dpa, "SharedSnapshotLocationCredential", msg)
+	}
+}
+
+// sharedSnapshotLocationCredentials returns a message for each snapshot location referencing the same credential
+// secret key as an earlier snapshot location of a different provider, as credentials are provider specific
+func sharedSnapshotLocationCredentials(dpa *oadpv1alpha1.DataProtectionApplication) []string {
+	messages := []string{}
+	for i, vslSpec := range dpa.Spec.SnapshotLocations {
+		if vslSpec.Velero == nil || vslSpec.Velero.Credential == nil {
+			continue
+		}
+		provider := strings.TrimPrefix(vslSpec.Velero.Provider, veleroIOPrefix)
+		for j, otherSpec := range dpa.Spec.SnapshotLocations[:i] {
+			if otherSpec.Velero == nil || otherSpec.Velero.Credential == nil ||
+				strings.TrimPrefix(otherSpec.Velero.Provider, veleroIOPrefix) == provider {
+				continue
+			}
+			if otherSpec.Velero.Credential.Name == vslSpec.Velero.Credential.Name && otherSpec.Velero.Credential.Key == vslSpec.Velero.Credential.Key {
+				messages = append(messages, fmt.Sprintf(
+					"snapshotLocations[%d] provider %s uses credential %s key %s of snapshotLocations[%d] provider %s, use a credential for each provider",
+					i, provider, vslSpec.Velero.Credential.Name, vslSpec.Velero.Credential.Key, j, strings.TrimPrefix(otherSpec.Velero.Provider, veleroIOPrefix)))
+				break
+			}
+		}
+	}
+	return messages
+}
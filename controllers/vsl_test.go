@@ -57,7 +57,9 @@ func TestDPAReconciler_ValidateVolumeSnapshotLocation(t *testing.T) {
 				},
 				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
 					Configuration: &oadpv1alpha1.ApplicationConfig{
-						Velero: &oadpv1alpha1.VeleroConfig{},
+						Velero: &oadpv1alpha1.VeleroConfig{
+							DefaultPlugins: []oadpv1alpha1.DefaultPlugin{oadpv1alpha1.DefaultPluginAWS},
+						},
 					},
 					SnapshotLocations: []oadpv1alpha1.SnapshotLocation{
 						{
@@ -89,7 +91,9 @@ func TestDPAReconciler_ValidateVolumeSnapshotLocation(t *testing.T) {
 				},
 				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
 					Configuration: &oadpv1alpha1.ApplicationConfig{
-						Velero: &oadpv1alpha1.VeleroConfig{},
+						Velero: &oadpv1alpha1.VeleroConfig{
+							DefaultPlugins: []oadpv1alpha1.DefaultPlugin{oadpv1alpha1.DefaultPluginAWS},
+						},
 					},
 					SnapshotLocations: []oadpv1alpha1.SnapshotLocation{
 						{
@@ -118,7 +122,9 @@ func TestDPAReconciler_ValidateVolumeSnapshotLocation(t *testing.T) {
 				},
 				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
 					Configuration: &oadpv1alpha1.ApplicationConfig{
-						Velero: &oadpv1alpha1.VeleroConfig{},
+						Velero: &oadpv1alpha1.VeleroConfig{
+							DefaultPlugins: []oadpv1alpha1.DefaultPlugin{oadpv1alpha1.DefaultPluginAWS},
+						},
 					},
 					SnapshotLocations: []oadpv1alpha1.SnapshotLocation{
 						{
@@ -151,7 +157,9 @@ func TestDPAReconciler_ValidateVolumeSnapshotLocation(t *testing.T) {
 				},
 				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
 					Configuration: &oadpv1alpha1.ApplicationConfig{
-						Velero: &oadpv1alpha1.VeleroConfig{},
+						Velero: &oadpv1alpha1.VeleroConfig{
+							DefaultPlugins: []oadpv1alpha1.DefaultPlugin{oadpv1alpha1.DefaultPluginAWS},
+						},
 					},
 					SnapshotLocations: []oadpv1alpha1.SnapshotLocation{
 						{
@@ -186,7 +194,9 @@ func TestDPAReconciler_ValidateVolumeSnapshotLocation(t *testing.T) {
 				},
 				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
 					Configuration: &oadpv1alpha1.ApplicationConfig{
-						Velero: &oadpv1alpha1.VeleroConfig{},
+						Velero: &oadpv1alpha1.VeleroConfig{
+							DefaultPlugins: []oadpv1alpha1.DefaultPlugin{oadpv1alpha1.DefaultPluginGCP},
+						},
 					},
 					SnapshotLocations: []oadpv1alpha1.SnapshotLocation{
 						{
@@ -215,7 +225,9 @@ func TestDPAReconciler_ValidateVolumeSnapshotLocation(t *testing.T) {
 				},
 				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
 					Configuration: &oadpv1alpha1.ApplicationConfig{
-						Velero: &oadpv1alpha1.VeleroConfig{},
+						Velero: &oadpv1alpha1.VeleroConfig{
+							DefaultPlugins: []oadpv1alpha1.DefaultPlugin{oadpv1alpha1.DefaultPluginGCP},
+						},
 					},
 					SnapshotLocations: []oadpv1alpha1.SnapshotLocation{
 						{
@@ -247,7 +259,9 @@ func TestDPAReconciler_ValidateVolumeSnapshotLocation(t *testing.T) {
 				},
 				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
 					Configuration: &oadpv1alpha1.ApplicationConfig{
-						Velero: &oadpv1alpha1.VeleroConfig{},
+						Velero: &oadpv1alpha1.VeleroConfig{
+							DefaultPlugins: []oadpv1alpha1.DefaultPlugin{oadpv1alpha1.DefaultPluginGCP},
+						},
 					},
 					SnapshotLocations: []oadpv1alpha1.SnapshotLocation{
 						{
@@ -279,7 +293,9 @@ func TestDPAReconciler_ValidateVolumeSnapshotLocation(t *testing.T) {
 				},
 				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
 					Configuration: &oadpv1alpha1.ApplicationConfig{
-						Velero: &oadpv1alpha1.VeleroConfig{},
+						Velero: &oadpv1alpha1.VeleroConfig{
+							DefaultPlugins: []oadpv1alpha1.DefaultPlugin{oadpv1alpha1.DefaultPluginGCP},
+						},
 					},
 					SnapshotLocations: []oadpv1alpha1.SnapshotLocation{
 						{
@@ -313,7 +329,9 @@ func TestDPAReconciler_ValidateVolumeSnapshotLocation(t *testing.T) {
 				},
 				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
 					Configuration: &oadpv1alpha1.ApplicationConfig{
-						Velero: &oadpv1alpha1.VeleroConfig{},
+						Velero: &oadpv1alpha1.VeleroConfig{
+							DefaultPlugins: []oadpv1alpha1.DefaultPlugin{oadpv1alpha1.DefaultPluginMicrosoftAzure},
+						},
 					},
 					SnapshotLocations: []oadpv1alpha1.SnapshotLocation{
 						{
@@ -342,7 +360,9 @@ func TestDPAReconciler_ValidateVolumeSnapshotLocation(t *testing.T) {
 				},
 				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
 					Configuration: &oadpv1alpha1.ApplicationConfig{
-						Velero: &oadpv1alpha1.VeleroConfig{},
+						Velero: &oadpv1alpha1.VeleroConfig{
+							DefaultPlugins: []oadpv1alpha1.DefaultPlugin{oadpv1alpha1.DefaultPluginMicrosoftAzure},
+						},
 					},
 					SnapshotLocations: []oadpv1alpha1.SnapshotLocation{
 						{
@@ -374,7 +394,9 @@ func TestDPAReconciler_ValidateVolumeSnapshotLocation(t *testing.T) {
 				},
 				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
 					Configuration: &oadpv1alpha1.ApplicationConfig{
-						Velero: &oadpv1alpha1.VeleroConfig{},
+						Velero: &oadpv1alpha1.VeleroConfig{
+							DefaultPlugins: []oadpv1alpha1.DefaultPlugin{oadpv1alpha1.DefaultPluginMicrosoftAzure},
+						},
 					},
 					SnapshotLocations: []oadpv1alpha1.SnapshotLocation{
 						{
@@ -406,7 +428,9 @@ func TestDPAReconciler_ValidateVolumeSnapshotLocation(t *testing.T) {
 				},
 				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
 					Configuration: &oadpv1alpha1.ApplicationConfig{
-						Velero: &oadpv1alpha1.VeleroConfig{},
+						Velero: &oadpv1alpha1.VeleroConfig{
+							DefaultPlugins: []oadpv1alpha1.DefaultPlugin{oadpv1alpha1.DefaultPluginMicrosoftAzure},
+						},
 					},
 					SnapshotLocations: []oadpv1alpha1.SnapshotLocation{
 						{
@@ -438,7 +462,9 @@ func TestDPAReconciler_ValidateVolumeSnapshotLocation(t *testing.T) {
 				},
 				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
 					Configuration: &oadpv1alpha1.ApplicationConfig{
-						Velero: &oadpv1alpha1.VeleroConfig{},
+						Velero: &oadpv1alpha1.VeleroConfig{
+							DefaultPlugins: []oadpv1alpha1.DefaultPlugin{oadpv1alpha1.DefaultPluginMicrosoftAzure},
+						},
 					},
 					SnapshotLocations: []oadpv1alpha1.SnapshotLocation{
 						{
@@ -470,7 +496,9 @@ func TestDPAReconciler_ValidateVolumeSnapshotLocation(t *testing.T) {
 				},
 				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
 					Configuration: &oadpv1alpha1.ApplicationConfig{
-						Velero: &oadpv1alpha1.VeleroConfig{},
+						Velero: &oadpv1alpha1.VeleroConfig{
+							DefaultPlugins: []oadpv1alpha1.DefaultPlugin{oadpv1alpha1.DefaultPluginGCP},
+						},
 					},
 					SnapshotLocations: []oadpv1alpha1.SnapshotLocation{
 						{
@@ -493,6 +521,103 @@ func TestDPAReconciler_ValidateVolumeSnapshotLocation(t *testing.T) {
 				},
 			},
 		},
+
+		{
+			name: "test AWS VSL without AWS plugin",
+			dpa: &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-Velero-VSL",
+					Namespace: "test-ns",
+				},
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{
+							DefaultPlugins: []oadpv1alpha1.DefaultPlugin{oadpv1alpha1.DefaultPluginOpenShift},
+						},
+					},
+					SnapshotLocations: []oadpv1alpha1.SnapshotLocation{
+						{
+							Velero: &velerov1.VolumeSnapshotLocationSpec{
+								Provider: AWSProvider,
+								Config: map[string]string{
+									Region: "us-east-1",
+								},
+							},
+						},
+					},
+				},
+			},
+			want:    false,
+			wantErr: true,
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "cloud-credentials",
+					Namespace: "test-ns",
+				},
+			},
+		},
+		{
+			name: "test Azure VSL without Azure plugin",
+			dpa: &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-Velero-VSL",
+					Namespace: "test-ns",
+				},
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{
+							DefaultPlugins: []oadpv1alpha1.DefaultPlugin{oadpv1alpha1.DefaultPluginGCP},
+						},
+					},
+					SnapshotLocations: []oadpv1alpha1.SnapshotLocation{
+						{
+							Velero: &velerov1.VolumeSnapshotLocationSpec{
+								Provider: AzureProvider,
+							},
+						},
+					},
+				},
+			},
+			want:    false,
+			wantErr: true,
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "cloud-credentials",
+					Namespace: "test-ns",
+				},
+			},
+		},
+		{
+			name: "test deprecated velero.io/aws VSL without AWS plugin",
+			dpa: &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-Velero-VSL",
+					Namespace: "test-ns",
+				},
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{
+							DefaultPlugins: []oadpv1alpha1.DefaultPlugin{oadpv1alpha1.DefaultPluginGCP},
+						},
+					},
+					SnapshotLocations: []oadpv1alpha1.SnapshotLocation{
+						{
+							Velero: &velerov1.VolumeSnapshotLocationSpec{
+								Provider: veleroIOPrefix + AWSProvider,
+							},
+						},
+					},
+				},
+			},
+			want:    false,
+			wantErr: true,
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "cloud-credentials",
+					Namespace: "test-ns",
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -524,6 +649,84 @@ func TestDPAReconciler_ValidateVolumeSnapshotLocation(t *testing.T) {
 
 }
 
+func Test_snapshotLocationPlugin(t *testing.T) {
+	tests := []struct {
+		provider string
+		want     oadpv1alpha1.DefaultPlugin
+	}{
+		{provider: AWSProvider, want: oadpv1alpha1.DefaultPluginAWS},
+		{provider: veleroIOPrefix + GCPProvider, want: oadpv1alpha1.DefaultPluginGCP},
+		{provider: AzureProvider, want: oadpv1alpha1.DefaultPluginMicrosoftAzure},
+		{provider: "example.com/custom", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.provider, func(t *testing.T) {
+			if got := snapshotLocationPlugin(tt.provider); got != tt.want {
+				t.Errorf("snapshotLocationPlugin() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_validateSnapshotLocationCredential(t *testing.T) {
+	tests := []struct {
+		name           string
+		featureFlags   []string
+		credential     *corev1.SecretKeySelector
+		wantErrMessage string
+	}{
+		{
+			name: "credential not set",
+		},
+		{
+			name: "credential set",
+			credential: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "snapshot-credentials"},
+				Key:                  "cloud",
+			},
+		},
+		{
+			name: "credential without key",
+			credential: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "snapshot-credentials"},
+			},
+			wantErrMessage: "Secret key specified in snapshotLocations[0] cannot be empty",
+		},
+		{
+			name:           "credential without name",
+			credential:     &corev1.SecretKeySelector{Key: "cloud"},
+			wantErrMessage: "Secret name specified in snapshotLocations[0] cannot be empty",
+		},
+		{
+			name:         "credential without name with no-secret feature flag",
+			featureFlags: []string{noSecretFeatureFlag},
+			credential:   &corev1.SecretKeySelector{Key: "cloud"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dpa := &oadpv1alpha1.DataProtectionApplication{
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{FeatureFlags: tt.featureFlags},
+					},
+				},
+			}
+			vslSpec := &velerov1.VolumeSnapshotLocationSpec{Provider: AWSProvider, Credential: tt.credential}
+			err := validateSnapshotLocationCredential(dpa, 0, vslSpec)
+			if tt.wantErrMessage == "" {
+				if err != nil {
+					t.Errorf("validateSnapshotLocationCredential() unexpected error = %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErrMessage {
+				t.Errorf("validateSnapshotLocationCredential() error = %v, want %v", err, tt.wantErrMessage)
+			}
+		})
+	}
+}
+
 func TestDPAReconciler_ReconcileVolumeSnapshotLocations(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -557,6 +760,36 @@ func TestDPAReconciler_ReconcileVolumeSnapshotLocations(t *testing.T) {
 			want:    true,
 			wantErr: false,
 		},
+		{
+			name: "VSL credential is set on the velero VSL",
+			dpa: &oadpv1alpha1.DataProtectionApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-Velero-VSL",
+					Namespace: "test-ns",
+				},
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration: &oadpv1alpha1.ApplicationConfig{
+						Velero: &oadpv1alpha1.VeleroConfig{},
+					},
+					SnapshotLocations: []oadpv1alpha1.SnapshotLocation{
+						{
+							Velero: &velerov1.VolumeSnapshotLocationSpec{
+								Provider: AWSProvider,
+								Config: map[string]string{
+									Region: "us-east-1",
+								},
+								Credential: &corev1.SecretKeySelector{
+									LocalObjectReference: corev1.LocalObjectReference{Name: "snapshot-credentials"},
+									Key:                  "cloud",
+								},
+							},
+						},
+					},
+				},
+			},
+			want:    true,
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -610,6 +843,197 @@ func TestDPAReconciler_ReconcileVolumeSnapshotLocations(t *testing.T) {
 			if !reflect.DeepEqual(vsl.OwnerReferences, wantVSL.OwnerReferences) {
 				t.Errorf("ReconcileVolumeSnapshotLocations() expected VSL owner references to be %#v, got %#v", wantVSL.OwnerReferences, vsl.OwnerReferences)
 			}
+			if !reflect.DeepEqual(vsl.Spec.Credential, tt.dpa.Spec.SnapshotLocations[0].Velero.Credential) {
+				t.Errorf("ReconcileVolumeSnapshotLocations() expected VSL credential to be %#v, got %#v", tt.dpa.Spec.SnapshotLocations[0].Velero.Credential, vsl.Spec.Credential)
+			}
+		})
+	}
+}
+
+func TestDPAReconciler_warnSnapshotMoveRegionMismatch(t *testing.T) {
+	newDPA := func(snapshotMoveData *bool, vslRegion string) *oadpv1alpha1.DataProtectionApplication {
+		return &oadpv1alpha1.DataProtectionApplication{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-DPA-CR",
+				Namespace: "test-ns",
+			},
+			Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+				Configuration: &oadpv1alpha1.ApplicationConfig{
+					Velero: &oadpv1alpha1.VeleroConfig{
+						DefaultSnapshotMoveData: snapshotMoveData,
+					},
+				},
+				BackupLocations: []oadpv1alpha1.BackupLocation{
+					{
+						Velero: &velerov1.BackupStorageLocationSpec{
+							Provider: "aws",
+							Config: map[string]string{
+								Region: "us-east-1",
+							},
+						},
+					},
+				},
+				SnapshotLocations: []oadpv1alpha1.SnapshotLocation{
+					{
+						Velero: &velerov1.VolumeSnapshotLocationSpec{
+							Provider: "aws",
+							Config: map[string]string{
+								AWSRegion: vslRegion,
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+	tests := []struct {
+		name           string
+		dpa            *oadpv1alpha1.DataProtectionApplication
+		wantMismatches []string
+	}{
+		{
+			name: "mismatched regions with data move enabled",
+			dpa:  newDPA(pointer.Bool(true), "eu-west-1"),
+			wantMismatches: []string{
+				"snapshotLocations[0] region eu-west-1 differs from backupLocations[0] region us-east-1, moving snapshot data with defaultSnapshotMoveData transfers it across regions",
+			},
+		},
+		{
+			name:           "matching regions with data move enabled",
+			dpa:            newDPA(pointer.Bool(true), "us-east-1"),
+			wantMismatches: []string{},
+		},
+		{
+			name:           "mismatched regions with data move disabled",
+			dpa:            newDPA(pointer.Bool(false), "eu-west-1"),
+			wantMismatches: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := record.NewFakeRecorder(10)
+			r := &DPAReconciler{
+				Log:           logr.Discard(),
+				EventRecorder: recorder,
+			}
+			got := snapshotMoveRegionMismatches(tt.dpa)
+			if !reflect.DeepEqual(got, tt.wantMismatches) {
+				t.Errorf("snapshotMoveRegionMismatches() got = %v, want %v", got, tt.wantMismatches)
+			}
+			r.warnSnapshotMoveRegionMismatch(r.Log, tt.dpa)
+			if len(recorder.Events) != len(tt.wantMismatches) {
+				t.Errorf("warnSnapshotMoveRegionMismatch() emitted %d events, want %d", len(recorder.Events), len(tt.wantMismatches))
+			}
+		})
+	}
+}
+
+func TestDPAReconciler_warnSharedSnapshotLocationCredentials(t *testing.T) {
+	newVSL := func(provider string, secretName string) oadpv1alpha1.SnapshotLocation {
+		return oadpv1alpha1.SnapshotLocation{
+			Velero: &velerov1.VolumeSnapshotLocationSpec{
+				Provider: provider,
+				Credential: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: secretName},
+					Key:                  "cloud",
+				},
+			},
+		}
+	}
+	tests := []struct {
+		name              string
+		snapshotLocations []oadpv1alpha1.SnapshotLocation
+		wantMessages      []string
+	}{
+		{
+			name:              "different providers with distinct credentials",
+			snapshotLocations: []oadpv1alpha1.SnapshotLocation{newVSL("aws", "aws-credentials"), newVSL("gcp", "gcp-credentials")},
+			wantMessages:      []string{},
+		},
+		{
+			name:              "same provider sharing a credential",
+			snapshotLocations: []oadpv1alpha1.SnapshotLocation{newVSL("aws", "cloud-credentials"), newVSL("velero.io/aws", "cloud-credentials")},
+			wantMessages:      []string{},
+		},
+		{
+			name:              "different providers sharing a credential",
+			snapshotLocations: []oadpv1alpha1.SnapshotLocation{newVSL("aws", "cloud-credentials"), newVSL("azure", "cloud-credentials")},
+			wantMessages: []string{
+				"snapshotLocations[1] provider azure uses credential cloud-credentials key cloud of snapshotLocations[0] provider aws, use a credential for each provider",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dpa := &oadpv1alpha1.DataProtectionApplication{
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					SnapshotLocations: tt.snapshotLocations,
+				},
+			}
+			recorder := record.NewFakeRecorder(10)
+			r := &DPAReconciler{
+				Log:           logr.Discard(),
+				EventRecorder: recorder,
+			}
+			got := sharedSnapshotLocationCredentials(dpa)
+			if !reflect.DeepEqual(got, tt.wantMessages) {
+				t.Errorf("sharedSnapshotLocationCredentials() got = %v, want %v", got, tt.wantMessages)
+			}
+			r.warnSharedSnapshotLocationCredentials(r.Log, dpa)
+			if len(recorder.Events) != len(tt.wantMessages) {
+				t.Errorf("warnSharedSnapshotLocationCredentials() emitted %d events, want %d", len(recorder.Events), len(tt.wantMessages))
+			}
+		})
+	}
+}
+
+func Test_missingSnapshotLocationMessage(t *testing.T) {
+	awsSnapshotLocation := []oadpv1alpha1.SnapshotLocation{
+		{Velero: &velerov1.VolumeSnapshotLocationSpec{Provider: AWSProvider, Config: map[string]string{AWSRegion: "us-east-1"}}},
+	}
+	tests := []struct {
+		name              string
+		velero            *oadpv1alpha1.VeleroConfig
+		snapshotLocations []oadpv1alpha1.SnapshotLocation
+		want              string
+	}{
+		{
+			name:   "snapshot capable plugins without snapshot location",
+			velero: &oadpv1alpha1.VeleroConfig{DefaultPlugins: []oadpv1alpha1.DefaultPlugin{oadpv1alpha1.DefaultPluginOpenShift, oadpv1alpha1.DefaultPluginAWS, oadpv1alpha1.DefaultPluginGCP}},
+			want:   "aws, gcp default plugins can take native volume snapshots but no snapshotLocations are configured, persistent volumes will not be snapshotted; add a snapshotLocation, enable the csi default plugin or set defaultVolumesToFSBackup",
+		},
+		{
+			name:              "snapshot capable plugin with snapshot location",
+			velero:            &oadpv1alpha1.VeleroConfig{DefaultPlugins: []oadpv1alpha1.DefaultPlugin{oadpv1alpha1.DefaultPluginAWS}},
+			snapshotLocations: awsSnapshotLocation,
+		},
+		{
+			name:   "snapshot capable plugin with the csi plugin",
+			velero: &oadpv1alpha1.VeleroConfig{DefaultPlugins: []oadpv1alpha1.DefaultPlugin{oadpv1alpha1.DefaultPluginAWS, oadpv1alpha1.DefaultPluginCSI}},
+		},
+		{
+			name: "snapshot capable plugin with file system backup by default",
+			velero: &oadpv1alpha1.VeleroConfig{
+				DefaultPlugins:           []oadpv1alpha1.DefaultPlugin{oadpv1alpha1.DefaultPluginMicrosoftAzure},
+				DefaultVolumesToFSBackup: pointer.Bool(true),
+			},
+		},
+		{
+			name:   "no snapshot capable plugin",
+			velero: &oadpv1alpha1.VeleroConfig{DefaultPlugins: []oadpv1alpha1.DefaultPlugin{oadpv1alpha1.DefaultPluginOpenShift}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dpa := &oadpv1alpha1.DataProtectionApplication{
+				Spec: oadpv1alpha1.DataProtectionApplicationSpec{
+					Configuration:     &oadpv1alpha1.ApplicationConfig{Velero: tt.velero},
+					SnapshotLocations: tt.snapshotLocations,
+				},
+			}
+			if got := missingSnapshotLocationMessage(dpa); got != tt.want {
+				t.Errorf("missingSnapshotLocationMessage() = %q, want %q", got, tt.want)
+			}
 		})
 	}
 }
@@ -25,6 +25,10 @@ type DefaultPluginFields struct {
 	PluginImage        string
 	PluginSecretKey    string
 	PluginName         string
+	// operator env var holding the plugin image bundled with the operator
+	RelatedImageEnvVar string
+	// unsupportedOverrides key replacing the plugin image
+	ImageOverrideKey oadpv1alpha1.UnsupportedImageKey
 }
 
 const (
@@ -40,6 +44,8 @@ var (
 			EnvCredentialsFile: common.AWSSharedCredentialsFileEnvKey,
 			PluginName:         common.VeleroPluginForAWS,
 			PluginSecretKey:    "cloud",
+			RelatedImageEnvVar: "RELATED_IMAGE_VELERO_PLUGIN_FOR_AWS",
+			ImageOverrideKey:   oadpv1alpha1.AWSPluginImageKey,
 		},
 		oadpv1alpha1.DefaultPluginGCP: {
 			IsCloudProvider:    true,
@@ -48,6 +54,8 @@ var (
 			EnvCredentialsFile: common.GCPCredentialsEnvKey,
 			PluginName:         common.VeleroPluginForGCP,
 			PluginSecretKey:    "cloud",
+			RelatedImageEnvVar: "RELATED_IMAGE_VELERO_PLUGIN_FOR_GCP",
+			ImageOverrideKey:   oadpv1alpha1.GCPPluginImageKey,
 		},
 		oadpv1alpha1.DefaultPluginMicrosoftAzure: {
 			IsCloudProvider:    true,
@@ -56,19 +64,27 @@ var (
 			EnvCredentialsFile: common.AzureCredentialsFileEnvKey,
 			PluginName:         common.VeleroPluginForAzure,
 			PluginSecretKey:    "cloud",
+			RelatedImageEnvVar: "RELATED_IMAGE_VELERO_PLUGIN_FOR_MICROSOFT_AZURE",
+			ImageOverrideKey:   oadpv1alpha1.AzurePluginImageKey,
 		},
 		oadpv1alpha1.DefaultPluginOpenShift: {
-			IsCloudProvider: false,
-			PluginName:      common.VeleroPluginForOpenshift,
+			IsCloudProvider:    false,
+			PluginName:         common.VeleroPluginForOpenshift,
+			RelatedImageEnvVar: "RELATED_IMAGE_OPENSHIFT_VELERO_PLUGIN",
+			ImageOverrideKey:   oadpv1alpha1.OpenShiftPluginImageKey,
 		},
 		oadpv1alpha1.DefaultPluginCSI: {
 			IsCloudProvider: false,
 			//TODO: Check if the Registry needs to an upstream one from CSI
-			PluginName: common.VeleroPluginForCSI,
+			PluginName:         common.VeleroPluginForCSI,
+			RelatedImageEnvVar: "RELATED_IMAGE_VELERO_PLUGIN_FOR_CSI",
+			ImageOverrideKey:   oadpv1alpha1.CSIPluginImageKey,
 		},
 		oadpv1alpha1.DefaultPluginKubeVirt: {
-			IsCloudProvider: false,
-			PluginName:      common.KubeVirtPlugin,
+			IsCloudProvider:    false,
+			PluginName:         common.KubeVirtPlugin,
+			RelatedImageEnvVar: "RELATED_IMAGE_KUBEVIRT_VELERO_PLUGIN",
+			ImageOverrideKey:   oadpv1alpha1.KubeVirtPluginImageKey,
 		},
 	}
 )
@@ -194,7 +210,7 @@ func AppendCloudProviderVolumes(dpa *oadpv1alpha1.DataProtectionApplication, ds
 		if cloudProviderMap, ok := PluginSpecificFields[plugin]; ok &&
 			cloudProviderMap.IsCloudProvider && //if plugin is a cloud provider plugin, and one of the following condition is true
 			(!dpa.Spec.Configuration.Velero.NoDefaultBackupLocation || // it has a backup location in OADP/velero context OR
-				dpa.Spec.UnsupportedOverrides[oadpv1alpha1.OperatorTypeKey] == oadpv1alpha1.OperatorTypeMTC) { // OADP is installed via MTC
+				dpa.IsOperatorTypeMTC()) { // OADP is installed via MTC
 
 			pluginNeedsCheck, foundProviderPlugin := providerNeedsDefaultCreds[string(plugin)]
 			if !foundProviderPlugin && !hasCloudStorage {
@@ -292,7 +308,7 @@ func AppendPluginSpecificSpecs(dpa *oadpv1alpha1.DataProtectionApplication, vele
 				continue
 			}
 			if dpa.Spec.Configuration.Velero.NoDefaultBackupLocation &&
-				dpa.Spec.UnsupportedOverrides[oadpv1alpha1.OperatorTypeKey] != oadpv1alpha1.OperatorTypeMTC &&
+				!dpa.IsOperatorTypeMTC() &&
 				pluginSpecificMap.IsCloudProvider {
 				continue
 			}
@@ -4,8 +4,10 @@ import (
 	"context"
 	"errors"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/endpoints"
 	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/s3/s3manager"
 )
 
@@ -41,3 +43,25 @@ func GetBucketRegion(bucket string) (string, error) {
 
 	return "", errors.New("unable to determine bucket's region")
 }
+
+// HeadBucket returns an error if the bucket does not exist or cannot be accessed with the profile of the shared
+// credentials file. s3URL, if set, is used as the S3 endpoint instead of the AWS endpoint of the region.
+func HeadBucket(bucket, region, s3URL string, forcePathStyle bool, credentialsFile, profile string) error {
+	config := aws.Config{
+		Region:           aws.String(region),
+		S3ForcePathStyle: aws.Bool(forcePathStyle),
+	}
+	if s3URL != "" {
+		config.Endpoint = aws.String(s3URL)
+	}
+	sessionInstance, err := session.NewSessionWithOptions(session.Options{
+		Config:            config,
+		Profile:           profile,
+		SharedConfigFiles: []string{credentialsFile},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = s3.New(sessionInstance).HeadBucket(&s3.HeadBucketInput{Bucket: aws.String(bucket)})
+	return err
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/vmware-tanzu/velero/pkg/util/boolptr"
@@ -12,6 +13,10 @@ import (
 	"github.com/openshift/oadp-operator/pkg/velero/client"
 )
 
+// velero has no option to disable repo maintenance and falls back to its default frequency for a zero
+// frequency, ten years is passed instead so that repositories, marked maintained on initialization, are not due
+const disabledRepoMaintenanceFrequency = 87600 * time.Hour
+
 // VeleroServerArgs are the arguments that are passed to the Velero server
 // +kubebuilder:object:generate=true
 type Args struct {
@@ -73,7 +78,11 @@ func (a Args) StringArr(dpaFeatureFlags []string, logLevel string) ([]string, er
 		args = append(args, fmt.Sprintf("--resource-timeout=%s", a.ResourceTimeout.String())) // duration
 	}
 	if a.RepoMaintenanceFrequency != nil {
-		args = append(args, fmt.Sprintf("--default-repo-maintain-frequency=%s", a.RepoMaintenanceFrequency.String())) // duration
+		repoMaintenanceFrequency := *a.RepoMaintenanceFrequency
+		if repoMaintenanceFrequency == 0 {
+			repoMaintenanceFrequency = disabledRepoMaintenanceFrequency
+		}
+		args = append(args, fmt.Sprintf("--default-repo-maintain-frequency=%s", repoMaintenanceFrequency.String())) // duration
 	}
 	// default-volume-snapshot-locations set outside Args
 	if a.DisabledControllers != nil {
@@ -17,7 +17,8 @@ type ServerConfig struct {
 	// pluginDir will be fixed to /plugins
 	// pluginDir
 
-	// The address to expose prometheus metrics
+	// The address to expose prometheus metrics. Binding to a loopback address such as 127.0.0.1:8085 keeps metrics
+	// local to the pod and the operator does not create the velero metrics Service.
 	// +optional
 	MetricsAddress string `json:"metrics-address,omitempty"`
 	// defaultBackupLocation will be defined outside of server config in DataProtectionApplication
@@ -73,6 +74,7 @@ type ServerConfig struct {
 	// +optional
 	FormatFlag string `json:"log-format,omitempty"`
 	// How often (in nanoseconds) 'maintain' is run for backup repositories by default.
+	// Velero cannot disable maintenance, set to 0 to run it every 87600h (10 years) instead, for example when it is run externally.
 	// +optional
 	RepoMaintenanceFrequency *time.Duration `json:"default-repo-maintain-frequency,omitempty"`
 	// How often (in nanoseconds) garbage collection checks for expired backups. (default is 1 hour)